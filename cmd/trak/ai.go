@@ -7,25 +7,36 @@ import (
 )
 
 var aiCmd = &cobra.Command{
-	Use:   "ai <branch>",
+	Use:   "ai [branch]",
 	Short: "Run AI assistant in a track",
 	Long: `Run the AI assistant (toad) in the context of the specified track.
 
-This opens a tmux window for the track and runs 'toad -a codex <path>'.
-Only supported for worktree tracks (not devbox).`,
-	Args: cobra.ExactArgs(1),
-	RunE: runAI,
+This opens the track's window and runs 'toad -a codex <path>'.
+Only supported for worktree tracks (not devbox).
+
+Without a branch, or when several tracks match it, pick the track in a fuzzy
+finder.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runAI,
 }
 
 func runAI(cmd *cobra.Command, args []string) error {
-	branch := args[0]
-
 	opsLayer, cleanup, err := initOps()
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	branch, err := pickTrack(opsLayer, args)
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
 	fmt.Printf("Starting AI assistant for track '%s'...\n", branch)
 
 	if err := opsLayer.RunAI(branch); err != nil {
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/laurent/trak/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var (
+	capturePane  int
+	captureLines int
+	capturePrint bool
+)
+
+var captureCmd = &cobra.Command{
+	Use:   "capture <branch>",
+	Short: "Save the output of a track's tmux pane",
+	Long: `Save the output of a pane of a track's tmux window, scrollback included, to the
+track's log directory, so test output, server logs and agent transcripts outlive
+the scrollback. Captured output can be searched with trak grep.
+
+The window's first pane is captured unless --pane selects another by its tmux
+pane index.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runCapture,
+}
+
+func init() {
+	captureCmd.Flags().IntVarP(&capturePane, "pane", "p", ops.MainPane, "tmux index of the pane to capture, -1 for the first pane")
+	captureCmd.Flags().IntVarP(&captureLines, "lines", "n", 0, "Lines of scrollback to include (0 for all)")
+	captureCmd.Flags().BoolVar(&capturePrint, "print", false, "Print the output instead of saving it")
+}
+
+func runCapture(cmd *cobra.Command, args []string) error {
+	branch := args[0]
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	opts := ops.CaptureOptions{Pane: capturePane, Lines: captureLines}
+	if capturePrint {
+		output, err := opsLayer.CapturePane(branch, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Println(output)
+		return nil
+	}
+
+	path, err := opsLayer.SaveCapture(branch, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Saved to %s\n", path)
+	return nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ciLogsExcerpt bool
+	ciLogsWindow  bool
+	ciLogsAI      bool
+)
+
+var ciCmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Inspect CI for a track",
+	Long:  `Inspect and act on the CI runs of a track's branch.`,
+}
+
+var ciLogsCmd = &cobra.Command{
+	Use:   "logs <branch>",
+	Short: "Show failing CI job logs",
+	Long: `Fetch the logs of the failed jobs from the latest failed CI run of a branch.
+
+By default the logs are shown in $PAGER (or less). Use --window to open them
+in a tmux window instead, and --excerpt to only show the extracted error block.
+Use --ai to ask the configured AI agent in the track's window to fix the failure.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runCILogs,
+}
+
+var ciRerunCmd = &cobra.Command{
+	Use:   "rerun <branch>",
+	Short: "Re-run failed CI jobs",
+	Long: `Re-run only the failed jobs of the latest workflow runs for the head
+commit of a branch's PR.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runCIRerun,
+}
+
+func init() {
+	ciLogsCmd.Flags().BoolVarP(&ciLogsExcerpt, "excerpt", "e", false, "Only show the extracted error block")
+	ciLogsCmd.Flags().BoolVarP(&ciLogsWindow, "window", "w", false, "Open the logs in a tmux window")
+	ciLogsCmd.Flags().BoolVar(&ciLogsAI, "ai", false, "Send the error excerpt to the AI agent in the track's window")
+
+	ciCmd.AddCommand(ciLogsCmd)
+	ciCmd.AddCommand(ciRerunCmd)
+}
+
+func runCILogs(cmd *cobra.Command, args []string) error {
+	branch := args[0]
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	fmt.Printf("Fetching failing CI logs for '%s'...\n", branch)
+
+	logs, err := opsLayer.GetCILogs(branch)
+	if err != nil {
+		return fmt.Errorf("failed to get CI logs: %w", err)
+	}
+
+	logPath, excerptPath, err := opsLayer.SaveCILogs(branch, logs)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Run: %s (%s)\n", logs.RunName, logs.RunURL)
+
+	if ciLogsAI {
+		fmt.Println("Sending error excerpt to AI agent...")
+		if err := opsLayer.SendCIExcerptToAI(branch, excerptPath); err != nil {
+			return fmt.Errorf("failed to send excerpt to AI: %w", err)
+		}
+		return nil
+	}
+
+	path := logPath
+	if ciLogsExcerpt {
+		path = excerptPath
+	}
+
+	if ciLogsWindow {
+		if err := opsLayer.OpenCILogsWindow(branch, path); err != nil {
+			return fmt.Errorf("failed to open logs window: %w", err)
+		}
+		return nil
+	}
+
+	return page(path)
+}
+
+func runCIRerun(cmd *cobra.Command, args []string) error {
+	branch := args[0]
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	names, err := opsLayer.RerunFailedCI(branch)
+	if err != nil {
+		return fmt.Errorf("failed to re-run CI: %w", err)
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No failed workflow runs to re-run.")
+		return nil
+	}
+
+	for _, name := range names {
+		fmt.Printf("Re-running failed jobs of %s\n", name)
+	}
+	return nil
+}
+
+// page displays a file using $PAGER, falling back to less.
+func page(path string) error {
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		pager = "less -R"
+	}
+
+	fields := strings.Fields(pager)
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
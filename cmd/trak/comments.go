@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/laurent/trak/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var commentsCmd = &cobra.Command{
+	Use:   "comments <branch>",
+	Short: "List unresolved review comments on a track's PR",
+	Long: `List the unresolved review threads on a track's pull request.
+
+Enter a thread number to open its file at the commented line in $EDITOR
+inside the track's tmux window, or 'r <number>' to mark it resolved.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runComments,
+}
+
+func runComments(cmd *cobra.Command, args []string) error {
+	branch := args[0]
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	fmt.Println("Fetching review comments...")
+
+	threads, err := opsLayer.ListReviewThreads(branch)
+	if err != nil {
+		return fmt.Errorf("failed to list review comments: %w", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		if len(threads) == 0 {
+			fmt.Println("No unresolved review comments.")
+			return nil
+		}
+
+		printThreads(threads)
+
+		fmt.Print("\nEnter number to open, 'r <number>' to resolve (or 'q' to quit): ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		input = strings.TrimSpace(input)
+		if input == "q" || input == "" {
+			return nil
+		}
+
+		resolve := false
+		if rest, ok := strings.CutPrefix(input, "r "); ok {
+			resolve = true
+			input = strings.TrimSpace(rest)
+		}
+
+		num, err := strconv.Atoi(input)
+		if err != nil || num < 1 || num > len(threads) {
+			return fmt.Errorf("invalid selection: %s", input)
+		}
+		thread := threads[num-1]
+
+		if !resolve {
+			return opsLayer.OpenThreadInEditor(branch, thread)
+		}
+
+		if err := opsLayer.ResolveReviewThread(thread.ID); err != nil {
+			return fmt.Errorf("failed to resolve thread: %w", err)
+		}
+		fmt.Printf("Resolved thread on %s.\n\n", threadLocation(thread))
+		threads = append(threads[:num-1], threads[num:]...)
+	}
+}
+
+// printThreads prints a numbered list of review threads.
+func printThreads(threads []github.ReviewThread) {
+	fmt.Println("\nUnresolved review comments:")
+	for i, t := range threads {
+		body := strings.Join(strings.Fields(t.Body), " ")
+		replies := ""
+		if t.Replies > 0 {
+			replies = fmt.Sprintf(" (+%d)", t.Replies)
+		}
+		fmt.Printf("  %-2d %s  @%s%s\n", i+1, threadLocation(t), t.Author, replies)
+		fmt.Printf("     %s\n", truncate(body, 100))
+	}
+}
+
+// threadLocation formats a thread's file and line as "path:line".
+func threadLocation(t github.ReviewThread) string {
+	if t.Line == 0 {
+		return t.Path
+	}
+	return fmt.Sprintf("%s:%d", t.Path, t.Line)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// completeTracks completes the branch argument of commands acting on a track with the
+// branches of the repo's tracks.
+func completeTracks(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	defer cleanup()
+
+	branches, err := opsLayer.TrackBranches()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return branches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeRemoteBranches completes the branch argument of trak new with the remote branches
+// that have no track yet.
+func completeRemoteBranches(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	defer cleanup()
+
+	branches, err := opsLayer.UntrackedRemoteBranches()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return branches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeValues completes a flag with a fixed set of values.
+func completeValues(values ...string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -6,39 +6,59 @@ import (
 	"os"
 	"strings"
 
+	"github.com/laurent/trak/internal/ops"
 	"github.com/spf13/cobra"
 )
 
 var (
-	deleteRemote bool
-	deleteForce  bool
+	deleteRemote     bool
+	deleteForce      bool
+	deleteKeepWindow bool
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete <branch>",
+	Use:   "delete [branch]",
 	Short: "Delete a track",
 	Long: `Delete a track's local worktree or devbox.
 
 By default, only deletes the local environment and database record.
-Use --remote to also delete the remote branch on GitHub.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runDelete,
+Use --remote to also delete the remote branch on GitHub.
+
+The track's tmux window is closed: running processes get C-c and the window is
+killed once they exit or after tmux.on_delete.grace (2s by default). You are
+warned about editors running in the window that may have unsaved buffers.
+Use --keep-window to leave the window open.
+
+Without a branch, or when several tracks match it, pick the tracks to delete in
+a fuzzy finder: tab selects several. The confirmation names the tracks matched;
+with --force, a partial branch name opens the finder instead.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runDelete,
 }
 
 func init() {
 	deleteCmd.Flags().BoolVarP(&deleteRemote, "remote", "r", false, "Also delete the remote branch")
 	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "Skip confirmation prompt")
+	deleteCmd.Flags().BoolVar(&deleteKeepWindow, "keep-window", false, "Leave the track's tmux window open")
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
-	branch := args[0]
-
 	opsLayer, cleanup, err := initOps()
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	branches, err := pickTracks(opsLayer, args, true, deleteForce)
+	if err != nil {
+		return err
+	}
+	if len(branches) == 0 {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
 	// Confirm deletion unless --force is specified
 	if !deleteForce {
 		action := "local track"
@@ -46,7 +66,26 @@ func runDelete(cmd *cobra.Command, args []string) error {
 			action = "local track AND remote branch"
 		}
 
-		fmt.Printf("Delete %s for branch '%s'? [y/N]: ", action, branch)
+		if !deleteKeepWindow {
+			for _, branch := range branches {
+				warnings, err := opsLayer.WindowWarnings(branch)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "warning: failed to check the tmux window of %s: %v\n", branch, err)
+				}
+				for _, w := range warnings {
+					if len(branches) > 1 {
+						w = branch + ": " + w
+					}
+					fmt.Printf("Warning: %s.\n", w)
+				}
+			}
+		}
+
+		if len(branches) == 1 {
+			fmt.Printf("Delete %s for branch '%s'? [y/N]: ", action, branches[0])
+		} else {
+			fmt.Printf("Delete %s for branches %s? [y/N]: ", action, quoteBranches(branches))
+		}
 
 		reader := bufio.NewReader(os.Stdin)
 		input, err := reader.ReadString('\n')
@@ -61,17 +100,29 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("Deleting track '%s'...\n", branch)
+	opts := ops.DeleteOptions{Remote: deleteRemote, KeepWindow: deleteKeepWindow}
+	for _, branch := range branches {
+		fmt.Printf("Deleting track '%s'...\n", branch)
 
-	if err := opsLayer.DeleteTrack(branch, deleteRemote); err != nil {
-		return fmt.Errorf("failed to delete track: %w", err)
-	}
+		if err := opsLayer.DeleteTrack(branch, opts); err != nil {
+			return fmt.Errorf("failed to delete track %s: %w", branch, err)
+		}
 
-	if deleteRemote {
-		fmt.Println("Track and remote branch deleted.")
-	} else {
-		fmt.Println("Track deleted. Remote branch preserved.")
+		if deleteRemote {
+			fmt.Println("Track and remote branch deleted.")
+		} else {
+			fmt.Println("Track deleted. Remote branch preserved.")
+		}
 	}
 
 	return nil
 }
+
+// quoteBranches lists branches for a prompt, e.g. 'a', 'b' and 'c'.
+func quoteBranches(branches []string) string {
+	quoted := make([]string, len(branches))
+	for i, b := range branches {
+		quoted[i] = "'" + b + "'"
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check tracks and their tmux windows for problems",
+	Long: `Check that tracks and their tmux windows are consistent.
+
+trak finds a track's window by the @trak_track_id option it sets on it, so
+renaming a window is fine. doctor reports worktrees that no longer exist,
+recorded window IDs that are out of date, tracks with several windows, and
+windows tagged with a track that no longer exists.
+
+With --fix, recorded window IDs are corrected and windows named after a branch
+that trak created before it tagged windows are adopted. Nothing is deleted or
+killed; the commands to do so are printed instead.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Fix what can be fixed safely")
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	issues, err := opsLayer.Doctor(doctorFix)
+	if err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("No problems found.")
+		return nil
+	}
+
+	fixable := 0
+	for _, issue := range issues {
+		prefix := ""
+		if issue.Branch != "" {
+			prefix = issue.Branch + ": "
+		}
+		fmt.Printf("%s%s\n", prefix, issue.Problem)
+		if issue.Fixed {
+			fmt.Printf("  fixed: %s\n", issue.Fix)
+		} else {
+			fmt.Printf("  fix: %s\n", issue.Fix)
+			if issue.Fixable && !doctorFix {
+				fixable++
+			}
+		}
+	}
+
+	if fixable > 0 {
+		fmt.Printf("\nRun trak doctor --fix to fix %d of these.\n", fixable)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/cobra"
+)
+
+var grepIgnoreCase bool
+
+var grepCmd = &cobra.Command{
+	Use:   "grep <pattern>",
+	Short: "Search the captured output of the repo's tracks",
+	Long: `Search the output saved by trak capture across the repo's tracks, including
+tracks that have since been deleted. The pattern is a Go regular expression.
+
+Matches are printed as branch:file:line: text, oldest capture first.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGrep,
+}
+
+func init() {
+	grepCmd.Flags().BoolVarP(&grepIgnoreCase, "ignore-case", "i", false, "Match case-insensitively")
+}
+
+func runGrep(cmd *cobra.Command, args []string) error {
+	expr := args[0]
+	if grepIgnoreCase {
+		expr = "(?i)" + expr
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	matches, err := opsLayer.GrepCaptures(pattern)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		fmt.Println("No matches.")
+		return nil
+	}
+
+	for _, m := range matches {
+		fmt.Printf("%s:%s:%d: %s\n", m.Branch, filepath.Base(m.Path), m.Line, m.Text)
+	}
+	return nil
+}
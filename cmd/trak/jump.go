@@ -7,26 +7,40 @@ import (
 )
 
 var jumpCmd = &cobra.Command{
-	Use:   "jump <branch>",
-	Short: "Jump to a track's tmux window",
-	Long: `Open or switch to the tmux window for the specified track.
+	Use:   "jump [branch]",
+	Short: "Jump to a track's window",
+	Long: `Open or switch to the tmux window (or zellij tab) for the specified track.
 
 If the window doesn't exist, it will be created. For worktree tracks,
 the window opens in the worktree directory. For devbox tracks, an SSH
-session is started.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runJump,
+session is started.
+
+Without a multiplexer (mux.backend: print), the cd command to run is
+printed instead, e.g. for eval "$(trak jump <branch>)".
+
+The branch can be abbreviated like with trak path. Without a branch, or when
+several tracks match it, pick the track in a fuzzy finder.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runJump,
 }
 
 func runJump(cmd *cobra.Command, args []string) error {
-	branch := args[0]
-
 	opsLayer, cleanup, err := initOps()
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	branch, err := pickTrack(opsLayer, args)
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
 	if err := opsLayer.JumpToTrack(branch); err != nil {
 		return fmt.Errorf("failed to jump to track: %w", err)
 	}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/github"
+	"github.com/laurent/trak/internal/ops"
 	"github.com/spf13/cobra"
 )
 
@@ -39,8 +41,8 @@ func runList(cmd *cobra.Command, args []string) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
 	// Print header
-	fmt.Fprintln(w, "BRANCH\tTYPE\tGIT\tPR\tCI\tREVIEW\tAGE")
-	fmt.Fprintln(w, "──────\t────\t───\t──\t──\t──────\t───")
+	fmt.Fprintln(w, "BRANCH\tTYPE\tGIT\tPR\tCI\tREVIEW\tISSUE\tAGE")
+	fmt.Fprintln(w, "──────\t────\t───\t──\t──\t──────\t─────\t───")
 
 	for _, t := range tracks {
 		branch := t.Track.Branch
@@ -49,6 +51,9 @@ func runList(cmd *cobra.Command, args []string) error {
 		}
 
 		trackType := string(t.Track.Type)
+		if t.Track.Review {
+			trackType = "review"
+		}
 
 		// Git status
 		gitStatus := t.Status.GitStatus.String()
@@ -71,6 +76,12 @@ func runList(cmd *cobra.Command, args []string) error {
 			reviewStatus = t.Status.Review.Symbol()
 		}
 
+		// Linked issue
+		issueRef := "—"
+		if t.Track.IssueRef != nil {
+			issueRef = *t.Track.IssueRef
+		}
+
 		// Age
 		age := formatAge(t.Track.CreatedAt)
 
@@ -79,11 +90,16 @@ func runList(cmd *cobra.Command, args []string) error {
 			branch = branch + " (stale)"
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			branch, trackType, gitStatus, prStatus, ciStatus, reviewStatus, age)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			branch, trackType, gitStatus, prStatus, ciStatus, reviewStatus, issueRef, age)
 	}
 
 	w.Flush()
+
+	// Only query the quota when something failed, it costs a gh call
+	if ops.StatusWarning(tracks, github.RateLimit{}) != "" {
+		fmt.Fprintf(os.Stderr, "\nwarning: %s\n", ops.StatusWarning(tracks, opsLayer.RateLimit()))
+	}
 	return nil
 }
 
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/laurent/trak/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var logLimit int
+
+var logCmd = &cobra.Command{
+	Use:   "log [branch]",
+	Short: "Show the history of tracks",
+	Long: `Show what happened to tracks: creation, jumps, syncs, pushes, PR creation,
+merges, deletions, conflicts and errors, newest first.
+
+Without a branch, the history of all tracks of the repo is shown, including
+tracks that have since been deleted.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runLog,
+}
+
+func init() {
+	logCmd.Flags().IntVarP(&logLimit, "limit", "n", 50, "Maximum number of events to show (0 for all)")
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	var branch string
+	if len(args) > 0 {
+		branch = args[0]
+	}
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	events, err := opsLayer.ListEvents(branch, logLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	if len(events) == 0 {
+		if branch != "" {
+			fmt.Printf("No history for %s.\n", branch)
+		} else {
+			fmt.Println("No history yet.")
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tBRANCH\tEVENT\tSHA\tDETAIL")
+	fmt.Fprintln(w, "────\t──────\t─────\t───\t──────")
+
+	for _, e := range events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			e.CreatedAt.Local().Format("2006-01-02 15:04"),
+			truncate(e.Branch, 30),
+			e.Kind,
+			ops.EventSHA(e),
+			e.Detail)
+	}
+
+	return w.Flush()
+}
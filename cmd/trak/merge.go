@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/laurent/trak/internal/github"
+	"github.com/laurent/trak/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mergeAuto      bool
+	mergeMethod    string
+	mergeCleanup   bool
+	mergeNoCleanup bool
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <branch>",
+	Short: "Merge a track's PR",
+	Long: `Merge the pull request of a track, or enable auto-merge with --auto.
+
+The merge method defaults to repo.merge_method in the config (squash if unset).
+When repo.cleanup_after_merge is set, or --cleanup is given, the track and its
+remote branch are deleted after a successful merge.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runMerge,
+}
+
+func init() {
+	mergeCmd.Flags().BoolVarP(&mergeAuto, "auto", "a", false, "Enable auto-merge once requirements are met")
+	mergeCmd.Flags().StringVarP(&mergeMethod, "method", "m", "", "Merge method: squash, rebase or merge")
+	mergeCmd.Flags().BoolVar(&mergeCleanup, "cleanup", false, "Delete the track and remote branch after merging")
+	mergeCmd.Flags().BoolVar(&mergeNoCleanup, "no-cleanup", false, "Keep the track after merging")
+	_ = mergeCmd.RegisterFlagCompletionFunc("method", completeValues(github.MergeMethodSquash, github.MergeMethodRebase, github.MergeMethodMerge))
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	branch := args[0]
+
+	if mergeCleanup && mergeNoCleanup {
+		return fmt.Errorf("cannot specify both --cleanup and --no-cleanup")
+	}
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	opts := ops.MergeOptions{Auto: mergeAuto, Method: mergeMethod}
+	if mergeCleanup || mergeNoCleanup {
+		opts.Cleanup = &mergeCleanup
+	}
+
+	result, err := opsLayer.MergeTrack(branch, opts)
+	if err != nil {
+		return fmt.Errorf("merge failed: %w", err)
+	}
+
+	if result.AutoMerge {
+		fmt.Printf("Auto-merge (%s) enabled for '%s'.\n", result.Method, branch)
+		return nil
+	}
+
+	fmt.Printf("Merged '%s' (%s).\n", branch, result.Method)
+	if result.CleanedUp {
+		fmt.Println("Track and remote branch deleted.")
+	}
+	return nil
+}
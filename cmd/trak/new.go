@@ -12,31 +12,50 @@ import (
 var (
 	newWorktree bool
 	newDevbox   bool
+	newIssue    string
+	newPR       string
 )
 
 var newCmd = &cobra.Command{
-	Use:   "new <branch>",
+	Use:   "new [branch]",
 	Short: "Create a new track",
 	Long: `Create a new track for the specified branch.
 
 By default, prompts for track type (worktree or devbox).
-Use --worktree or --devbox to skip the prompt.`,
-	Args: cobra.ExactArgs(1),
-	RunE: runNew,
+Use --worktree or --devbox to skip the prompt.
+
+With --issue, the track is linked to an issue (e.g. 42, owner/repo#42 or PROJ-123)
+and the branch name defaults to the configured issue branch template.
+PRs opened for the track then reference the issue.
+
+With --pr, a worktree track is created at the head branch of an existing PR
+(a number or URL). PRs from forks add a remote for the fork.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeRemoteBranches,
+	RunE:              runNew,
 }
 
 func init() {
 	newCmd.Flags().BoolVarP(&newWorktree, "worktree", "w", false, "Create a worktree track")
 	newCmd.Flags().BoolVarP(&newDevbox, "devbox", "d", false, "Create a devbox track")
+	newCmd.Flags().StringVarP(&newIssue, "issue", "i", "", "Link the track to an issue and derive the branch name from it")
+	newCmd.Flags().StringVar(&newPR, "pr", "", "Create a worktree track from a PR number or URL")
 }
 
 func runNew(cmd *cobra.Command, args []string) error {
-	branch := args[0]
-
 	// Validate flags
 	if newWorktree && newDevbox {
 		return fmt.Errorf("cannot specify both --worktree and --devbox")
 	}
+	if newPR != "" {
+		if len(args) > 0 || newIssue != "" || newDevbox {
+			return fmt.Errorf("--pr cannot be combined with a branch name, --issue or --devbox")
+		}
+		return runNewFromPR(newPR)
+	}
+	if len(args) == 0 && newIssue == "" {
+		return fmt.Errorf("a branch name, --issue or --pr is required")
+	}
 
 	opsLayer, cleanup, err := initOps()
 	if err != nil {
@@ -44,6 +63,22 @@ func runNew(cmd *cobra.Command, args []string) error {
 	}
 	defer cleanup()
 
+	var branch, issueRef string
+	if len(args) > 0 {
+		branch = args[0]
+	}
+	if newIssue != "" {
+		issueBranch, iss, err := opsLayer.IssueBranch(newIssue)
+		if err != nil {
+			return err
+		}
+		if branch == "" {
+			branch = issueBranch
+		}
+		issueRef = iss.Ref
+		fmt.Printf("Issue %s: %s\n", iss.Ref, iss.Title)
+	}
+
 	// Determine track type
 	var trackType string
 	if newWorktree {
@@ -78,6 +113,31 @@ func runNew(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unknown track type: %s", trackType)
 	}
 
+	if issueRef != "" {
+		if err := opsLayer.LinkIssue(branch, issueRef); err != nil {
+			return err
+		}
+		fmt.Printf("Linked to issue %s.\n", issueRef)
+	}
+
+	fmt.Printf("Use 'trak jump %s' to switch to the track.\n", branch)
+	return nil
+}
+
+// runNewFromPR creates a worktree track from an existing PR.
+func runNewFromPR(ref string) error {
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	fmt.Printf("Creating worktree track for PR %s...\n", ref)
+	branch, err := opsLayer.NewTrackFromPR(ref)
+	if err != nil {
+		return fmt.Errorf("failed to create track from PR: %w", err)
+	}
+	fmt.Printf("Worktree track created for branch '%s'.\n", branch)
 	fmt.Printf("Use 'trak jump %s' to switch to the track.\n", branch)
 	return nil
 }
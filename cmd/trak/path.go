@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/laurent/trak/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var pathCmd = &cobra.Command{
+	Use:   "path [branch]",
+	Short: "Print a track's worktree path",
+	Long: `Print the worktree path of a track, e.g. for cd "$(trak path feature)".
+
+The branch can be abbreviated: it matches the exact branch, else the only
+branch containing it, else the only branch containing its letters in order
+("flog" matches feature/login). Without a branch, or when several tracks match
+it, pick the track in a fuzzy finder.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runPath,
+}
+
+var cdCmd = &cobra.Command{
+	Use:   "cd [branch|-]",
+	Short: "Change the shell's directory to a track's worktree",
+	Long: `Change the directory of the calling shell to a track's worktree.
+
+The branch matches like with trak path, and - returns to the track you were
+in before the current one.
+
+A program can't change the directory of the shell that runs it, so this needs
+the shell function installed by trak shell-init. Without it, the path is
+printed.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runCd,
+}
+
+// errNotPicked fails commands whose output is used by the shell when the user cancels the
+// track picker, so that the shell doesn't act on an empty path.
+var errNotPicked = errors.New("no track picked")
+
+func runPath(cmd *cobra.Command, args []string) error {
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	branch, err := pickTrack(opsLayer, args)
+	if err != nil {
+		return err
+	}
+	if branch == "" {
+		return errNotPicked
+	}
+
+	path, err := opsLayer.TrackPath(branch)
+	if err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
+
+func runCd(cmd *cobra.Command, args []string) error {
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	query := ops.PreviousTrack
+	if len(args) == 0 || args[0] != ops.PreviousTrack {
+		if query, err = pickTrack(opsLayer, args); err != nil {
+			return err
+		}
+		if query == "" {
+			return errNotPicked
+		}
+	}
+
+	cwd, _ := os.Getwd()
+	path, err := opsLayer.EnterTrack(query, cwd)
+	if err != nil {
+		return err
+	}
+
+	// The shell function captures the output; printed to a terminal, it's being run without it
+	if isTerminal(os.Stdout) {
+		fmt.Fprintln(os.Stderr, "trak cd needs the shell function to change directory, see trak shell-init --help")
+	}
+	fmt.Println(path)
+	return nil
+}
+
+// isTerminal reports whether f is a terminal rather than a pipe or file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/ops"
+	"github.com/laurent/trak/internal/tui"
+)
+
+// pickTracks resolves the optional branch argument of a command to tracks: the only track
+// matching it (see ops.MatchTrack), else the tracks picked in a fuzzy finder prefilled
+// with it when run in a terminal. With exact, only a full branch name is taken as is,
+// for commands acting without confirmation. It returns no branches if the user cancelled
+// the finder.
+func pickTracks(opsLayer *ops.Ops, args []string, multi, exact bool) ([]string, error) {
+	var query string
+	var matches []db.Track
+	if len(args) > 0 {
+		query = args[0]
+		var err error
+		if matches, err = opsLayer.MatchTracks(query); err != nil {
+			return nil, err
+		}
+		if len(matches) == 1 && (!exact || matches[0].Branch == query) {
+			return []string{matches[0].Branch}, nil
+		}
+	}
+
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stderr) {
+		if query == "" {
+			return nil, fmt.Errorf("branch argument required")
+		}
+		if len(matches) == 1 {
+			return nil, fmt.Errorf("no track for branch %q (did you mean %s?)", query, matches[0].Branch)
+		}
+		_, err := opsLayer.MatchTrack(query)
+		return nil, err
+	}
+	return tui.PickTracks(opsLayer, query, multi)
+}
+
+// pickTrack is pickTracks for commands working on a single track. It returns "" if the
+// user cancelled the finder.
+func pickTrack(opsLayer *ops.Ops, args []string) (string, error) {
+	branches, err := pickTracks(opsLayer, args, false, false)
+	if err != nil || len(branches) == 0 {
+		return "", err
+	}
+	return branches[0], nil
+}
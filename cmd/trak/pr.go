@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/laurent/trak/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prCreateTitle string
+	prCreateDraft bool
+	prCreateReady bool
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Manage a track's pull request",
+	Long:  `Manage the pull request associated with a track's branch.`,
+}
+
+var prCreateCmd = &cobra.Command{
+	Use:   "create <branch>",
+	Short: "Push a track and open a PR",
+	Long: `Push a track's branch and open a pull request, without rebasing.
+
+The title, body, reviewers, labels, assignees and draft state come from the
+"pr" section of the config. The body defaults to the repo's pull request
+template, or a list of the branch's commits.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runPRCreate,
+}
+
+func init() {
+	prCreateCmd.Flags().StringVarP(&prCreateTitle, "title", "t", "", "Override the PR title")
+	prCreateCmd.Flags().BoolVar(&prCreateDraft, "draft", false, "Open the PR as a draft")
+	prCreateCmd.Flags().BoolVar(&prCreateReady, "ready", false, "Open the PR as ready for review")
+
+	prCmd.AddCommand(prCreateCmd)
+}
+
+func runPRCreate(cmd *cobra.Command, args []string) error {
+	branch := args[0]
+
+	if prCreateDraft && prCreateReady {
+		return fmt.Errorf("cannot specify both --draft and --ready")
+	}
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	overrides := ops.PRCreateOptions{Title: prCreateTitle}
+	if prCreateDraft || prCreateReady {
+		draft := prCreateDraft
+		overrides.Draft = &draft
+	}
+
+	fmt.Printf("Creating PR for '%s'...\n", branch)
+
+	prNum, err := opsLayer.CreatePRForTrack(branch, overrides)
+	if err != nil {
+		return fmt.Errorf("failed to create PR: %w", err)
+	}
+
+	fmt.Printf("Created PR #%d\n", prNum)
+	return nil
+}
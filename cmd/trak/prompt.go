@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	promptFormat     string
+	tmuxStatusFormat string
+	tmuxStatusDir    string
+)
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print the current track's status for a shell prompt",
+	Long: `Print the branch, git status, PR, CI and review symbols of the track the
+current directory is in, e.g. "feature ↑2 #42 ✓○". Nothing is printed outside
+tracks.
+
+The status is the one cached by the last refresh (the dashboard, trak list,
+trak watch or the track picker), so no git command or network request slows
+the prompt down. Run trak watch to keep it up to date.
+
+The format uses Go text/template syntax with {{.Branch}}, {{.Git}}, {{.PR}},
+{{.CI}} and {{.Review}}, and defaults to prompt.format in the config:
+
+  bash (~/.bashrc): PS1='$(trak prompt) \$ '
+  zsh (~/.zshrc):   setopt prompt_subst; PROMPT='$(trak prompt) %# '`,
+	Args: cobra.NoArgs,
+	RunE: runPrompt,
+}
+
+var tmuxStatusCmd = &cobra.Command{
+	Use:   "tmux-status",
+	Short: "Print the current track's status for the tmux status bar",
+	Long: `Like trak prompt, with tmux colors: green when clean, passing or approved,
+yellow when dirty or pending, red when failing or changes were requested.
+
+The format defaults to prompt.tmux_format in the config. tmux runs status
+commands outside the pane, so pass the pane's directory:
+
+  set -g status-right '#(trak tmux-status --dir "#{pane_current_path}")'`,
+	Args: cobra.NoArgs,
+	RunE: runTmuxStatus,
+}
+
+func init() {
+	promptCmd.Flags().StringVar(&promptFormat, "format", "", "Format template (default prompt.format)")
+	tmuxStatusCmd.Flags().StringVar(&tmuxStatusFormat, "format", "", "Format template (default prompt.tmux_format)")
+	tmuxStatusCmd.Flags().StringVar(&tmuxStatusDir, "dir", "", "Directory to show the track of (default the current directory)")
+}
+
+func runPrompt(cmd *cobra.Command, args []string) error {
+	cwd, _ := os.Getwd()
+	return printPrompt(cwd, promptFormat, false)
+}
+
+func runTmuxStatus(cmd *cobra.Command, args []string) error {
+	dir := tmuxStatusDir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	return printPrompt(dir, tmuxStatusFormat, true)
+}
+
+// printPrompt prints the prompt for the track dir is in, if any.
+func printPrompt(dir, format string, tmux bool) error {
+	opsLayer, cleanup, err := initCachedOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	prompt, err := opsLayer.Prompt(dir, format, tmux)
+	if err != nil {
+		return err
+	}
+	if prompt != "" {
+		fmt.Println(prompt)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Browse PRs awaiting your review",
+	Long: `Interactively browse the open PRs where your review is requested and
+check one out as a read-only review track.
+
+Review tracks can't be synced, pushed or merged. They are deleted automatically
+once your review is submitted or the PR is closed.`,
+	RunE: runReview,
+}
+
+func runReview(cmd *cobra.Command, args []string) error {
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if deleted, err := opsLayer.CleanupReviewTracks(); err == nil {
+		for _, branch := range deleted {
+			fmt.Printf("Removed review track '%s' (review submitted or PR closed).\n", branch)
+		}
+	}
+
+	fmt.Println("Fetching review requests...")
+
+	reqs, err := opsLayer.ListReviewRequests()
+	if err != nil {
+		return err
+	}
+
+	if len(reqs) == 0 {
+		fmt.Println("No pull requests awaiting your review.")
+		return nil
+	}
+
+	// Display review requests
+	fmt.Println("\nReview requests:")
+	fmt.Println("  #  PR      TITLE                                    AUTHOR       AGE")
+	fmt.Println("  ── ──      ─────                                    ──────       ───")
+
+	for i, r := range reqs {
+		title := r.Title
+		if r.Draft {
+			title = "[draft] " + title
+		}
+		author := r.Author
+		if r.IsFork {
+			author += " (fork)"
+		}
+
+		age := "—"
+		if !r.UpdatedAt.IsZero() {
+			age = formatAge(r.UpdatedAt)
+		}
+
+		fmt.Printf("  %-2d %-7s %-40s %-12s %s\n", i+1, fmt.Sprintf("#%d", r.Number), truncate(title, 40), author, age)
+	}
+
+	// Prompt for selection
+	fmt.Print("\nEnter number to check out for review (or 'q' to quit): ")
+
+	reader := bufio.NewReader(os.Stdin)
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	input = strings.TrimSpace(input)
+	if input == "q" || input == "" {
+		return nil
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil || num < 1 || num > len(reqs) {
+		return fmt.Errorf("invalid selection: %s", input)
+	}
+
+	selected := reqs[num-1]
+
+	fmt.Printf("Creating review track for PR #%d...\n", selected.Number)
+	branch, err := opsLayer.NewReviewTrack(selected.Number)
+	if err != nil {
+		return fmt.Errorf("failed to create review track: %w", err)
+	}
+
+	fmt.Printf("Review track created. Use 'trak jump %s' to switch to it.\n", branch)
+	return nil
+}
@@ -7,6 +7,9 @@ import (
 
 	"github.com/laurent/trak/internal/config"
 	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/forge"
+	"github.com/laurent/trak/internal/issue"
+	"github.com/laurent/trak/internal/mux"
 	"github.com/laurent/trak/internal/ops"
 	"github.com/laurent/trak/internal/tui"
 	"github.com/spf13/cobra"
@@ -37,6 +40,24 @@ func initOps() (*ops.Ops, func(), error) {
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load config: %w", err)
 	}
+	if err := forge.ValidateKind(cfg.Repo.Forge); err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := issue.ValidateKind(cfg.Issue.Tracker); err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if _, err := cfg.Undo.RetentionPeriod(); err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := mux.ValidateKind(cfg.Mux.Backend); err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.Tmux.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := cfg.Prompt.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
 
 	dbPath := config.GetDBPath()
 	database, err := db.Open(dbPath)
@@ -57,6 +78,29 @@ func initOps() (*ops.Ops, func(), error) {
 	return opsLayer, cleanup, nil
 }
 
+// initCachedOps initializes an ops layer that only reads recorded state (see ops.NewCached),
+// for commands that must not run git or call the forge.
+func initCachedOps() (*ops.Ops, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.Prompt.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	database, err := db.Open(config.GetDBPath())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err := database.Migrate(); err != nil {
+		database.Close()
+		return nil, nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return ops.NewCached(database, cfg), func() { database.Close() }, nil
+}
+
 // Execute runs the root command.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -73,4 +117,20 @@ func init() {
 	rootCmd.AddCommand(deleteCmd)
 	rootCmd.AddCommand(aiCmd)
 	rootCmd.AddCommand(remoteCmd)
+	rootCmd.AddCommand(reviewCmd)
+	rootCmd.AddCommand(ciCmd)
+	rootCmd.AddCommand(prCmd)
+	rootCmd.AddCommand(commentsCmd)
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(captureCmd)
+	rootCmd.AddCommand(grepCmd)
+	rootCmd.AddCommand(pathCmd)
+	rootCmd.AddCommand(cdCmd)
+	rootCmd.AddCommand(shellInitCmd)
+	rootCmd.AddCommand(promptCmd)
+	rootCmd.AddCommand(tmuxStatusCmd)
 }
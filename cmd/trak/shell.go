@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// posixShellInit wraps trak for bash and zsh.
+const posixShellInit = `# trak shell integration, e.g. in ~/.zshrc: eval "$(trak shell-init zsh)"
+trak() {
+  if [ "$1" = cd ]; then
+    shift
+    local dir
+    dir="$(command trak cd "$@")" || return
+    builtin cd -- "$dir"
+  else
+    command trak "$@"
+  fi
+}
+`
+
+// fishShellInit wraps trak for fish.
+const fishShellInit = `# trak shell integration, in ~/.config/fish/config.fish: trak shell-init fish | source
+function trak
+    if test (count $argv) -gt 0; and test "$argv[1]" = cd
+        set -l dir (command trak cd $argv[2..-1]); or return
+        builtin cd -- $dir
+    else
+        command trak $argv
+    end
+end
+`
+
+var shellInitCmd = &cobra.Command{
+	Use:   "shell-init bash|zsh|fish",
+	Short: "Print the shell function that makes trak cd work",
+	Long: `Print a shell function wrapping trak, so that trak cd changes the directory
+of your shell. Add it to your shell's startup file:
+
+  bash (~/.bashrc):                 eval "$(trak shell-init bash)"
+  zsh (~/.zshrc):                   eval "$(trak shell-init zsh)"
+  fish (~/.config/fish/config.fish): trak shell-init fish | source`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	RunE:      runShellInit,
+}
+
+func runShellInit(cmd *cobra.Command, args []string) error {
+	switch args[0] {
+	case "bash", "zsh":
+		fmt.Print(posixShellInit)
+	case "fish":
+		fmt.Print(fishShellInit)
+	default:
+		return fmt.Errorf("unsupported shell %q (expected bash, zsh or fish)", args[0])
+	}
+	return nil
+}
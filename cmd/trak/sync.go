@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/laurent/trak/internal/config"
 	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/ops"
 	"github.com/spf13/cobra"
 )
 
@@ -13,29 +15,40 @@ var syncCmd = &cobra.Command{
 	Short: "Sync a track with remote",
 	Long: `Sync a track: fetch, rebase onto default branch, push, and create PR if needed.
 
-If no branch is specified, syncs the current track (if in a worktree).
+Without a branch, or when several tracks match it, pick the tracks to sync in
+a fuzzy finder: tab selects several.
 Returns information about conflicts if the rebase fails.`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runSync,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runSync,
 }
 
 func runSync(cmd *cobra.Command, args []string) error {
-	var branch string
-
-	if len(args) > 0 {
-		branch = args[0]
-	} else {
-		// Try to detect current branch from working directory
-		// For now, require explicit branch
-		return fmt.Errorf("branch argument required (auto-detect not yet implemented)")
-	}
-
 	opsLayer, cleanup, err := initOps()
 	if err != nil {
 		return err
 	}
 	defer cleanup()
 
+	branches, err := pickTracks(opsLayer, args, true, false)
+	if err != nil {
+		return err
+	}
+	if len(branches) == 0 {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	for _, branch := range branches {
+		if err := syncBranch(opsLayer, branch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncBranch syncs one track and reports the results.
+func syncBranch(opsLayer *ops.Ops, branch string) error {
 	fmt.Printf("Syncing track '%s'...\n", branch)
 
 	result, err := opsLayer.SyncTrack(branch)
@@ -62,6 +75,9 @@ func runSync(cmd *cobra.Command, args []string) error {
 	if result.PRCreated {
 		fmt.Printf("Created PR #%d\n", result.PRNumber)
 	}
+	if result.PRError != nil {
+		fmt.Fprintf(os.Stderr, "warning: PR not created: %v\n", result.PRError)
+	}
 
 	fmt.Println("Sync complete.")
 	return nil
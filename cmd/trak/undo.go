@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var (
+	undoList bool
+	undoPush bool
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [branch]",
+	Short: "Undo the last delete or sync of a track",
+	Long: `Undo the most recent delete or sync, optionally of a given branch.
+
+Undoing a delete recreates the worktree from the saved branch tip and restores
+the track's database record. Devbox tracks get a fresh devbox on the branch.
+Undoing a sync resets the worktree to the branch tip before the rebase.
+
+If the operation also changed the remote branch (a sync's force-push or a
+delete with --remote), you are asked whether to force-push the previous remote
+SHA back. Use --push to do so without asking.
+
+Operations can be undone for the configured undo.retention (7 days by default).`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeTracks,
+	RunE:              runUndo,
+}
+
+func init() {
+	undoCmd.Flags().BoolVarP(&undoList, "list", "l", false, "List the operations that can be undone")
+	undoCmd.Flags().BoolVar(&undoPush, "push", false, "Force-push the previous remote SHA back without asking")
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	var branch string
+	if len(args) > 0 {
+		branch = args[0]
+	}
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	entries, err := opsLayer.ListUndoable(branch)
+	if err != nil {
+		return fmt.Errorf("failed to list undoable operations: %w", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("Nothing to undo.")
+		return nil
+	}
+
+	if undoList {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tBRANCH\tOPERATION\tREMOTE")
+		fmt.Fprintln(w, "────\t──────\t─────────\t──────")
+		for _, e := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				e.CreatedAt.Local().Format("2006-01-02 15:04"),
+				truncate(e.Branch, 30),
+				e.Operation,
+				formatUndoRemote(e))
+		}
+		return w.Flush()
+	}
+
+	entry := entries[0]
+	fmt.Printf("Undoing %s of '%s' from %s...\n", entry.Operation, entry.Branch, entry.CreatedAt.Local().Format("2006-01-02 15:04"))
+
+	if err := opsLayer.Undo(entry); err != nil {
+		return fmt.Errorf("failed to undo %s: %w", entry.Operation, err)
+	}
+
+	switch entry.Operation {
+	case db.UndoDelete:
+		fmt.Printf("Track '%s' restored.\n", entry.Branch)
+	case db.UndoSync:
+		fmt.Printf("Track '%s' reset to %s.\n", entry.Branch, ops.ShortSHA(entry.LocalSHA))
+	}
+
+	if entry.RemoteBranch == "" {
+		return nil
+	}
+
+	if !undoPush {
+		fmt.Printf("Force-push %s back to %s/%s? [y/N]: ", ops.ShortSHA(entry.RemoteSHA), entry.RemoteName, entry.RemoteBranch)
+
+		reader := bufio.NewReader(os.Stdin)
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input != "y" && input != "yes" {
+			fmt.Println("Remote branch left as is.")
+			return nil
+		}
+	}
+
+	if err := opsLayer.RestoreRemote(entry); err != nil {
+		return err
+	}
+	fmt.Printf("Restored %s/%s to %s.\n", entry.RemoteName, entry.RemoteBranch, ops.ShortSHA(entry.RemoteSHA))
+
+	return nil
+}
+
+// formatUndoRemote describes the remote branch an undo can restore.
+func formatUndoRemote(e db.UndoEntry) string {
+	if e.RemoteBranch == "" {
+		return "—"
+	}
+	return fmt.Sprintf("%s/%s@%s", e.RemoteName, e.RemoteBranch, ops.ShortSHA(e.RemoteSHA))
+}
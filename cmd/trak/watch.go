@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/laurent/trak/internal/config"
+	"github.com/laurent/trak/internal/notify"
+	"github.com/laurent/trak/internal/ops"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchInterval time.Duration
+	watchSinks    []string
+	watchOnce     bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Notify when tracked PRs change state",
+	Long: `Poll the PRs of all tracks and send a notification when one changes state:
+CI passing or failing, approval, changes requested, new review comments,
+merge or close.
+
+Notifications go to the sinks configured under notify.sinks (desktop by default),
+or those given with --sink: desktop, tmux, bell or command.`,
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 0, "Poll interval (default from config, or 1m)")
+	watchCmd.Flags().StringSliceVar(&watchSinks, "sink", nil, "Notification sinks, overriding the config (desktop, tmux, bell, command)")
+	watchCmd.Flags().BoolVar(&watchOnce, "once", false, "Poll once and exit")
+	_ = watchCmd.RegisterFlagCompletionFunc("sink", completeValues(notify.SinkDesktop, notify.SinkTmux, notify.SinkBell, notify.SinkCommand))
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	interval := watchInterval
+	if interval <= 0 {
+		if interval, err = cfg.Notify.WatchInterval(); err != nil {
+			return err
+		}
+	}
+
+	sinkNames := cfg.Notify.Sinks
+	if len(watchSinks) > 0 {
+		sinkNames = watchSinks
+	}
+	sinks, err := notify.New(sinkNames, cfg.Notify.Command)
+	if err != nil {
+		return err
+	}
+
+	opsLayer, cleanup, err := initOps()
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !watchOnce {
+		fmt.Printf("Watching tracks every %s (Ctrl-C to stop)...\n", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		pollAndNotify(opsLayer, sinks)
+		if watchOnce {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollAndNotify polls for transitions and sends a notification for each.
+// Errors are reported and watching continues.
+func pollAndNotify(opsLayer *ops.Ops, sinks []notify.Sink) {
+	transitions, err := opsLayer.PollTransitions()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return
+	}
+
+	for _, t := range transitions {
+		fmt.Printf("%s  %s: %s\n", time.Now().Format("15:04:05"), t.Branch, t.Message)
+		n := notify.Notification{
+			Branch:  t.Branch,
+			Title:   "trak: " + t.Branch,
+			Message: t.Message,
+			URL:     t.URL,
+		}
+		if err := notify.SendAll(sinks, n); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to notify: %v\n", err)
+		}
+	}
+}
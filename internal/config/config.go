@@ -5,20 +5,184 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the trak configuration.
 type Config struct {
-	Repo  RepoConfig  `yaml:"repo"`
-	Cache CacheConfig `yaml:"cache,omitempty"`
+	Repo   RepoConfig   `yaml:"repo"`
+	AI     AIConfig     `yaml:"ai,omitempty"`
+	PR     PRConfig     `yaml:"pr,omitempty"`
+	Issue  IssueConfig  `yaml:"issue,omitempty"`
+	Notify NotifyConfig `yaml:"notify,omitempty"`
+	Undo   UndoConfig   `yaml:"undo,omitempty"`
+	Mux    MuxConfig    `yaml:"mux,omitempty"`
+	Tmux   TmuxConfig   `yaml:"tmux,omitempty"`
+	Prompt PromptConfig `yaml:"prompt,omitempty"`
+	Cache  CacheConfig  `yaml:"cache,omitempty"`
+}
+
+// MuxConfig selects the terminal multiplexer track windows live in.
+type MuxConfig struct {
+	// Backend is "tmux", "zellij", or "print" for terminals without a multiplexer, where
+	// trak prints the commands that take you to a track instead of switching windows.
+	// When empty it is detected from $TMUX and $ZELLIJ, then from what is installed.
+	Backend string `yaml:"backend,omitempty"`
 }
 
 // RepoConfig contains repository-related configuration.
 type RepoConfig struct {
 	Path   string `yaml:"path"`
 	Remote string `yaml:"remote"`
+	// Host is the forge host, e.g. a GitHub Enterprise Server hostname.
+	// When empty it is inferred from the origin remote of Path. gh is only pointed at an
+	// inferred host it is logged in to (SSH config aliases aren't), else at github.com.
+	Host string `yaml:"host,omitempty"`
+	// Forge is the code hosting service: "github", "gitlab" or "gitea".
+	// When empty it is detected from Host.
+	Forge string `yaml:"forge,omitempty"`
+	// GitHubAPI selects how GitHub is accessed: "gh" (default) shells out to the gh CLI,
+	// "rest" calls the REST API directly with a token from the environment or gh.
+	GitHubAPI string `yaml:"github_api,omitempty"`
+	// MergeMethod is the default PR merge method: "squash", "rebase" or "merge".
+	MergeMethod string `yaml:"merge_method,omitempty"`
+	// CleanupAfterMerge deletes the track and remote branch after trak merges a PR.
+	CleanupAfterMerge bool `yaml:"cleanup_after_merge,omitempty"`
+}
+
+// GitHubAPIREST selects the native REST client for GitHub (see RepoConfig.GitHubAPI).
+const GitHubAPIREST = "rest"
+
+// AIConfig contains settings for the AI assistant run inside track windows.
+type AIConfig struct {
+	// Command is the agent command, invoked with the worktree path as its argument.
+	Command string `yaml:"command,omitempty"`
+}
+
+// DefaultAICommand is the AI agent command used when none is configured.
+const DefaultAICommand = "tc"
+
+// AgentCommand returns the configured AI agent command, or the default.
+func (c AIConfig) AgentCommand() string {
+	if c.Command == "" {
+		return DefaultAICommand
+	}
+	return c.Command
+}
+
+// PRConfig contains settings for pull requests created by trak.
+// Templates use Go text/template syntax, e.g. "{{.FirstCommit}}".
+type PRConfig struct {
+	TitleTemplate    string   `yaml:"title_template,omitempty"`
+	BodyTemplate     string   `yaml:"body_template,omitempty"`
+	BodyTemplateFile string   `yaml:"body_template_file,omitempty"`
+	Reviewers        []string `yaml:"reviewers,omitempty"`
+	Labels           []string `yaml:"labels,omitempty"`
+	Assignees        []string `yaml:"assignees,omitempty"`
+	Draft            bool     `yaml:"draft,omitempty"`
+}
+
+// IssueConfig contains settings for linking tracks to issues.
+type IssueConfig struct {
+	// Tracker is the issue tracker: "github" (default) or "jira".
+	Tracker string `yaml:"tracker,omitempty"`
+	// JiraURL is the Jira base URL, e.g. https://example.atlassian.net.
+	JiraURL string `yaml:"jira_url,omitempty"`
+	// BranchTemplate names branches created for issues, using {{user}}, {{key}} and {{slug}}.
+	BranchTemplate string `yaml:"branch_template,omitempty"`
+	// MentionOnly references the issue from PR bodies without closing it on merge.
+	MentionOnly bool `yaml:"mention_only,omitempty"`
+}
+
+// NotifyConfig contains settings for trak watch notifications.
+type NotifyConfig struct {
+	// Sinks are where notifications go: "desktop" (default), "tmux", "bell" and "command".
+	Sinks []string `yaml:"sinks,omitempty"`
+	// Command is the shell command run by the "command" sink, e.g. a webhook call.
+	Command string `yaml:"command,omitempty"`
+	// Interval is how often trak watch polls, as a Go duration (e.g. "2m").
+	Interval string `yaml:"interval,omitempty"`
+}
+
+// DefaultWatchInterval is the trak watch poll interval used when none is configured.
+const DefaultWatchInterval = time.Minute
+
+// WatchInterval returns the configured poll interval, or the default.
+func (c NotifyConfig) WatchInterval() (time.Duration, error) {
+	if c.Interval == "" {
+		return DefaultWatchInterval, nil
+	}
+	d, err := time.ParseDuration(c.Interval)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid notify interval %q", c.Interval)
+	}
+	return d, nil
+}
+
+// UndoConfig contains settings for trak undo.
+type UndoConfig struct {
+	// Retention is how long deletes and syncs can be undone, as a Go duration (e.g. "72h").
+	Retention string `yaml:"retention,omitempty"`
+}
+
+// DefaultUndoRetention is how long operations can be undone when no retention is configured.
+const DefaultUndoRetention = 7 * 24 * time.Hour
+
+// RetentionPeriod returns the configured undo retention, or the default.
+func (c UndoConfig) RetentionPeriod() (time.Duration, error) {
+	if c.Retention == "" {
+		return DefaultUndoRetention, nil
+	}
+	d, err := time.ParseDuration(c.Retention)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid undo retention %q", c.Retention)
+	}
+	return d, nil
+}
+
+// PromptConfig contains the formats of trak prompt and trak tmux-status. Formats use Go
+// text/template syntax with {{.Branch}}, {{.Git}}, {{.PR}}, {{.CI}} and {{.Review}}, e.g.
+// "{{.Branch}} {{.CI}}".
+type PromptConfig struct {
+	// Format is the trak prompt format. Defaults to DefaultPromptFormat.
+	Format string `yaml:"format,omitempty"`
+	// TmuxFormat is the trak tmux-status format, where the fields carry tmux colors.
+	// Defaults to DefaultPromptFormat.
+	TmuxFormat string `yaml:"tmux_format,omitempty"`
+}
+
+// DefaultPromptFormat shows the branch and git status, then the PR with its CI and review
+// symbols if there is one, e.g. "feature ↑2 #42 ✓○".
+const DefaultPromptFormat = "{{.Branch}}{{with .Git}} {{.}}{{end}}{{with .PR}} {{.}} {{$.CI}}{{$.Review}}{{end}}"
+
+// PromptFormat returns the configured trak prompt format, or the default.
+func (c PromptConfig) PromptFormat() string {
+	if c.Format == "" {
+		return DefaultPromptFormat
+	}
+	return c.Format
+}
+
+// TmuxStatusFormat returns the configured trak tmux-status format, or the default.
+func (c PromptConfig) TmuxStatusFormat() string {
+	if c.TmuxFormat == "" {
+		return DefaultPromptFormat
+	}
+	return c.TmuxFormat
+}
+
+// Validate checks that the formats are valid templates.
+func (c PromptConfig) Validate() error {
+	if _, err := template.New("prompt").Parse(c.PromptFormat()); err != nil {
+		return fmt.Errorf("invalid prompt format: %w", err)
+	}
+	if _, err := template.New("tmux_status").Parse(c.TmuxStatusFormat()); err != nil {
+		return fmt.Errorf("invalid prompt tmux format: %w", err)
+	}
+	return nil
 }
 
 // CacheConfig contains cached values that are updated periodically.
@@ -100,6 +264,28 @@ func Save(cfg *Config) error {
 	return nil
 }
 
+// GetLogDir returns the directory holding the log directories of all repos.
+func GetLogDir() string {
+	return filepath.Join(configDir(), "logs")
+}
+
+// GetCacheDir returns the directory of files cached between runs, e.g. for shell completion.
+func GetCacheDir() string {
+	return filepath.Join(configDir(), "cache")
+}
+
+// GetRepoLogDir returns the directory holding the log directories of a repo's tracks.
+// The slug should be a filesystem-safe identifier for the repo's remote.
+func GetRepoLogDir(repoSlug string) string {
+	return filepath.Join(GetLogDir(), repoSlug)
+}
+
+// GetTrackLogDir returns the directory where logs for a track are stored.
+// The slugs should be filesystem-safe identifiers for the repo's remote and the track.
+func GetTrackLogDir(repoSlug, trackSlug string) string {
+	return filepath.Join(GetRepoLogDir(repoSlug), trackSlug)
+}
+
 // GetDBPath returns the path to the trak database file.
 func GetDBPath() string {
 	return filepath.Join(configDir(), "trak.db")
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestEnsureConfigDir(t *testing.T) {
@@ -209,3 +210,58 @@ func TestEmptyConfig(t *testing.T) {
 		t.Errorf("Repo.Remote = %v, want empty", loaded.Repo.Remote)
 	}
 }
+
+func TestAgentCommand(t *testing.T) {
+	if got := (AIConfig{}).AgentCommand(); got != DefaultAICommand {
+		t.Errorf("AgentCommand() = %q, want default %q", got, DefaultAICommand)
+	}
+	if got := (AIConfig{Command: "claude"}).AgentCommand(); got != "claude" {
+		t.Errorf("AgentCommand() = %q, want %q", got, "claude")
+	}
+}
+
+func TestWatchInterval(t *testing.T) {
+	if got, err := (NotifyConfig{}).WatchInterval(); err != nil || got != DefaultWatchInterval {
+		t.Errorf("WatchInterval() = %v, %v, want default %v", got, err, DefaultWatchInterval)
+	}
+	if got, err := (NotifyConfig{Interval: "30s"}).WatchInterval(); err != nil || got != 30*time.Second {
+		t.Errorf("WatchInterval() = %v, %v, want 30s", got, err)
+	}
+	for _, bad := range []string{"soon", "-1m", "0s"} {
+		if _, err := (NotifyConfig{Interval: bad}).WatchInterval(); err == nil {
+			t.Errorf("WatchInterval() with %q: expected error", bad)
+		}
+	}
+}
+
+func TestRetentionPeriod(t *testing.T) {
+	if got, err := (UndoConfig{}).RetentionPeriod(); err != nil || got != DefaultUndoRetention {
+		t.Errorf("RetentionPeriod() = %v, %v, want default %v", got, err, DefaultUndoRetention)
+	}
+	if got, err := (UndoConfig{Retention: "72h"}).RetentionPeriod(); err != nil || got != 72*time.Hour {
+		t.Errorf("RetentionPeriod() = %v, %v, want 72h", got, err)
+	}
+	for _, bad := range []string{"forever", "-1h", "0s"} {
+		if _, err := (UndoConfig{Retention: bad}).RetentionPeriod(); err == nil {
+			t.Errorf("RetentionPeriod() with %q: expected error", bad)
+		}
+	}
+}
+
+func TestPromptConfig(t *testing.T) {
+	var c PromptConfig
+	if c.PromptFormat() != DefaultPromptFormat || c.TmuxStatusFormat() != DefaultPromptFormat {
+		t.Error("expected the default formats")
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("expected the default formats to be valid, got %v", err)
+	}
+
+	c = PromptConfig{Format: "{{.Branch}}", TmuxFormat: "{{.CI"}
+	if c.PromptFormat() != "{{.Branch}}" {
+		t.Errorf("expected the configured format, got %q", c.PromptFormat())
+	}
+	if err := c.Validate(); err == nil {
+		t.Error("expected an error for an unterminated action")
+	}
+}
@@ -0,0 +1,264 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TmuxConfig contains settings for the tmux windows trak creates for tracks.
+type TmuxConfig struct {
+	// Session is the template of the session name track windows go in, using Go text/template
+	// syntax with {{.Repo}}, {{.Owner}} and {{.Branch}}. Defaults to DefaultSessionTemplate,
+	// or DefaultPerTrackSessionTemplate with PerTrack.
+	Session string `yaml:"session,omitempty"`
+	// PerTrack gives every track its own session instead of a window in the repo's session.
+	PerTrack bool `yaml:"per_track,omitempty"`
+	// Layout is the name of the layout applied to new track windows, "" for a single pane.
+	Layout string `yaml:"layout,omitempty"`
+	// Layouts are the named layouts available to Layout and Tracks.
+	Layouts map[string]Layout `yaml:"layouts,omitempty"`
+	// Tracks overrides the layout for branches matching a glob, e.g. {"review/*": "minimal"}.
+	// The layout name "none" gives matching tracks a single pane.
+	Tracks map[string]string `yaml:"tracks,omitempty"`
+	// OnDelete configures what happens to a track's window when the track is deleted.
+	OnDelete WindowCleanup `yaml:"on_delete,omitempty"`
+}
+
+// WindowCleanup configures how the window of a deleted track is closed.
+type WindowCleanup struct {
+	// Keep leaves windows open when their track is deleted.
+	Keep bool `yaml:"keep,omitempty"`
+	// Grace is how long processes get to exit after C-c before the window is killed,
+	// e.g. "5s". Defaults to DefaultGrace.
+	Grace string `yaml:"grace,omitempty"`
+	// Warn selects the panes trak warns about before deleting a track: "editors" (default)
+	// for panes running one of Editors, "running" for panes running anything but a shell,
+	// or "never".
+	Warn string `yaml:"warn,omitempty"`
+	// Editors are the commands that may hold unsaved buffers. Defaults to DefaultEditors.
+	Editors []string `yaml:"editors,omitempty"`
+}
+
+// Window cleanup warning levels (see WindowCleanup.Warn).
+const (
+	WarnEditors = "editors"
+	WarnRunning = "running"
+	WarnNever   = "never"
+)
+
+// DefaultGrace is how long processes get to exit before a deleted track's window is killed.
+const DefaultGrace = 2 * time.Second
+
+// DefaultEditors are the commands warned about when a track with a window running them is deleted.
+var DefaultEditors = []string{"vi", "vim", "nvim", "emacs", "nano", "hx", "helix", "kak", "micro"}
+
+// GracePeriod returns the configured grace period, or the default.
+func (c WindowCleanup) GracePeriod() (time.Duration, error) {
+	if c.Grace == "" {
+		return DefaultGrace, nil
+	}
+	d, err := time.ParseDuration(c.Grace)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tmux on_delete grace %q: %w", c.Grace, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid tmux on_delete grace %q: must not be negative", c.Grace)
+	}
+	return d, nil
+}
+
+// WarnLevel returns the configured warning level, or the default.
+func (c WindowCleanup) WarnLevel() string {
+	if c.Warn == "" {
+		return WarnEditors
+	}
+	return c.Warn
+}
+
+// EditorCommands returns the configured editors, or the defaults.
+func (c WindowCleanup) EditorCommands() []string {
+	if len(c.Editors) == 0 {
+		return DefaultEditors
+	}
+	return c.Editors
+}
+
+// Layout describes the panes of a track window.
+type Layout struct {
+	// Panes are created in order. The first pane is the window's initial pane.
+	Panes []Pane `yaml:"panes"`
+	// Select is a tmux layout applied once all panes exist, e.g. "main-vertical" or "tiled".
+	Select string `yaml:"select,omitempty"`
+}
+
+// Pane describes one pane of a layout.
+type Pane struct {
+	// Name identifies the pane so later panes can split it.
+	Name string `yaml:"name,omitempty"`
+	// Split is where the pane goes relative to the pane it splits: "below" (default) or "right".
+	// Ignored for the first pane.
+	Split string `yaml:"split,omitempty"`
+	// From is the name of the pane to split. Defaults to the previous pane.
+	From string `yaml:"from,omitempty"`
+	// Size is the pane's size as lines/columns or a percentage, e.g. "20" or "30%".
+	Size string `yaml:"size,omitempty"`
+	// Dir is the pane's start directory, relative to the worktree unless absolute.
+	Dir string `yaml:"dir,omitempty"`
+	// Command is run in the pane once it's created, e.g. "nvim".
+	Command string `yaml:"command,omitempty"`
+	// Focus selects the pane once the layout is applied. Defaults to the first pane.
+	Focus bool `yaml:"focus,omitempty"`
+}
+
+// Pane split directions.
+const (
+	SplitBelow = "below"
+	SplitRight = "right"
+)
+
+// Default session name templates (see TmuxConfig.Session).
+const (
+	DefaultSessionTemplate         = "{{.Repo}}"
+	DefaultPerTrackSessionTemplate = "{{.Repo}}-{{.Branch}}"
+)
+
+// NoLayout is the layout name that disables layouts for a track (see TmuxConfig.Tracks).
+const NoLayout = "none"
+
+// SessionData is the data available to the session name template.
+type SessionData struct {
+	Repo   string // Repository name, e.g. "trak" for "laurent/trak"
+	Owner  string // Repository owner or group, e.g. "laurent"
+	Branch string
+}
+
+// SessionName renders the session name template for a branch of a remote ("owner/repo").
+func (c TmuxConfig) SessionName(remote, branch string) (string, error) {
+	t, err := template.New("session").Parse(c.SessionTemplate())
+	if err != nil {
+		return "", fmt.Errorf("failed to parse tmux session template: %w", err)
+	}
+
+	data := SessionData{Repo: path.Base(remote), Owner: path.Dir(remote), Branch: branch}
+	if data.Owner == "." {
+		data.Owner = ""
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render tmux session template: %w", err)
+	}
+	name := strings.TrimSpace(b.String())
+	if name == "" {
+		return "", fmt.Errorf("tmux session template %q renders an empty name", c.SessionTemplate())
+	}
+	return name, nil
+}
+
+// SessionTemplate returns the configured session name template, or the default.
+func (c TmuxConfig) SessionTemplate() string {
+	if c.Session != "" {
+		return c.Session
+	}
+	if c.PerTrack {
+		return DefaultPerTrackSessionTemplate
+	}
+	return DefaultSessionTemplate
+}
+
+// LayoutFor returns the layout for a track's window, or nil for a single pane.
+// An override matching the branch wins over the default layout; if several match,
+// the longest pattern wins.
+func (c TmuxConfig) LayoutFor(branch string) (*Layout, error) {
+	name := c.Layout
+
+	patterns := make([]string, 0, len(c.Tracks))
+	for pattern := range c.Tracks {
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, branch); ok {
+			name = c.Tracks[pattern]
+			break
+		}
+	}
+
+	if name == "" || name == NoLayout {
+		return nil, nil
+	}
+	layout, ok := c.Layouts[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tmux layout %q", name)
+	}
+	return &layout, nil
+}
+
+// Validate checks that layouts are well-formed and that every referenced layout exists.
+func (c TmuxConfig) Validate() error {
+	if _, err := template.New("session").Parse(c.SessionTemplate()); err != nil {
+		return fmt.Errorf("invalid tmux session template: %w", err)
+	}
+	if c.PerTrack && !strings.Contains(c.SessionTemplate(), ".Branch") {
+		return fmt.Errorf("tmux session template %q must use {{.Branch}} with per_track", c.Session)
+	}
+	if c.Layout != "" && c.Layout != NoLayout {
+		if _, ok := c.Layouts[c.Layout]; !ok {
+			return fmt.Errorf("unknown tmux layout %q", c.Layout)
+		}
+	}
+	for pattern, name := range c.Tracks {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tmux track pattern %q: %w", pattern, err)
+		}
+		if _, ok := c.Layouts[name]; !ok && name != NoLayout {
+			return fmt.Errorf("unknown tmux layout %q for tracks matching %q", name, pattern)
+		}
+	}
+	if _, err := c.OnDelete.GracePeriod(); err != nil {
+		return err
+	}
+	switch c.OnDelete.WarnLevel() {
+	case WarnEditors, WarnRunning, WarnNever:
+	default:
+		return fmt.Errorf("tmux on_delete warn must be %q, %q or %q, got %q", WarnEditors, WarnRunning, WarnNever, c.OnDelete.Warn)
+	}
+	for name, layout := range c.Layouts {
+		if err := layout.validate(); err != nil {
+			return fmt.Errorf("invalid tmux layout %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (l Layout) validate() error {
+	if len(l.Panes) == 0 {
+		return fmt.Errorf("no panes")
+	}
+	names := make(map[string]bool)
+	for i, p := range l.Panes {
+		switch p.Split {
+		case "", SplitBelow, SplitRight:
+		default:
+			return fmt.Errorf("pane %d: split must be %q or %q, got %q", i+1, SplitBelow, SplitRight, p.Split)
+		}
+		if p.From != "" && !names[p.From] {
+			return fmt.Errorf("pane %d: splits unknown pane %q (panes can only split earlier ones)", i+1, p.From)
+		}
+		if p.Name != "" {
+			if names[p.Name] {
+				return fmt.Errorf("duplicate pane name %q", p.Name)
+			}
+			names[p.Name] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestTmuxConfigYAML(t *testing.T) {
+	input := `
+layout: dev
+layouts:
+  dev:
+    select: main-vertical
+    panes:
+      - name: editor
+        command: nvim
+        focus: true
+      - name: shell
+        split: right
+        size: 40%
+      - name: tests
+        from: shell
+        dir: internal
+        command: go test ./...
+tracks:
+  "review/*": none
+`
+	var c TmuxConfig
+	if err := yaml.Unmarshal([]byte(input), &c); err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	dev := c.Layouts["dev"]
+	if len(dev.Panes) != 3 || dev.Select != "main-vertical" {
+		t.Fatalf("unexpected layout: %+v", dev)
+	}
+	if p := dev.Panes[1]; p.Split != SplitRight || p.Size != "40%" {
+		t.Errorf("unexpected shell pane: %+v", p)
+	}
+	if p := dev.Panes[2]; p.From != "shell" || p.Dir != "internal" || p.Command != "go test ./..." {
+		t.Errorf("unexpected tests pane: %+v", p)
+	}
+}
+
+func TestLayoutFor(t *testing.T) {
+	c := TmuxConfig{
+		Layout: "dev",
+		Layouts: map[string]Layout{
+			"dev":     {Panes: []Pane{{Name: "editor"}, {Name: "shell"}}},
+			"minimal": {Panes: []Pane{{Name: "shell"}}},
+		},
+		Tracks: map[string]string{
+			"review/*":      NoLayout,
+			"alice/*":       "minimal",
+			"alice/spike-*": "dev",
+		},
+	}
+
+	tests := []struct {
+		branch string
+		want   int // number of panes, 0 for no layout
+	}{
+		{"feature", 2},
+		{"review/pr-42", 0},
+		{"alice/fix", 1},
+		{"alice/spike-cache", 2}, // longest pattern wins
+	}
+	for _, tt := range tests {
+		layout, err := c.LayoutFor(tt.branch)
+		if err != nil {
+			t.Fatalf("LayoutFor(%q) failed: %v", tt.branch, err)
+		}
+		got := 0
+		if layout != nil {
+			got = len(layout.Panes)
+		}
+		if got != tt.want {
+			t.Errorf("LayoutFor(%q) has %d panes, want %d", tt.branch, got, tt.want)
+		}
+	}
+
+	if layout, err := (TmuxConfig{}).LayoutFor("feature"); layout != nil || err != nil {
+		t.Errorf("expected no layout by default, got %+v, %v", layout, err)
+	}
+	if _, err := (TmuxConfig{Layout: "missing"}).LayoutFor("feature"); err == nil {
+		t.Error("expected error for unknown layout")
+	}
+}
+
+func TestTmuxConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TmuxConfig
+		wantErr string
+	}{
+		{"unknown default", TmuxConfig{Layout: "dev"}, `unknown tmux layout "dev"`},
+		{"unknown override", TmuxConfig{Tracks: map[string]string{"x/*": "dev"}}, `unknown tmux layout "dev"`},
+		{"bad pattern", TmuxConfig{Tracks: map[string]string{"[": NoLayout}}, "invalid tmux track pattern"},
+		{"no panes", TmuxConfig{Layouts: map[string]Layout{"dev": {}}}, "no panes"},
+		{"bad split", TmuxConfig{Layouts: map[string]Layout{"dev": {Panes: []Pane{{}, {Split: "left"}}}}}, "split must be"},
+		{"unknown from", TmuxConfig{Layouts: map[string]Layout{"dev": {Panes: []Pane{{}, {From: "editor"}}}}}, `splits unknown pane "editor"`},
+		{"duplicate name", TmuxConfig{Layouts: map[string]Layout{"dev": {Panes: []Pane{{Name: "a"}, {Name: "a"}}}}}, `duplicate pane name "a"`},
+		{"bad session", TmuxConfig{Session: "{{.Repo"}, "invalid tmux session template"},
+		{"bad grace", TmuxConfig{OnDelete: WindowCleanup{Grace: "soon"}}, "invalid tmux on_delete grace"},
+		{"bad warn", TmuxConfig{OnDelete: WindowCleanup{Warn: "always"}}, "on_delete warn must be"},
+		{"per track without branch", TmuxConfig{Session: "{{.Repo}}", PerTrack: true}, "must use {{.Branch}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionName(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TmuxConfig
+		remote string
+		want   string
+	}{
+		{"default", TmuxConfig{}, "laurent/trak", "trak"},
+		{"per track", TmuxConfig{PerTrack: true}, "laurent/trak", "trak-feature/login"},
+		{"template", TmuxConfig{Session: "{{.Owner}}-{{.Repo}}"}, "group/sub/api", "group/sub-api"},
+		{"no owner", TmuxConfig{Session: "{{.Owner}}{{.Repo}}"}, "api", "api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.SessionName(tt.remote, "feature/login")
+			if err != nil || got != tt.want {
+				t.Errorf("SessionName() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := (TmuxConfig{Session: "{{.Owner}}"}).SessionName("api", "x"); err == nil {
+		t.Error("expected error for an empty session name")
+	}
+}
+
+func TestWindowCleanup(t *testing.T) {
+	var c WindowCleanup
+	if d, err := c.GracePeriod(); err != nil || d != DefaultGrace {
+		t.Errorf("GracePeriod() = %v, %v, want default", d, err)
+	}
+	if c.WarnLevel() != WarnEditors || len(c.EditorCommands()) != len(DefaultEditors) {
+		t.Errorf("expected default warnings, got %q %v", c.WarnLevel(), c.EditorCommands())
+	}
+
+	c = WindowCleanup{Grace: "500ms", Warn: WarnNever, Editors: []string{"code"}}
+	if d, err := c.GracePeriod(); err != nil || d != 500*time.Millisecond {
+		t.Errorf("GracePeriod() = %v, %v, want 500ms", d, err)
+	}
+	if c.WarnLevel() != WarnNever || c.EditorCommands()[0] != "code" {
+		t.Errorf("unexpected settings: %q %v", c.WarnLevel(), c.EditorCommands())
+	}
+	if _, err := (WindowCleanup{Grace: "-1s"}).GracePeriod(); err == nil {
+		t.Error("expected error for a negative grace period")
+	}
+}
@@ -3,6 +3,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -29,6 +30,75 @@ type Track struct {
 	DevboxName   *string // devbox name, nil for worktree
 	CreatedAt    time.Time
 	LastAccessed *time.Time
+	PRNumber     *int    // associated PR number, nil if not recorded
+	Review       bool    // read-only track for reviewing someone else's PR
+	IssueRef     *string // linked issue, e.g. "#42" or "PROJ-123", nil if none
+	TmuxWindow   *string // tmux window ID, e.g. "@4", nil if no window was created yet
+}
+
+// EventKind identifies what happened to a track.
+type EventKind string
+
+const (
+	EventCreate   EventKind = "create"
+	EventJump     EventKind = "jump"
+	EventSync     EventKind = "sync"
+	EventPush     EventKind = "push"
+	EventPRCreate EventKind = "pr_create"
+	EventMerge    EventKind = "merge"
+	EventDelete   EventKind = "delete"
+	EventConflict EventKind = "conflict"
+	EventError    EventKind = "error"
+	EventUndo     EventKind = "undo"
+)
+
+// Event is an entry in the history of a track.
+type Event struct {
+	ID        int64
+	RemoteURL string
+	Branch    string
+	Kind      EventKind
+	Detail    string
+	BeforeSHA string // head SHA before the operation, "" if not applicable
+	AfterSHA  string // head SHA after the operation, "" if not applicable
+	CreatedAt time.Time
+}
+
+// UndoOperation identifies a destructive operation that can be undone.
+type UndoOperation string
+
+const (
+	UndoDelete UndoOperation = "delete"
+	UndoSync   UndoOperation = "sync"
+)
+
+// UndoEntry records the state of a track before a destructive operation.
+type UndoEntry struct {
+	ID           int64
+	RemoteURL    string
+	Branch       string
+	Operation    UndoOperation
+	Track        Track  // the track's database row before the operation
+	LocalSHA     string // local branch tip before the operation
+	RemoteName   string // git remote of RemoteBranch, e.g. "origin"
+	RemoteBranch string // remote branch that was overwritten or deleted, "" if none was
+	RemoteSHA    string // tip of RemoteBranch before the operation
+	CreatedAt    time.Time
+}
+
+// trackColumns lists the columns selected for a Track, in scan order.
+const trackColumns = `id, branch, remote_url, head_sha, type, path, devbox_name, created_at, last_accessed, pr_number, is_review, issue_ref, tmux_window`
+
+// trackMigrations lists columns added to the tracks table after its initial schema.
+// Each column is added by Migrate if it doesn't exist yet.
+var trackMigrations = []struct {
+	column     string
+	definition string
+}{
+	{"pr_number", "INTEGER"},
+	{"is_review", "INTEGER NOT NULL DEFAULT 0"},
+	{"issue_ref", "TEXT"},
+	{"tmux_window", "TEXT"},
 }
 
 // DB wraps a SQLite database connection.
@@ -61,7 +131,7 @@ func (db *DB) Close() error {
 	return nil
 }
 
-// Migrate creates the database tables if they don't exist.
+// Migrate creates the database tables if they don't exist and adds any missing columns.
 func (db *DB) Migrate() error {
 	schema := `
 	CREATE TABLE IF NOT EXISTS tracks (
@@ -76,18 +146,92 @@ func (db *DB) Migrate() error {
 		last_accessed TIMESTAMP,
 		UNIQUE(remote_url, branch)
 	);
+	CREATE TABLE IF NOT EXISTS track_snapshots (
+		remote_url TEXT NOT NULL,
+		branch TEXT NOT NULL,
+		snapshot TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (remote_url, branch)
+	);
+	CREATE TABLE IF NOT EXISTS track_statuses (
+		remote_url TEXT NOT NULL,
+		branch TEXT NOT NULL,
+		status TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (remote_url, branch)
+	);
+	CREATE TABLE IF NOT EXISTS track_events (
+		id INTEGER PRIMARY KEY,
+		remote_url TEXT NOT NULL,
+		branch TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		detail TEXT NOT NULL DEFAULT '',
+		before_sha TEXT NOT NULL DEFAULT '',
+		after_sha TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS track_events_branch ON track_events (remote_url, branch);
+	CREATE TABLE IF NOT EXISTS undo_journal (
+		id INTEGER PRIMARY KEY,
+		remote_url TEXT NOT NULL,
+		branch TEXT NOT NULL,
+		operation TEXT NOT NULL,
+		track TEXT NOT NULL,
+		local_sha TEXT NOT NULL DEFAULT '',
+		remote_name TEXT NOT NULL DEFAULT '',
+		remote_branch TEXT NOT NULL DEFAULT '',
+		remote_sha TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL,
+		undone INTEGER NOT NULL DEFAULT 0
+	);
 	`
 	if _, err := db.conn.Exec(schema); err != nil {
 		return fmt.Errorf("failed to create schema: %w", err)
 	}
+
+	for _, m := range trackMigrations {
+		if err := db.addColumnIfMissing("tracks", m.column, m.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumnIfMissing adds a column to a table unless it already exists.
+func (db *DB) addColumnIfMissing(table, column, definition string) error {
+	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return fmt.Errorf("failed to inspect table %s: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+
+	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)
+	if _, err := db.conn.Exec(query); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
 	return nil
 }
 
 // InsertTrack inserts a new track into the database.
 func (db *DB) InsertTrack(track Track) error {
 	query := `
-	INSERT INTO tracks (branch, remote_url, head_sha, type, path, devbox_name, created_at, last_accessed)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	INSERT INTO tracks (branch, remote_url, head_sha, type, path, devbox_name, created_at, last_accessed, pr_number, is_review, issue_ref, tmux_window)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	createdAt := track.CreatedAt
@@ -104,6 +248,10 @@ func (db *DB) InsertTrack(track Track) error {
 		track.DevboxName,
 		createdAt,
 		track.LastAccessed,
+		track.PRNumber,
+		track.Review,
+		track.IssueRef,
+		track.TmuxWindow,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert track: %w", err)
@@ -115,7 +263,7 @@ func (db *DB) InsertTrack(track Track) error {
 func (db *DB) UpdateTrack(track Track) error {
 	query := `
 	UPDATE tracks
-	SET head_sha = ?, type = ?, path = ?, devbox_name = ?, last_accessed = ?
+	SET head_sha = ?, type = ?, path = ?, devbox_name = ?, last_accessed = ?, pr_number = ?, is_review = ?, issue_ref = ?, tmux_window = ?
 	WHERE remote_url = ? AND branch = ?
 	`
 
@@ -125,6 +273,10 @@ func (db *DB) UpdateTrack(track Track) error {
 		track.Path,
 		track.DevboxName,
 		track.LastAccessed,
+		track.PRNumber,
+		track.Review,
+		track.IssueRef,
+		track.TmuxWindow,
 		track.RemoteURL,
 		track.Branch,
 	)
@@ -163,11 +315,7 @@ func (db *DB) DeleteTrack(remoteURL, branch string) error {
 
 // GetTrack retrieves a track by remote URL and branch.
 func (db *DB) GetTrack(remoteURL, branch string) (*Track, error) {
-	query := `
-	SELECT id, branch, remote_url, head_sha, type, path, devbox_name, created_at, last_accessed
-	FROM tracks
-	WHERE remote_url = ? AND branch = ?
-	`
+	query := `SELECT ` + trackColumns + ` FROM tracks WHERE remote_url = ? AND branch = ?`
 
 	row := db.conn.QueryRow(query, remoteURL, branch)
 	return scanTrack(row)
@@ -175,11 +323,7 @@ func (db *DB) GetTrack(remoteURL, branch string) (*Track, error) {
 
 // ListTracks retrieves all tracks from the database.
 func (db *DB) ListTracks() ([]Track, error) {
-	query := `
-	SELECT id, branch, remote_url, head_sha, type, path, devbox_name, created_at, last_accessed
-	FROM tracks
-	ORDER BY last_accessed DESC NULLS LAST, created_at DESC
-	`
+	query := `SELECT ` + trackColumns + ` FROM tracks ORDER BY last_accessed DESC NULLS LAST, created_at DESC`
 
 	rows, err := db.conn.Query(query)
 	if err != nil {
@@ -241,70 +385,390 @@ func (db *DB) UpdateHeadSHA(remoteURL, branch, sha string) error {
 	return nil
 }
 
-// rowScanner is an interface satisfied by both *sql.Row and *sql.Rows.
-type rowScanner interface {
-	Scan(dest ...any) error
+// SetIssueRef links a track to an issue.
+func (db *DB) SetIssueRef(remoteURL, branch, ref string) error {
+	query := `UPDATE tracks SET issue_ref = ? WHERE remote_url = ? AND branch = ?`
+
+	result, err := db.conn.Exec(query, ref, remoteURL, branch)
+	if err != nil {
+		return fmt.Errorf("failed to set issue ref: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("track not found")
+	}
+	return nil
 }
 
-func scanTrack(row *sql.Row) (*Track, error) {
-	var track Track
-	var trackType string
-	var createdAt string
-	var lastAccessed sql.NullString
+// SetTmuxWindow records the tmux window ID of a track. An empty ID clears it.
+func (db *DB) SetTmuxWindow(remoteURL, branch, windowID string) error {
+	query := `UPDATE tracks SET tmux_window = ? WHERE remote_url = ? AND branch = ?`
 
-	err := row.Scan(
-		&track.ID,
-		&track.Branch,
-		&track.RemoteURL,
-		&track.HeadSHA,
-		&trackType,
-		&track.Path,
-		&track.DevboxName,
-		&createdAt,
-		&lastAccessed,
+	var value *string
+	if windowID != "" {
+		value = &windowID
+	}
+
+	result, err := db.conn.Exec(query, value, remoteURL, branch)
+	if err != nil {
+		return fmt.Errorf("failed to set tmux window: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("track not found")
+	}
+	return nil
+}
+
+// GetSnapshot returns the last status snapshot saved for a track, or "" if none was saved.
+// Snapshots are opaque to the database; ops stores them as JSON.
+func (db *DB) GetSnapshot(remoteURL, branch string) (string, error) {
+	query := `SELECT snapshot FROM track_snapshots WHERE remote_url = ? AND branch = ?`
+
+	var snapshot string
+	err := db.conn.QueryRow(query, remoteURL, branch).Scan(&snapshot)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// SaveSnapshot stores the status snapshot of a track, replacing the previous one.
+func (db *DB) SaveSnapshot(remoteURL, branch, snapshot string) error {
+	query := `
+	INSERT INTO track_snapshots (remote_url, branch, snapshot, updated_at)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT (remote_url, branch) DO UPDATE SET snapshot = excluded.snapshot, updated_at = excluded.updated_at
+	`
+
+	if _, err := db.conn.Exec(query, remoteURL, branch, snapshot, time.Now()); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// DeleteSnapshot removes the status snapshot of a track, if any.
+func (db *DB) DeleteSnapshot(remoteURL, branch string) error {
+	query := `DELETE FROM track_snapshots WHERE remote_url = ? AND branch = ?`
+
+	if _, err := db.conn.Exec(query, remoteURL, branch); err != nil {
+		return fmt.Errorf("failed to delete snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the last status saved for a track and when it was saved, or "" if
+// none was saved. Statuses are opaque to the database; ops stores them as JSON.
+func (db *DB) GetStatus(remoteURL, branch string) (string, time.Time, error) {
+	query := `SELECT status, updated_at FROM track_statuses WHERE remote_url = ? AND branch = ?`
+
+	var status, updatedAt string
+	err := db.conn.QueryRow(query, remoteURL, branch).Scan(&status, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", time.Time{}, nil
+	}
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get status: %w", err)
+	}
+	updated, err := parseTimestamp(updatedAt)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to parse status time: %w", err)
+	}
+	return status, updated, nil
+}
+
+// SaveStatus stores the status of a track, replacing the previous one.
+func (db *DB) SaveStatus(remoteURL, branch, status string) error {
+	query := `
+	INSERT INTO track_statuses (remote_url, branch, status, updated_at)
+	VALUES (?, ?, ?, ?)
+	ON CONFLICT (remote_url, branch) DO UPDATE SET status = excluded.status, updated_at = excluded.updated_at
+	`
+
+	if _, err := db.conn.Exec(query, remoteURL, branch, status, time.Now()); err != nil {
+		return fmt.Errorf("failed to save status: %w", err)
+	}
+	return nil
+}
+
+// DeleteStatus removes the saved status of a track, if any.
+func (db *DB) DeleteStatus(remoteURL, branch string) error {
+	query := `DELETE FROM track_statuses WHERE remote_url = ? AND branch = ?`
+
+	if _, err := db.conn.Exec(query, remoteURL, branch); err != nil {
+		return fmt.Errorf("failed to delete status: %w", err)
+	}
+	return nil
+}
+
+// InsertEvent appends an event to the history of a track.
+// Events are not tied to the tracks table, so they outlive deleted tracks.
+func (db *DB) InsertEvent(event Event) error {
+	query := `
+	INSERT INTO track_events (remote_url, branch, kind, detail, before_sha, after_sha, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	createdAt := event.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	_, err := db.conn.Exec(query,
+		event.RemoteURL,
+		event.Branch,
+		string(event.Kind),
+		event.Detail,
+		event.BeforeSHA,
+		event.AfterSHA,
+		createdAt,
 	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("failed to scan track: %w", err)
+		return fmt.Errorf("failed to insert event: %w", err)
 	}
+	return nil
+}
 
-	track.Type = TrackType(trackType)
+// ListEvents returns the most recent events for a remote, newest first.
+// An empty branch returns events for all branches. A limit of 0 or less returns all events.
+func (db *DB) ListEvents(remoteURL, branch string, limit int) ([]Event, error) {
+	query := `SELECT id, remote_url, branch, kind, detail, before_sha, after_sha, created_at FROM track_events WHERE remote_url = ?`
+	args := []any{remoteURL}
+	if branch != "" {
+		query += ` AND branch = ?`
+		args = append(args, branch)
+	}
+	query += ` ORDER BY id DESC`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
 
-	// Parse created_at
-	t, err := time.Parse("2006-01-02 15:04:05", createdAt)
+	rows, err := db.conn.Query(query, args...)
 	if err != nil {
-		// Try alternative format
-		t, err = time.Parse(time.RFC3339, createdAt)
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]Event, 0)
+	for rows.Next() {
+		var event Event
+		var kind, createdAt string
+		err := rows.Scan(
+			&event.ID,
+			&event.RemoteURL,
+			&event.Branch,
+			&kind,
+			&event.Detail,
+			&event.BeforeSHA,
+			&event.AfterSHA,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		event.Kind = EventKind(kind)
+
+		t, err := parseTimestamp(createdAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse created_at: %w", err)
 		}
+		event.CreatedAt = t
+
+		events = append(events, event)
 	}
-	track.CreatedAt = t
 
-	// Parse last_accessed if present
-	if lastAccessed.Valid {
-		t, err := time.Parse("2006-01-02 15:04:05", lastAccessed.String)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return events, nil
+}
+
+// InsertUndoEntry journals the state of a track before a destructive operation.
+// Returns the ID of the new entry.
+func (db *DB) InsertUndoEntry(entry UndoEntry) (int64, error) {
+	query := `
+	INSERT INTO undo_journal (remote_url, branch, operation, track, local_sha, remote_name, remote_branch, remote_sha, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	trackJSON, err := json.Marshal(entry.Track)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode track: %w", err)
+	}
+
+	// Stored in UTC so entries can be compared by time in SQL
+	createdAt := entry.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	createdAt = createdAt.UTC()
+
+	result, err := db.conn.Exec(query,
+		entry.RemoteURL,
+		entry.Branch,
+		string(entry.Operation),
+		string(trackJSON),
+		entry.LocalSHA,
+		entry.RemoteName,
+		entry.RemoteBranch,
+		entry.RemoteSHA,
+		createdAt,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert undo entry: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// ListUndoEntries returns the entries of a remote that haven't been undone yet and were
+// journaled after since, newest first. An empty branch returns entries for all branches.
+func (db *DB) ListUndoEntries(remoteURL, branch string, since time.Time) ([]UndoEntry, error) {
+	query := `SELECT id, remote_url, branch, operation, track, local_sha, remote_name, remote_branch, remote_sha, created_at
+	FROM undo_journal WHERE remote_url = ? AND undone = 0 AND created_at >= ?`
+	args := []any{remoteURL, since.UTC()}
+	if branch != "" {
+		query += ` AND branch = ?`
+		args = append(args, branch)
+	}
+	query += ` ORDER BY id DESC`
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list undo entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]UndoEntry, 0)
+	for rows.Next() {
+		var entry UndoEntry
+		var operation, trackJSON, createdAt string
+		err := rows.Scan(
+			&entry.ID,
+			&entry.RemoteURL,
+			&entry.Branch,
+			&operation,
+			&trackJSON,
+			&entry.LocalSHA,
+			&entry.RemoteName,
+			&entry.RemoteBranch,
+			&entry.RemoteSHA,
+			&createdAt,
+		)
 		if err != nil {
-			t, err = time.Parse(time.RFC3339, lastAccessed.String)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse last_accessed: %w", err)
-			}
+			return nil, fmt.Errorf("failed to scan undo entry: %w", err)
 		}
-		track.LastAccessed = &t
+		entry.Operation = UndoOperation(operation)
+
+		if err := json.Unmarshal([]byte(trackJSON), &entry.Track); err != nil {
+			return nil, fmt.Errorf("failed to decode track: %w", err)
+		}
+
+		t, err := parseTimestamp(createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse created_at: %w", err)
+		}
+		entry.CreatedAt = t
+
+		entries = append(entries, entry)
 	}
 
-	return &track, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating undo entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// MarkUndone records that an entry was undone, so it can't be undone twice.
+func (db *DB) MarkUndone(id int64) error {
+	result, err := db.conn.Exec(`UPDATE undo_journal SET undone = 1 WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark undo entry as undone: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("undo entry not found")
+	}
+	return nil
+}
+
+// DeleteUndoEntry removes an entry, e.g. when the operation it journaled failed.
+func (db *DB) DeleteUndoEntry(id int64) error {
+	if _, err := db.conn.Exec(`DELETE FROM undo_journal WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete undo entry: %w", err)
+	}
+	return nil
+}
+
+// PruneUndoEntries removes entries that were undone or journaled before the given time.
+// Returns the IDs of the removed entries.
+func (db *DB) PruneUndoEntries(before time.Time) ([]int64, error) {
+	rows, err := db.conn.Query(`DELETE FROM undo_journal WHERE undone = 1 OR created_at < ? RETURNING id`, before.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune undo entries: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan undo entry id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating undo entries: %w", err)
+	}
+
+	return ids, nil
+}
+
+// rowScanner is an interface satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTrack(row *sql.Row) (*Track, error) {
+	track, err := scanTrackFrom(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return track, err
 }
 
 func scanTrackRow(rows *sql.Rows) (*Track, error) {
+	return scanTrackFrom(rows)
+}
+
+// scanTrackFrom scans a row of trackColumns into a Track.
+// sql.ErrNoRows is returned unwrapped so callers can detect it.
+func scanTrackFrom(row rowScanner) (*Track, error) {
 	var track Track
 	var trackType string
 	var createdAt string
 	var lastAccessed sql.NullString
+	var prNumber sql.NullInt64
 
-	err := rows.Scan(
+	err := row.Scan(
 		&track.ID,
 		&track.Branch,
 		&track.RemoteURL,
@@ -314,34 +778,50 @@ func scanTrackRow(rows *sql.Rows) (*Track, error) {
 		&track.DevboxName,
 		&createdAt,
 		&lastAccessed,
+		&prNumber,
+		&track.Review,
+		&track.IssueRef,
+		&track.TmuxWindow,
 	)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to scan track: %w", err)
 	}
 
 	track.Type = TrackType(trackType)
 
+	if prNumber.Valid {
+		n := int(prNumber.Int64)
+		track.PRNumber = &n
+	}
+
 	// Parse created_at
-	t, err := time.Parse("2006-01-02 15:04:05", createdAt)
+	t, err := parseTimestamp(createdAt)
 	if err != nil {
-		t, err = time.Parse(time.RFC3339, createdAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse created_at: %w", err)
-		}
+		return nil, fmt.Errorf("failed to parse created_at: %w", err)
 	}
 	track.CreatedAt = t
 
 	// Parse last_accessed if present
 	if lastAccessed.Valid {
-		t, err := time.Parse("2006-01-02 15:04:05", lastAccessed.String)
+		t, err := parseTimestamp(lastAccessed.String)
 		if err != nil {
-			t, err = time.Parse(time.RFC3339, lastAccessed.String)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse last_accessed: %w", err)
-			}
+			return nil, fmt.Errorf("failed to parse last_accessed: %w", err)
 		}
 		track.LastAccessed = &t
 	}
 
 	return &track, nil
 }
+
+// parseTimestamp parses a timestamp as stored by SQLite or by the Go driver.
+func parseTimestamp(value string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02 15:04:05", value)
+	if err != nil {
+		// Try alternative format
+		t, err = time.Parse(time.RFC3339, value)
+	}
+	return t, err
+}
@@ -44,6 +44,109 @@ func TestMigrate(t *testing.T) {
 	}
 }
 
+func TestMigrateAddsColumns(t *testing.T) {
+	db, err := Open(":memory:")
+	if err != nil {
+		t.Fatalf("failed to open db: %v", err)
+	}
+	defer db.Close()
+
+	// Schema as created before review tracks existed
+	_, err = db.conn.Exec(`
+	CREATE TABLE tracks (
+		id INTEGER PRIMARY KEY,
+		branch TEXT NOT NULL,
+		remote_url TEXT NOT NULL,
+		head_sha TEXT NOT NULL,
+		type TEXT NOT NULL CHECK (type IN ('worktree', 'devbox')),
+		path TEXT,
+		devbox_name TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		last_accessed TIMESTAMP,
+		UNIQUE(remote_url, branch)
+	);
+	INSERT INTO tracks (branch, remote_url, head_sha, type) VALUES ('old', 'owner/repo', 'abc', 'worktree');
+	`)
+	if err != nil {
+		t.Fatalf("failed to create old schema: %v", err)
+	}
+
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	got, err := db.GetTrack("owner/repo", "old")
+	if err != nil {
+		t.Fatalf("failed to get track: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected track, got nil")
+	}
+	if got.PRNumber != nil {
+		t.Errorf("pr_number = %v, want nil", *got.PRNumber)
+	}
+	if got.Review {
+		t.Error("is_review = true, want false")
+	}
+	if got.IssueRef != nil {
+		t.Errorf("issue_ref = %v, want nil", *got.IssueRef)
+	}
+}
+
+func TestSetIssueRef(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	if err := db.InsertTrack(Track{Branch: "PROJ-1-fix", RemoteURL: "owner/repo", HeadSHA: "abc", Type: TrackTypeWorktree}); err != nil {
+		t.Fatalf("failed to insert track: %v", err)
+	}
+	if err := db.SetIssueRef("owner/repo", "PROJ-1-fix", "PROJ-1"); err != nil {
+		t.Fatalf("failed to set issue ref: %v", err)
+	}
+
+	got, err := db.GetTrack("owner/repo", "PROJ-1-fix")
+	if err != nil {
+		t.Fatalf("failed to get track: %v", err)
+	}
+	if got.IssueRef == nil || *got.IssueRef != "PROJ-1" {
+		t.Errorf("issue_ref = %v, want PROJ-1", got.IssueRef)
+	}
+
+	if err := db.SetIssueRef("owner/repo", "missing", "PROJ-2"); err == nil {
+		t.Error("expected error for missing track")
+	}
+}
+
+func TestInsertAndGetReviewTrack(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	prNumber := 42
+	track := Track{
+		Branch:    "review/pr-42",
+		RemoteURL: "owner/repo",
+		HeadSHA:   "abc123",
+		Type:      TrackTypeWorktree,
+		PRNumber:  &prNumber,
+		Review:    true,
+	}
+
+	if err := db.InsertTrack(track); err != nil {
+		t.Fatalf("failed to insert track: %v", err)
+	}
+
+	got, err := db.GetTrack(track.RemoteURL, track.Branch)
+	if err != nil {
+		t.Fatalf("failed to get track: %v", err)
+	}
+	if got.PRNumber == nil || *got.PRNumber != 42 {
+		t.Errorf("pr_number = %v, want 42", got.PRNumber)
+	}
+	if !got.Review {
+		t.Error("is_review = false, want true")
+	}
+}
+
 func TestInsertAndGetTrack(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -417,3 +520,220 @@ func TestTypeConstraint(t *testing.T) {
 		t.Error("expected error for invalid type")
 	}
 }
+
+func TestSnapshots(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	got, err := db.GetSnapshot("owner/repo", "feature")
+	if err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	if got != "" {
+		t.Errorf("expected no snapshot, got %q", got)
+	}
+
+	if err := db.SaveSnapshot("owner/repo", "feature", `{"ci":"pending"}`); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+	if err := db.SaveSnapshot("owner/repo", "feature", `{"ci":"success"}`); err != nil {
+		t.Fatalf("failed to replace snapshot: %v", err)
+	}
+
+	got, _ = db.GetSnapshot("owner/repo", "feature")
+	if got != `{"ci":"success"}` {
+		t.Errorf("expected latest snapshot, got %q", got)
+	}
+
+	if err := db.DeleteSnapshot("owner/repo", "feature"); err != nil {
+		t.Fatalf("failed to delete snapshot: %v", err)
+	}
+	got, _ = db.GetSnapshot("owner/repo", "feature")
+	if got != "" {
+		t.Errorf("expected snapshot to be deleted, got %q", got)
+	}
+}
+
+func TestStatuses(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	got, updated, err := db.GetStatus("owner/repo", "feature")
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+	if got != "" || !updated.IsZero() {
+		t.Errorf("expected no status, got %q at %v", got, updated)
+	}
+
+	before := time.Now().Add(-time.Second)
+	if err := db.SaveStatus("owner/repo", "feature", `{"pr":1}`); err != nil {
+		t.Fatalf("failed to save status: %v", err)
+	}
+	if err := db.SaveStatus("owner/repo", "feature", `{"pr":2}`); err != nil {
+		t.Fatalf("failed to replace status: %v", err)
+	}
+
+	got, updated, _ = db.GetStatus("owner/repo", "feature")
+	if got != `{"pr":2}` {
+		t.Errorf("expected latest status, got %q", got)
+	}
+	if updated.Before(before) {
+		t.Errorf("expected the save time, got %v", updated)
+	}
+
+	if err := db.DeleteStatus("owner/repo", "feature"); err != nil {
+		t.Fatalf("failed to delete status: %v", err)
+	}
+	got, _, _ = db.GetStatus("owner/repo", "feature")
+	if got != "" {
+		t.Errorf("expected status to be deleted, got %q", got)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	events := []Event{
+		{RemoteURL: "owner/repo", Branch: "feature", Kind: EventCreate, AfterSHA: "aaa"},
+		{RemoteURL: "owner/repo", Branch: "feature", Kind: EventSync, Detail: "rebased", BeforeSHA: "aaa", AfterSHA: "bbb"},
+		{RemoteURL: "owner/repo", Branch: "other", Kind: EventCreate},
+		{RemoteURL: "other/repo", Branch: "feature", Kind: EventCreate},
+	}
+	for _, e := range events {
+		if err := db.InsertEvent(e); err != nil {
+			t.Fatalf("failed to insert event: %v", err)
+		}
+	}
+
+	got, err := db.ListEvents("owner/repo", "feature", 0)
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(got))
+	}
+	if got[0].Kind != EventSync || got[0].BeforeSHA != "aaa" || got[0].AfterSHA != "bbb" || got[0].Detail != "rebased" {
+		t.Errorf("expected newest sync event first, got %+v", got[0])
+	}
+	if got[0].CreatedAt.IsZero() {
+		t.Error("expected created_at to be set")
+	}
+
+	all, _ := db.ListEvents("owner/repo", "", 0)
+	if len(all) != 3 {
+		t.Errorf("expected 3 events for the remote, got %d", len(all))
+	}
+
+	limited, _ := db.ListEvents("owner/repo", "", 1)
+	if len(limited) != 1 || limited[0].Branch != "other" {
+		t.Errorf("expected only the newest event, got %+v", limited)
+	}
+
+	// Events outlive the track they belong to.
+	if err := db.InsertTrack(Track{Branch: "feature", RemoteURL: "owner/repo", HeadSHA: "aaa", Type: TrackTypeWorktree}); err != nil {
+		t.Fatalf("failed to insert track: %v", err)
+	}
+	if err := db.DeleteTrack("owner/repo", "feature"); err != nil {
+		t.Fatalf("failed to delete track: %v", err)
+	}
+	got, _ = db.ListEvents("owner/repo", "feature", 0)
+	if len(got) != 2 {
+		t.Errorf("expected events to survive track deletion, got %d", len(got))
+	}
+}
+
+func TestUndoJournal(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	path := "/tmp/wt"
+	trk := Track{Branch: "feature", RemoteURL: "owner/repo", HeadSHA: "aaa", Type: TrackTypeWorktree, Path: &path}
+
+	old, err := db.InsertUndoEntry(UndoEntry{
+		RemoteURL: "owner/repo", Branch: "feature", Operation: UndoSync, Track: trk,
+		LocalSHA: "aaa", CreatedAt: time.Now().Add(-48 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to insert undo entry: %v", err)
+	}
+	id, err := db.InsertUndoEntry(UndoEntry{
+		RemoteURL: "owner/repo", Branch: "feature", Operation: UndoDelete, Track: trk,
+		LocalSHA: "bbb", RemoteName: "origin", RemoteBranch: "feature", RemoteSHA: "ccc",
+	})
+	if err != nil {
+		t.Fatalf("failed to insert undo entry: %v", err)
+	}
+
+	entries, err := db.ListUndoEntries("owner/repo", "", time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("failed to list undo entries: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry within the window, got %d", len(entries))
+	}
+	got := entries[0]
+	if got.ID != id || got.Operation != UndoDelete || got.RemoteSHA != "ccc" || got.RemoteBranch != "feature" {
+		t.Errorf("unexpected entry: %+v", got)
+	}
+	if got.Track.Path == nil || *got.Track.Path != path || got.Track.HeadSHA != "aaa" {
+		t.Errorf("expected track row to round-trip, got %+v", got.Track)
+	}
+
+	if err := db.MarkUndone(id); err != nil {
+		t.Fatalf("failed to mark undone: %v", err)
+	}
+	entries, _ = db.ListUndoEntries("owner/repo", "feature", time.Time{})
+	if len(entries) != 1 || entries[0].ID != old {
+		t.Errorf("expected only the old entry to remain undoable, got %+v", entries)
+	}
+
+	pruned, err := db.PruneUndoEntries(time.Now().Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("failed to prune: %v", err)
+	}
+	if len(pruned) != 2 {
+		t.Errorf("expected the undone and the expired entry to be pruned, got %v", pruned)
+	}
+}
+
+func TestSetTmuxWindow(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	track := Track{
+		Branch:    "feature-window",
+		RemoteURL: "https://github.com/user/repo",
+		HeadSHA:   "abc123",
+		Type:      TrackTypeWorktree,
+	}
+	if err := db.InsertTrack(track); err != nil {
+		t.Fatalf("failed to insert track: %v", err)
+	}
+
+	got, _ := db.GetTrack(track.RemoteURL, track.Branch)
+	if got.TmuxWindow != nil {
+		t.Errorf("expected no tmux window, got %q", *got.TmuxWindow)
+	}
+
+	if err := db.SetTmuxWindow(track.RemoteURL, track.Branch, "@4"); err != nil {
+		t.Fatalf("failed to set tmux window: %v", err)
+	}
+	got, _ = db.GetTrack(track.RemoteURL, track.Branch)
+	if got.TmuxWindow == nil || *got.TmuxWindow != "@4" {
+		t.Errorf("expected tmux window @4, got %v", got.TmuxWindow)
+	}
+
+	if err := db.SetTmuxWindow(track.RemoteURL, track.Branch, ""); err != nil {
+		t.Fatalf("failed to clear tmux window: %v", err)
+	}
+	got, _ = db.GetTrack(track.RemoteURL, track.Branch)
+	if got.TmuxWindow != nil {
+		t.Errorf("expected tmux window to be cleared, got %q", *got.TmuxWindow)
+	}
+
+	if err := db.SetTmuxWindow(track.RemoteURL, "missing", "@4"); err == nil {
+		t.Error("expected error for missing track")
+	}
+}
@@ -0,0 +1,168 @@
+// Package forge abstracts the code hosting service a repository lives on.
+// GitHub is backed by the gh CLI, GitLab and Gitea by their REST APIs.
+package forge
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// PR represents a pull request (merge request on GitLab).
+type PR struct {
+	Number       int
+	Branch       string
+	State        string // "open", "closed", "merged"
+	CIStatus     string // "success", "failure", "pending", "unknown"
+	ReviewStatus string // "approved", "changes_requested", "pending", "unknown"
+}
+
+// RemoteBranch represents a remote branch with metadata.
+type RemoteBranch struct {
+	Name       string
+	LastCommit string
+	Age        time.Duration
+}
+
+// PROptions configures a pull request created with CreatePR.
+type PROptions struct {
+	Title     string
+	Body      string
+	Reviewers []string // Usernames, "@me" for the current user
+	Labels    []string
+	Assignees []string // Usernames, "@me" for the current user
+	Draft     bool
+}
+
+// Forge is a code hosting service. Remotes are given as "owner/repo"
+// (or "group/subgroup/repo" on GitLab).
+type Forge interface {
+	// Name returns the forge kind, e.g. "github".
+	Name() string
+	// ListMyPRs returns all open PRs authored by the current user.
+	ListMyPRs(remote string) ([]PR, error)
+	// GetPRForBranch returns the most recent PR for a branch, or nil if none exists.
+	GetPRForBranch(remote, branch string) (*PR, error)
+	// GetPR returns the PR with the given number, or nil if none exists.
+	GetPR(remote string, number int) (*PR, error)
+	// PRURL returns the web URL of a PR.
+	PRURL(remote string, number int) string
+	// CreatePR creates a PR and returns its number.
+	CreatePR(remote, branch, baseBranch string, opts PROptions) (int, error)
+	// GetDefaultBranch returns the repository's default branch.
+	GetDefaultBranch(remote string) (string, error)
+	// ListMyBranches returns remote branches where the current user has open PRs.
+	ListMyBranches(remote string) ([]RemoteBranch, error)
+}
+
+// Supported forge kinds.
+const (
+	KindGitHub = "github"
+	KindGitLab = "gitlab"
+	KindGitea  = "gitea"
+)
+
+// Environment variables holding API tokens for the REST backends.
+const (
+	GitLabTokenEnv = "GITLAB_TOKEN"
+	GiteaTokenEnv  = "GITEA_TOKEN"
+)
+
+// ValidateKind returns an error if kind is neither empty nor a supported forge.
+func ValidateKind(kind string) error {
+	switch kind {
+	case "", KindGitHub, KindGitLab, KindGitea:
+		return nil
+	default:
+		return fmt.Errorf("unsupported forge %q (expected %s, %s or %s)", kind, KindGitHub, KindGitLab, KindGitea)
+	}
+}
+
+// DetectKind guesses the forge kind from a hostname, defaulting to GitHub.
+func DetectKind(host string) string {
+	h := strings.ToLower(host)
+	switch {
+	case strings.Contains(h, "gitlab"):
+		return KindGitLab
+	case strings.Contains(h, "gitea"), strings.Contains(h, "forgejo"), h == "codeberg.org":
+		return KindGitea
+	default:
+		return KindGitHub
+	}
+}
+
+// New returns the forge for a repo. An empty kind is detected from the host.
+// The GitHub backend uses gh's own host configuration (see github.SetHost).
+func New(kind, host string) (Forge, error) {
+	if err := ValidateKind(kind); err != nil {
+		return nil, err
+	}
+	if kind == "" {
+		kind = DetectKind(host)
+	}
+
+	switch kind {
+	case KindGitLab:
+		if host == "" {
+			host = "gitlab.com"
+		}
+		return NewGitLab("https://"+host, os.Getenv(GitLabTokenEnv)), nil
+	case KindGitea:
+		if host == "" {
+			return nil, fmt.Errorf("gitea forge requires a host")
+		}
+		return NewGitea("https://"+host, os.Getenv(GiteaTokenEnv)), nil
+	default:
+		return GitHub{}, nil
+	}
+}
+
+// ciStatusFromPipeline converts a GitLab pipeline or Gitea commit status to our standard values.
+func ciStatusFromPipeline(status string) string {
+	switch strings.ToLower(status) {
+	case "success":
+		return "success"
+	case "failed", "failure", "error":
+		return "failure"
+	case "pending", "running", "created", "preparing", "scheduled", "waiting_for_resource", "manual":
+		return "pending"
+	default:
+		return "unknown"
+	}
+}
+
+// resolveMe replaces "@me" in a list of usernames with the current user.
+func resolveMe(names []string, me string) []string {
+	out := make([]string, len(names))
+	for i, n := range names {
+		if n == "@me" {
+			n = me
+		}
+		out[i] = n
+	}
+	return out
+}
+
+// containsMe reports whether a list of usernames refers to the current user.
+func containsMe(names []string) bool {
+	for _, n := range names {
+		if n == "@me" {
+			return true
+		}
+	}
+	return false
+}
+
+// branchSet returns the unique branch names of a list of PRs, in order.
+func branchSet(prs []PR) []string {
+	seen := make(map[string]bool)
+	branches := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		if !seen[pr.Branch] {
+			seen[pr.Branch] = true
+			branches = append(branches, pr.Branch)
+		}
+	}
+	return branches
+}
@@ -0,0 +1,164 @@
+package forge
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/laurent/trak/internal/github"
+)
+
+// testServer serves canned JSON responses keyed by "METHOD /path?query".
+type testServer struct {
+	*httptest.Server
+	// Responses maps request keys to response bodies
+	Responses map[string]string
+	// Bodies records the decoded JSON body of each request with one
+	Bodies map[string]map[string]any
+	// Headers records the headers of the last request
+	Headers http.Header
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	ts := &testServer{
+		Responses: make(map[string]string),
+		Bodies:    make(map[string]map[string]any),
+	}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.RequestURI()
+		ts.Headers = r.Header.Clone()
+
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			var body map[string]any
+			if err := json.Unmarshal(data, &body); err == nil {
+				ts.Bodies[key] = body
+			}
+		}
+
+		resp, ok := ts.Responses[key]
+		if !ok {
+			t.Logf("unexpected request: %s", key)
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, resp)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestValidateKind(t *testing.T) {
+	for _, kind := range []string{"", "github", "gitlab", "gitea"} {
+		if err := ValidateKind(kind); err != nil {
+			t.Errorf("ValidateKind(%q) returned error: %v", kind, err)
+		}
+	}
+	if err := ValidateKind("bitbucket"); err == nil {
+		t.Error("expected error for unsupported forge")
+	}
+}
+
+func TestDetectKind(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"", KindGitHub},
+		{"github.com", KindGitHub},
+		{"ghe.example.com", KindGitHub},
+		{"gitlab.com", KindGitLab},
+		{"gitlab.example.com", KindGitLab},
+		{"gitea.example.com", KindGitea},
+		{"codeberg.org", KindGitea},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := DetectKind(tt.host); got != tt.want {
+				t.Errorf("DetectKind(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		kind string
+		host string
+		want string
+	}{
+		{"", "github.com", KindGitHub},
+		{"", "gitlab.example.com", KindGitLab},
+		{"gitea", "git.example.com", KindGitea},
+		{"github", "gitlab.example.com", KindGitHub},
+	}
+
+	for _, tt := range tests {
+		f, err := New(tt.kind, tt.host)
+		if err != nil {
+			t.Fatalf("New(%q, %q) returned error: %v", tt.kind, tt.host, err)
+		}
+		if f.Name() != tt.want {
+			t.Errorf("New(%q, %q) = %s, want %s", tt.kind, tt.host, f.Name(), tt.want)
+		}
+	}
+
+	if _, err := New("bitbucket", ""); err == nil {
+		t.Error("expected error for unsupported forge")
+	}
+	if _, err := New("gitea", ""); err == nil {
+		t.Error("expected error for gitea without a host")
+	}
+}
+
+func TestPRURL(t *testing.T) {
+	tests := []struct {
+		forge Forge
+		want  string
+	}{
+		{GitHub{}, "https://github.com/owner/repo/pull/42"},
+		{NewGitLab("https://gitlab.example.com/", ""), "https://gitlab.example.com/owner/repo/-/merge_requests/42"},
+		{NewGitea("https://gitea.example.com", ""), "https://gitea.example.com/owner/repo/pulls/42"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.forge.PRURL("owner/repo", 42); got != tt.want {
+			t.Errorf("%s PRURL() = %q, want %q", tt.forge.Name(), got, tt.want)
+		}
+	}
+}
+
+func TestCIStatusFromPipeline(t *testing.T) {
+	tests := map[string]string{
+		"success":  "success",
+		"failed":   "failure",
+		"error":    "failure",
+		"running":  "pending",
+		"pending":  "pending",
+		"canceled": "unknown",
+		"":         "unknown",
+	}
+	for in, want := range tests {
+		if got := ciStatusFromPipeline(in); got != want {
+			t.Errorf("ciStatusFromPipeline(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGitHubUsesRESTClient(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /repos/owner/repo"] = `{"default_branch": "trunk"}`
+
+	f := GitHub{Client: github.NewClient(ts.URL, "token")}
+	branch, err := f.GetDefaultBranch("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if branch != "trunk" {
+		t.Errorf("expected 'trunk', got %q", branch)
+	}
+}
@@ -0,0 +1,337 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Gitea is the Forge backed by the Gitea (and Forgejo) REST API (v1).
+type Gitea struct {
+	baseURL string
+	client  *restClient
+}
+
+// NewGitea creates a Gitea forge for the instance at baseURL (e.g. https://gitea.example.com).
+// token is an access token with repository read/write access.
+func NewGitea(baseURL, token string) *Gitea {
+	auth := ""
+	if token != "" {
+		auth = "token " + token
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &Gitea{baseURL: baseURL, client: newRESTClient(baseURL+"/api/v1", "Authorization", auth)}
+}
+
+// Name returns "gitea".
+func (g *Gitea) Name() string { return KindGitea }
+
+// giteaPageSize is the number of items requested per page, Gitea's default maximum.
+const giteaPageSize = 50
+
+// giteaBranchPages is the number of pages of recently updated pull requests searched for
+// a branch's. Branches are looked up on every status refresh, most have no pull request.
+const giteaBranchPages = 4
+
+// giteaPull is a Gitea pull request.
+type giteaPull struct {
+	Number int    `json:"number"`
+	State  string `json:"state"` // open, closed
+	Merged bool   `json:"merged"`
+	Head   struct {
+		Ref  string `json:"ref"`
+		SHA  string `json:"sha"`
+		Repo struct {
+			FullName string `json:"full_name"` // owner/repo, differs from the base for forks
+		} `json:"repo"`
+	} `json:"head"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// giteaReview is a review on a Gitea pull request.
+type giteaReview struct {
+	State     string `json:"state"` // APPROVED, REQUEST_CHANGES, COMMENT, PENDING
+	Dismissed bool   `json:"dismissed"`
+	Stale     bool   `json:"stale"`
+}
+
+// giteaBranch is a Gitea repository branch.
+type giteaBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID        string `json:"id"`
+		Timestamp string `json:"timestamp"`
+	} `json:"commit"`
+}
+
+// repo returns the API path of a repository.
+func (g *Gitea) repo(remote string) string {
+	owner, name, _ := strings.Cut(remote, "/")
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+}
+
+func (g *Gitea) currentUser() (string, error) {
+	var u struct {
+		Login string `json:"login"`
+	}
+	if err := g.client.get("/user", &u); err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	return u.Login, nil
+}
+
+// ListMyPRs returns all open pull requests authored by the current user.
+func (g *Gitea) ListMyPRs(remote string) ([]PR, error) {
+	user, err := g.currentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	prs := []PR{}
+	err = g.eachPull(remote, "state=open", 0, func(p giteaPull) bool {
+		if p.User.Login == user {
+			prs = append(prs, p.toPR())
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	return prs, nil
+}
+
+// GetPRForBranch returns the most recent pull request for a branch, or nil if none exists.
+// Only the most recently updated pull requests are searched (see giteaBranchPages).
+func (g *Gitea) GetPRForBranch(remote, branch string) (*PR, error) {
+	// The API can't filter by head branch, so scan from the most recently updated.
+	// Forks can have a branch of the same name.
+	var found *giteaPull
+	err := g.eachPull(remote, "state=all&sort=recentupdate", giteaBranchPages, func(p giteaPull) bool {
+		if p.Head.Ref == branch && strings.EqualFold(p.Head.Repo.FullName, remote) {
+			found = &p
+		}
+		return found != nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request for branch %s: %w", branch, err)
+	}
+	if found == nil {
+		return nil, nil
+	}
+	return g.withStatus(remote, *found), nil
+}
+
+// eachPull calls fn with the pull requests returned by a list query, page by page, until
+// fn returns true or all pages were read. maxPages limits the pages read, 0 reads all.
+func (g *Gitea) eachPull(remote, query string, maxPages int, fn func(giteaPull) bool) error {
+	for page := 1; maxPages == 0 || page <= maxPages; page++ {
+		var pulls []giteaPull
+		if err := g.client.get(fmt.Sprintf("%s/pulls?%s&limit=%d&page=%d", g.repo(remote), query, giteaPageSize, page), &pulls); err != nil {
+			return err
+		}
+		// Servers may cap the page size below ours, so only an empty page ends the list
+		if len(pulls) == 0 {
+			return nil
+		}
+		for _, p := range pulls {
+			if fn(p) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// GetPR returns the pull request with the given number, or nil if none exists.
+func (g *Gitea) GetPR(remote string, number int) (*PR, error) {
+	var p giteaPull
+	if err := g.client.get(fmt.Sprintf("%s/pulls/%d", g.repo(remote), number), &p); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pull request #%d: %w", number, err)
+	}
+	return g.withStatus(remote, p), nil
+}
+
+// PRURL returns the web URL of a pull request.
+func (g *Gitea) PRURL(remote string, number int) string {
+	return fmt.Sprintf("%s/%s/pulls/%d", g.baseURL, remote, number)
+}
+
+// withStatus converts a pull request, filling in CI and review status.
+// Failures to fetch either leave them unknown.
+func (g *Gitea) withStatus(remote string, p giteaPull) *PR {
+	pr := p.toPR()
+
+	var status struct {
+		State string `json:"state"`
+	}
+	if p.Head.SHA != "" {
+		if err := g.client.get(g.repo(remote)+"/commits/"+p.Head.SHA+"/status", &status); err == nil {
+			pr.CIStatus = ciStatusFromPipeline(status.State)
+		}
+	}
+
+	var reviews []giteaReview
+	if err := g.client.get(fmt.Sprintf("%s/pulls/%d/reviews", g.repo(remote), p.Number), &reviews); err == nil {
+		pr.ReviewStatus = reviewStatusFromGitea(reviews)
+	}
+
+	return &pr
+}
+
+// reviewStatusFromGitea summarizes the active reviews of a pull request.
+func reviewStatusFromGitea(reviews []giteaReview) string {
+	approved := false
+	for _, r := range reviews {
+		if r.Dismissed || r.Stale {
+			continue
+		}
+		switch r.State {
+		case "REQUEST_CHANGES":
+			return "changes_requested"
+		case "APPROVED":
+			approved = true
+		}
+	}
+	if approved {
+		return "approved"
+	}
+	return "pending"
+}
+
+// CreatePR creates a pull request and returns its number.
+func (g *Gitea) CreatePR(remote, branch, baseBranch string, opts PROptions) (int, error) {
+	title := opts.Title
+	if opts.Draft {
+		title = "WIP: " + title
+	}
+
+	req := map[string]any{
+		"head":  branch,
+		"base":  baseBranch,
+		"title": title,
+		"body":  opts.Body,
+	}
+
+	if containsMe(opts.Assignees) || containsMe(opts.Reviewers) {
+		me, err := g.currentUser()
+		if err != nil {
+			return 0, err
+		}
+		opts.Assignees = resolveMe(opts.Assignees, me)
+		opts.Reviewers = resolveMe(opts.Reviewers, me)
+	}
+	if len(opts.Assignees) > 0 {
+		req["assignees"] = opts.Assignees
+	}
+	if len(opts.Labels) > 0 {
+		ids, err := g.labelIDs(remote, opts.Labels)
+		if err != nil {
+			return 0, err
+		}
+		req["labels"] = ids
+	}
+
+	var p giteaPull
+	if err := g.client.post(g.repo(remote)+"/pulls", req, &p); err != nil {
+		return 0, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	if len(opts.Reviewers) > 0 {
+		body := map[string]any{"reviewers": opts.Reviewers}
+		if err := g.client.post(fmt.Sprintf("%s/pulls/%d/requested_reviewers", g.repo(remote), p.Number), body, nil); err != nil {
+			return p.Number, fmt.Errorf("created pull request #%d, but failed to request reviewers: %w", p.Number, err)
+		}
+	}
+
+	return p.Number, nil
+}
+
+// labelIDs resolves label names to the repository's label IDs.
+func (g *Gitea) labelIDs(remote string, names []string) ([]int64, error) {
+	var labels []struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := g.client.get(g.repo(remote)+"/labels?limit=100", &labels); err != nil {
+		return nil, fmt.Errorf("failed to list labels: %w", err)
+	}
+
+	byName := make(map[string]int64, len(labels))
+	for _, l := range labels {
+		byName[l.Name] = l.ID
+	}
+
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown label: %s", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// GetDefaultBranch returns the repository's default branch.
+func (g *Gitea) GetDefaultBranch(remote string) (string, error) {
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.client.get(g.repo(remote), &repo); err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+	if repo.DefaultBranch == "" {
+		return "", fmt.Errorf("no default branch found for %s", remote)
+	}
+	return repo.DefaultBranch, nil
+}
+
+// ListMyBranches returns remote branches where the current user has open pull requests.
+func (g *Gitea) ListMyBranches(remote string) ([]RemoteBranch, error) {
+	prs, err := g.ListMyPRs(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]RemoteBranch, 0, len(prs))
+	for _, name := range branchSet(prs) {
+		var b giteaBranch
+		if err := g.client.get(g.repo(remote)+"/branches/"+url.PathEscape(name), &b); err != nil {
+			// Branch might have been deleted, skip it
+			continue
+		}
+
+		var age time.Duration
+		if t, err := time.Parse(time.RFC3339, b.Commit.Timestamp); err == nil {
+			age = time.Since(t)
+		}
+
+		branches = append(branches, RemoteBranch{
+			Name:       b.Name,
+			LastCommit: b.Commit.ID,
+			Age:        age,
+		})
+	}
+	return branches, nil
+}
+
+// toPR converts a Gitea pull request to a PR without CI or review status.
+func (p giteaPull) toPR() PR {
+	state := p.State
+	if p.Merged {
+		state = "merged"
+	}
+	return PR{
+		Number:       p.Number,
+		Branch:       p.Head.Ref,
+		State:        state,
+		CIStatus:     "unknown",
+		ReviewStatus: "unknown",
+	}
+}
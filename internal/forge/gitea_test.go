@@ -0,0 +1,186 @@
+package forge
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGiteaListMyPRs(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v1/user"] = `{"login": "alice"}`
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls?state=open&limit=50&page=1"] = `[
+		{"number": 5, "state": "open", "head": {"ref": "feature-a", "sha": "abc"}, "user": {"login": "alice"}},
+		{"number": 6, "state": "open", "head": {"ref": "other", "sha": "def"}, "user": {"login": "bob"}}
+	]`
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls?state=open&limit=50&page=2"] = `[
+		{"number": 2, "state": "open", "head": {"ref": "feature-b", "sha": "123"}, "user": {"login": "alice"}}
+	]`
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls?state=open&limit=50&page=3"] = `[]`
+
+	g := NewGitea(ts.URL, "secret")
+	prs, err := g.ListMyPRs("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("expected 2 PRs, got %d", len(prs))
+	}
+	if prs[0].Number != 5 || prs[0].Branch != "feature-a" || prs[0].State != "open" {
+		t.Errorf("unexpected PR: %+v", prs[0])
+	}
+	if prs[1].Number != 2 || prs[1].Branch != "feature-b" {
+		t.Errorf("expected the PR on the second page, got %+v", prs[1])
+	}
+	if ts.Headers.Get("Authorization") != "token secret" {
+		t.Errorf("unexpected Authorization header: %q", ts.Headers.Get("Authorization"))
+	}
+}
+
+func TestGiteaGetPRForBranch(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls?state=all&sort=recentupdate&limit=50&page=1"] = `[
+		{"number": 7, "state": "open", "head": {"ref": "other", "sha": "fed", "repo": {"full_name": "owner/repo"}}}
+	]`
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls?state=all&sort=recentupdate&limit=50&page=2"] = `[
+		{"number": 6, "state": "open", "head": {"ref": "feature-a", "sha": "def", "repo": {"full_name": "alice/repo"}}},
+		{"number": 5, "state": "closed", "merged": true, "head": {"ref": "feature-a", "sha": "abc", "repo": {"full_name": "Owner/Repo"}}}
+	]`
+	ts.Responses["GET /api/v1/repos/owner/repo/commits/abc/status"] = `{"state": "success"}`
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls/5/reviews"] = `[
+		{"state": "REQUEST_CHANGES", "dismissed": true},
+		{"state": "APPROVED"}
+	]`
+
+	g := NewGitea(ts.URL, "")
+	pr, err := g.GetPRForBranch("owner/repo", "feature-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr == nil {
+		t.Fatal("expected PR, got nil")
+	}
+	if pr.Number != 5 || pr.State != "merged" {
+		t.Errorf("unexpected PR: %+v", pr)
+	}
+	if pr.CIStatus != "success" {
+		t.Errorf("expected CI status 'success', got %q", pr.CIStatus)
+	}
+	if pr.ReviewStatus != "approved" {
+		t.Errorf("expected review status 'approved', got %q", pr.ReviewStatus)
+	}
+}
+
+func TestGiteaGetPRForBranch_NotFound(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls?state=all&sort=recentupdate&limit=50&page=1"] = `[]`
+
+	g := NewGitea(ts.URL, "")
+	pr, err := g.GetPRForBranch("owner/repo", "feature-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr != nil {
+		t.Errorf("expected nil PR, got %+v", pr)
+	}
+}
+
+func TestGiteaGetPRForBranch_RecentOnly(t *testing.T) {
+	ts := newTestServer(t)
+	for page := 1; page <= giteaBranchPages; page++ {
+		ts.Responses[fmt.Sprintf("GET /api/v1/repos/owner/repo/pulls?state=all&sort=recentupdate&limit=50&page=%d", page)] = `[
+			{"number": 1, "state": "closed", "head": {"ref": "other", "sha": "abc", "repo": {"full_name": "owner/repo"}}}
+		]`
+	}
+
+	g := NewGitea(ts.URL, "")
+	pr, err := g.GetPRForBranch("owner/repo", "feature-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr != nil {
+		t.Errorf("expected nil PR, got %+v", pr)
+	}
+}
+
+func TestGiteaCreatePR(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v1/user"] = `{"login": "alice"}`
+	ts.Responses["GET /api/v1/repos/owner/repo/labels?limit=100"] = `[{"id": 1, "name": "bug"}, {"id": 2, "name": "needs-review"}]`
+	ts.Responses["POST /api/v1/repos/owner/repo/pulls"] = `{"number": 8}`
+	ts.Responses["POST /api/v1/repos/owner/repo/pulls/8/requested_reviewers"] = `[]`
+
+	g := NewGitea(ts.URL, "")
+	num, err := g.CreatePR("owner/repo", "feature-a", "main", PROptions{
+		Title:     "Add feature",
+		Reviewers: []string{"bob"},
+		Assignees: []string{"@me"},
+		Labels:    []string{"needs-review"},
+		Draft:     true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if num != 8 {
+		t.Errorf("expected PR 8, got %d", num)
+	}
+
+	body := ts.Bodies["POST /api/v1/repos/owner/repo/pulls"]
+	if body["title"] != "WIP: Add feature" {
+		t.Errorf("expected WIP title, got %v", body["title"])
+	}
+	if a, ok := body["assignees"].([]any); !ok || len(a) != 1 || a[0] != "alice" {
+		t.Errorf("expected @me to resolve to alice, got %v", body["assignees"])
+	}
+	if l, ok := body["labels"].([]any); !ok || len(l) != 1 || l[0] != float64(2) {
+		t.Errorf("expected label ID 2, got %v", body["labels"])
+	}
+
+	reviewers := ts.Bodies["POST /api/v1/repos/owner/repo/pulls/8/requested_reviewers"]
+	if r, ok := reviewers["reviewers"].([]any); !ok || len(r) != 1 || r[0] != "bob" {
+		t.Errorf("expected bob to be requested, got %v", reviewers)
+	}
+}
+
+func TestGiteaCreatePR_UnknownLabel(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v1/repos/owner/repo/labels?limit=100"] = `[]`
+
+	g := NewGitea(ts.URL, "")
+	_, err := g.CreatePR("owner/repo", "feature-a", "main", PROptions{Title: "x", Labels: []string{"nope"}})
+	if err == nil {
+		t.Fatal("expected error for unknown label")
+	}
+}
+
+func TestGiteaGetDefaultBranch(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v1/repos/owner/repo"] = `{"default_branch": "main"}`
+
+	g := NewGitea(ts.URL, "")
+	branch, err := g.GetDefaultBranch("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if branch != "main" {
+		t.Errorf("expected 'main', got %q", branch)
+	}
+}
+
+func TestGiteaListMyBranches(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v1/user"] = `{"login": "alice"}`
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls?state=open&limit=50&page=1"] = `[
+		{"number": 5, "state": "open", "head": {"ref": "feature-a"}, "user": {"login": "alice"}}
+	]`
+	ts.Responses["GET /api/v1/repos/owner/repo/pulls?state=open&limit=50&page=2"] = `[]`
+	ts.Responses["GET /api/v1/repos/owner/repo/branches/feature-a"] = `{"name": "feature-a", "commit": {"id": "abc123", "timestamp": "2024-01-15T10:30:00Z"}}`
+
+	g := NewGitea(ts.URL, "")
+	branches, err := g.ListMyBranches("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(branches) != 1 || branches[0].LastCommit != "abc123" {
+		t.Errorf("unexpected branches: %+v", branches)
+	}
+}
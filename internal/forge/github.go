@@ -0,0 +1,113 @@
+package forge
+
+import "github.com/laurent/trak/internal/github"
+
+// GitHub is the Forge backed by the gh CLI, or by the REST API when Client is set.
+type GitHub struct {
+	Client *github.Client
+}
+
+// Name returns "github".
+func (GitHub) Name() string { return KindGitHub }
+
+// ListMyPRs returns all open PRs authored by the current user.
+func (g GitHub) ListMyPRs(remote string) ([]PR, error) {
+	listMyPRs := github.ListMyPRs
+	if g.Client != nil {
+		listMyPRs = g.Client.ListMyPRs
+	}
+	prs, err := listMyPRs(remote)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]PR, len(prs))
+	for i, p := range prs {
+		result[i] = fromGitHubPR(p)
+	}
+	return result, nil
+}
+
+// GetPRForBranch returns the PR for a branch, or nil if none exists.
+func (g GitHub) GetPRForBranch(remote, branch string) (*PR, error) {
+	getPRForBranch := github.GetPRForBranch
+	if g.Client != nil {
+		getPRForBranch = g.Client.GetPRForBranch
+	}
+	pr, err := getPRForBranch(remote, branch)
+	if err != nil || pr == nil {
+		return nil, err
+	}
+	p := fromGitHubPR(*pr)
+	return &p, nil
+}
+
+// GetPR returns the PR with the given number, or nil if none exists.
+func (g GitHub) GetPR(remote string, number int) (*PR, error) {
+	getPR := github.GetPR
+	if g.Client != nil {
+		getPR = g.Client.GetPR
+	}
+	pr, err := getPR(remote, number)
+	if err != nil || pr == nil {
+		return nil, err
+	}
+	p := fromGitHubPR(*pr)
+	return &p, nil
+}
+
+// PRURL returns the web URL of a pull request on the gh host in use.
+func (GitHub) PRURL(remote string, number int) string {
+	return github.PRURL(remote, number)
+}
+
+// CreatePR creates a pull request and returns its number.
+func (g GitHub) CreatePR(remote, branch, baseBranch string, opts PROptions) (int, error) {
+	createPR := github.CreatePRWithOptions
+	if g.Client != nil {
+		createPR = g.Client.CreatePRWithOptions
+	}
+	return createPR(remote, branch, baseBranch, github.PROptions{
+		Title:     opts.Title,
+		Body:      opts.Body,
+		Reviewers: opts.Reviewers,
+		Labels:    opts.Labels,
+		Assignees: opts.Assignees,
+		Draft:     opts.Draft,
+	})
+}
+
+// GetDefaultBranch returns the repository's default branch.
+func (g GitHub) GetDefaultBranch(remote string) (string, error) {
+	if g.Client != nil {
+		return g.Client.GetDefaultBranch(remote)
+	}
+	return github.GetDefaultBranch(remote)
+}
+
+// ListMyBranches returns remote branches where the current user has open PRs.
+func (g GitHub) ListMyBranches(remote string) ([]RemoteBranch, error) {
+	listMyBranches := github.ListMyBranches
+	if g.Client != nil {
+		listMyBranches = g.Client.ListMyBranches
+	}
+	branches, err := listMyBranches(remote)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]RemoteBranch, len(branches))
+	for i, b := range branches {
+		result[i] = RemoteBranch{Name: b.Name, LastCommit: b.LastCommit, Age: b.Age}
+	}
+	return result, nil
+}
+
+// fromGitHubPR converts a github.PR to a PR.
+func fromGitHubPR(p github.PR) PR {
+	return PR{
+		Number:       p.Number,
+		Branch:       p.Branch,
+		State:        p.State,
+		CIStatus:     p.CIStatus,
+		ReviewStatus: p.ReviewStatus,
+	}
+}
@@ -0,0 +1,259 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GitLab is the Forge backed by the GitLab REST API (v4).
+type GitLab struct {
+	baseURL string
+	client  *restClient
+}
+
+// NewGitLab creates a GitLab forge for the instance at baseURL (e.g. https://gitlab.com).
+// token is a personal access token with the api scope.
+func NewGitLab(baseURL, token string) *GitLab {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &GitLab{baseURL: baseURL, client: newRESTClient(baseURL+"/api/v4", "PRIVATE-TOKEN", token)}
+}
+
+// Name returns "gitlab".
+func (g *GitLab) Name() string { return KindGitLab }
+
+// glUser is a GitLab user.
+type glUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+// glMergeRequest is a GitLab merge request.
+type glMergeRequest struct {
+	IID                 int    `json:"iid"`
+	SourceBranch        string `json:"source_branch"`
+	State               string `json:"state"` // opened, closed, merged, locked
+	DetailedMergeStatus string `json:"detailed_merge_status"`
+	HeadPipeline        *struct {
+		Status string `json:"status"`
+	} `json:"head_pipeline"` // Only present on single merge request responses
+}
+
+// glBranch is a GitLab repository branch.
+type glBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID            string `json:"id"`
+		CommittedDate string `json:"committed_date"`
+	} `json:"commit"`
+}
+
+// project returns the API path of a project, URL-encoding its full path.
+func (g *GitLab) project(remote string) string {
+	return "/projects/" + url.PathEscape(remote)
+}
+
+func (g *GitLab) currentUser() (*glUser, error) {
+	var u glUser
+	if err := g.client.get("/user", &u); err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	return &u, nil
+}
+
+// ListMyPRs returns all open merge requests authored by the current user.
+func (g *GitLab) ListMyPRs(remote string) ([]PR, error) {
+	user, err := g.currentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("state", "opened")
+	q.Set("author_username", user.Username)
+	q.Set("per_page", "100")
+
+	var mrs []glMergeRequest
+	if err := g.client.get(g.project(remote)+"/merge_requests?"+q.Encode(), &mrs); err != nil {
+		return nil, fmt.Errorf("failed to list merge requests: %w", err)
+	}
+
+	prs := make([]PR, len(mrs))
+	for i, mr := range mrs {
+		prs[i] = mr.toPR()
+	}
+	return prs, nil
+}
+
+// GetPRForBranch returns the most recent merge request for a branch, or nil if none exists.
+func (g *GitLab) GetPRForBranch(remote, branch string) (*PR, error) {
+	q := url.Values{}
+	q.Set("source_branch", branch)
+	q.Set("per_page", "1")
+
+	var mrs []glMergeRequest
+	if err := g.client.get(g.project(remote)+"/merge_requests?"+q.Encode(), &mrs); err != nil {
+		return nil, fmt.Errorf("failed to get merge request for branch %s: %w", branch, err)
+	}
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+
+	// The list endpoint doesn't include the pipeline, fetch the full merge request
+	return g.GetPR(remote, mrs[0].IID)
+}
+
+// GetPR returns the merge request with the given IID, or nil if none exists.
+func (g *GitLab) GetPR(remote string, number int) (*PR, error) {
+	var mr glMergeRequest
+	if err := g.client.get(fmt.Sprintf("%s/merge_requests/%d", g.project(remote), number), &mr); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get merge request !%d: %w", number, err)
+	}
+	pr := mr.toPR()
+	return &pr, nil
+}
+
+// PRURL returns the web URL of a merge request.
+func (g *GitLab) PRURL(remote string, number int) string {
+	return fmt.Sprintf("%s/%s/-/merge_requests/%d", g.baseURL, remote, number)
+}
+
+// CreatePR creates a merge request and returns its IID.
+func (g *GitLab) CreatePR(remote, branch, baseBranch string, opts PROptions) (int, error) {
+	title := opts.Title
+	if opts.Draft {
+		title = "Draft: " + title
+	}
+
+	req := map[string]any{
+		"source_branch": branch,
+		"target_branch": baseBranch,
+		"title":         title,
+		"description":   opts.Body,
+	}
+	if len(opts.Labels) > 0 {
+		req["labels"] = strings.Join(opts.Labels, ",")
+	}
+	if len(opts.Reviewers) > 0 {
+		ids, err := g.userIDs(opts.Reviewers)
+		if err != nil {
+			return 0, err
+		}
+		req["reviewer_ids"] = ids
+	}
+	if len(opts.Assignees) > 0 {
+		ids, err := g.userIDs(opts.Assignees)
+		if err != nil {
+			return 0, err
+		}
+		req["assignee_ids"] = ids
+	}
+
+	var mr glMergeRequest
+	if err := g.client.post(g.project(remote)+"/merge_requests", req, &mr); err != nil {
+		return 0, fmt.Errorf("failed to create merge request: %w", err)
+	}
+	return mr.IID, nil
+}
+
+// userIDs resolves usernames (or "@me") to GitLab user IDs.
+func (g *GitLab) userIDs(names []string) ([]int, error) {
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		if name == "@me" {
+			user, err := g.currentUser()
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, user.ID)
+			continue
+		}
+
+		var users []glUser
+		if err := g.client.get("/users?username="+url.QueryEscape(name), &users); err != nil {
+			return nil, fmt.Errorf("failed to look up user %s: %w", name, err)
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("unknown GitLab user: %s", name)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+// GetDefaultBranch returns the project's default branch.
+func (g *GitLab) GetDefaultBranch(remote string) (string, error) {
+	var project struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.client.get(g.project(remote), &project); err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+	if project.DefaultBranch == "" {
+		return "", fmt.Errorf("no default branch found for %s", remote)
+	}
+	return project.DefaultBranch, nil
+}
+
+// ListMyBranches returns remote branches where the current user has open merge requests.
+func (g *GitLab) ListMyBranches(remote string) ([]RemoteBranch, error) {
+	prs, err := g.ListMyPRs(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]RemoteBranch, 0, len(prs))
+	for _, name := range branchSet(prs) {
+		var b glBranch
+		if err := g.client.get(g.project(remote)+"/repository/branches/"+url.PathEscape(name), &b); err != nil {
+			// Branch might have been deleted, skip it
+			continue
+		}
+
+		var age time.Duration
+		if t, err := time.Parse(time.RFC3339, b.Commit.CommittedDate); err == nil {
+			age = time.Since(t)
+		}
+
+		branches = append(branches, RemoteBranch{
+			Name:       b.Name,
+			LastCommit: b.Commit.ID,
+			Age:        age,
+		})
+	}
+	return branches, nil
+}
+
+// toPR converts a GitLab merge request to a PR.
+func (mr glMergeRequest) toPR() PR {
+	pr := PR{
+		Number:       mr.IID,
+		Branch:       mr.SourceBranch,
+		CIStatus:     "unknown",
+		ReviewStatus: "unknown",
+	}
+
+	switch mr.State {
+	case "opened", "locked":
+		pr.State = "open"
+	default:
+		pr.State = mr.State
+	}
+
+	if mr.HeadPipeline != nil {
+		pr.CIStatus = ciStatusFromPipeline(mr.HeadPipeline.Status)
+	}
+
+	switch mr.DetailedMergeStatus {
+	case "not_approved":
+		pr.ReviewStatus = "pending"
+	case "requested_changes":
+		pr.ReviewStatus = "changes_requested"
+	}
+
+	return pr
+}
@@ -0,0 +1,165 @@
+package forge
+
+import "testing"
+
+func TestGitLabListMyPRs(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v4/user"] = `{"id": 7, "username": "alice"}`
+	ts.Responses["GET /api/v4/projects/group%2Frepo/merge_requests?author_username=alice&per_page=100&state=opened"] = `[
+		{"iid": 3, "source_branch": "feature-a", "state": "opened", "detailed_merge_status": "not_approved"},
+		{"iid": 4, "source_branch": "feature-b", "state": "opened", "detailed_merge_status": "mergeable"}
+	]`
+
+	g := NewGitLab(ts.URL, "secret")
+	prs, err := g.ListMyPRs("group/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("expected 2 PRs, got %d", len(prs))
+	}
+	if prs[0].Number != 3 || prs[0].Branch != "feature-a" || prs[0].State != "open" {
+		t.Errorf("unexpected first PR: %+v", prs[0])
+	}
+	if prs[0].ReviewStatus != "pending" {
+		t.Errorf("expected review status 'pending', got %q", prs[0].ReviewStatus)
+	}
+	if ts.Headers.Get("PRIVATE-TOKEN") != "secret" {
+		t.Error("expected token to be sent in PRIVATE-TOKEN header")
+	}
+}
+
+func TestGitLabGetPRForBranch(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v4/projects/group%2Frepo/merge_requests?per_page=1&source_branch=feature-a"] = `[{"iid": 3, "source_branch": "feature-a", "state": "merged"}]`
+	ts.Responses["GET /api/v4/projects/group%2Frepo/merge_requests/3"] = `{"iid": 3, "source_branch": "feature-a", "state": "merged", "head_pipeline": {"status": "failed"}}`
+
+	g := NewGitLab(ts.URL, "")
+	pr, err := g.GetPRForBranch("group/repo", "feature-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr == nil {
+		t.Fatal("expected PR, got nil")
+	}
+	if pr.State != "merged" || pr.CIStatus != "failure" {
+		t.Errorf("unexpected PR: %+v", pr)
+	}
+}
+
+func TestGitLabGetPRForBranch_NotFound(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v4/projects/group%2Frepo/merge_requests?per_page=1&source_branch=nope"] = `[]`
+
+	g := NewGitLab(ts.URL, "")
+	pr, err := g.GetPRForBranch("group/repo", "nope")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr != nil {
+		t.Errorf("expected nil PR, got %+v", pr)
+	}
+}
+
+func TestGitLabGetPR_NotFound(t *testing.T) {
+	ts := newTestServer(t)
+
+	g := NewGitLab(ts.URL, "")
+	pr, err := g.GetPR("group/repo", 99)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr != nil {
+		t.Errorf("expected nil PR, got %+v", pr)
+	}
+}
+
+func TestGitLabCreatePR(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v4/user"] = `{"id": 7, "username": "alice"}`
+	ts.Responses["GET /api/v4/users?username=bob"] = `[{"id": 9, "username": "bob"}]`
+	ts.Responses["POST /api/v4/projects/group%2Frepo/merge_requests"] = `{"iid": 12}`
+
+	g := NewGitLab(ts.URL, "")
+	num, err := g.CreatePR("group/repo", "feature-a", "main", PROptions{
+		Title:     "Add feature",
+		Body:      "Details",
+		Reviewers: []string{"bob"},
+		Assignees: []string{"@me"},
+		Labels:    []string{"backend", "needs-review"},
+		Draft:     true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if num != 12 {
+		t.Errorf("expected MR 12, got %d", num)
+	}
+
+	body := ts.Bodies["POST /api/v4/projects/group%2Frepo/merge_requests"]
+	if body["title"] != "Draft: Add feature" {
+		t.Errorf("expected draft title, got %v", body["title"])
+	}
+	if body["target_branch"] != "main" || body["source_branch"] != "feature-a" {
+		t.Errorf("unexpected branches: %v", body)
+	}
+	if body["labels"] != "backend,needs-review" {
+		t.Errorf("unexpected labels: %v", body["labels"])
+	}
+	if ids, ok := body["reviewer_ids"].([]any); !ok || len(ids) != 1 || ids[0] != float64(9) {
+		t.Errorf("unexpected reviewer_ids: %v", body["reviewer_ids"])
+	}
+	if ids, ok := body["assignee_ids"].([]any); !ok || len(ids) != 1 || ids[0] != float64(7) {
+		t.Errorf("unexpected assignee_ids: %v", body["assignee_ids"])
+	}
+}
+
+func TestGitLabCreatePR_UnknownUser(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v4/users?username=ghost"] = `[]`
+
+	g := NewGitLab(ts.URL, "")
+	_, err := g.CreatePR("group/repo", "feature-a", "main", PROptions{Title: "x", Reviewers: []string{"ghost"}})
+	if err == nil {
+		t.Fatal("expected error for unknown reviewer")
+	}
+}
+
+func TestGitLabGetDefaultBranch(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v4/projects/group%2Frepo"] = `{"default_branch": "develop"}`
+
+	g := NewGitLab(ts.URL, "")
+	branch, err := g.GetDefaultBranch("group/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if branch != "develop" {
+		t.Errorf("expected 'develop', got %q", branch)
+	}
+}
+
+func TestGitLabListMyBranches(t *testing.T) {
+	ts := newTestServer(t)
+	ts.Responses["GET /api/v4/user"] = `{"id": 7, "username": "alice"}`
+	ts.Responses["GET /api/v4/projects/group%2Frepo/merge_requests?author_username=alice&per_page=100&state=opened"] = `[
+		{"iid": 3, "source_branch": "feature/a", "state": "opened"},
+		{"iid": 4, "source_branch": "deleted", "state": "opened"}
+	]`
+	ts.Responses["GET /api/v4/projects/group%2Frepo/repository/branches/feature%2Fa"] = `{"name": "feature/a", "commit": {"id": "abc123", "committed_date": "2024-01-15T10:30:00Z"}}`
+
+	g := NewGitLab(ts.URL, "")
+	branches, err := g.ListMyBranches("group/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(branches) != 1 {
+		t.Fatalf("expected 1 branch (deleted one skipped), got %d", len(branches))
+	}
+	if branches[0].Name != "feature/a" || branches[0].LastCommit != "abc123" {
+		t.Errorf("unexpected branch: %+v", branches[0])
+	}
+	if branches[0].Age <= 0 {
+		t.Error("expected a positive age")
+	}
+}
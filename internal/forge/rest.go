@@ -0,0 +1,106 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// restClient is a minimal JSON REST client shared by the GitLab and Gitea backends.
+type restClient struct {
+	baseURL    string // API root, e.g. https://gitlab.com/api/v4
+	authHeader string // Header carrying the token, e.g. "Authorization"
+	authValue  string // Full header value, empty for anonymous access
+	http       *http.Client
+}
+
+// httpError is returned for non-2xx responses.
+type httpError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("%s %s failed: %s: %s", e.Method, e.Path, e.Status, e.Body)
+}
+
+// isNotFound reports whether an error is a 404 response.
+func isNotFound(err error) bool {
+	var he *httpError
+	return errors.As(err, &he) && he.StatusCode == http.StatusNotFound
+}
+
+func newRESTClient(baseURL, authHeader, authValue string) *restClient {
+	return &restClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		authHeader: authHeader,
+		authValue:  authValue,
+		http:       &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// get performs a GET request and decodes the JSON response into out.
+func (c *restClient) get(path string, out any) error {
+	return c.do(http.MethodGet, path, nil, out)
+}
+
+// post performs a POST request with a JSON body and decodes the JSON response into out.
+func (c *restClient) post(path string, body, out any) error {
+	return c.do(http.MethodPost, path, body, out)
+}
+
+func (c *restClient) do(method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authValue != "" {
+		req.Header.Set(c.authHeader, c.authValue)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &httpError{
+			Method:     method,
+			Path:       path,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       strings.TrimSpace(string(msg)),
+		}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response from %s: %w", path, err)
+	}
+	return nil
+}
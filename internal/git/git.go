@@ -33,6 +33,50 @@ func Fetch(repoPath string) error {
 	return err
 }
 
+// RemoteURL returns the URL of the origin remote.
+func RemoteURL(repoPath string) (string, error) {
+	return runGit(repoPath, "remote", "get-url", "origin")
+}
+
+// FetchRefspec fetches a single refspec from origin, e.g. "+refs/pull/1/head:refs/heads/pr-1".
+func FetchRefspec(repoPath, refspec string) error {
+	_, err := runGit(repoPath, "fetch", "origin", refspec)
+	return err
+}
+
+// HasRemote reports whether a remote with the given name is configured.
+func HasRemote(repoPath, name string) bool {
+	_, err := runGit(repoPath, "remote", "get-url", name)
+	return err == nil
+}
+
+// AddRemote adds a remote, e.g. for a contributor's fork.
+func AddRemote(repoPath, name, url string) error {
+	_, err := runGit(repoPath, "remote", "add", name, url)
+	return err
+}
+
+// FetchBranch fetches a single branch from a remote, updating its remote-tracking branch.
+func FetchBranch(repoPath, remote, branch string) error {
+	_, err := runGit(repoPath, "fetch", remote, branch)
+	return err
+}
+
+// BranchUpstream returns the remote and remote branch a local branch tracks.
+// Both are empty if the branch has no upstream.
+func BranchUpstream(repoPath, branch string) (remote, remoteBranch string, err error) {
+	remote, err = runGit(repoPath, "config", "--get", "branch."+branch+".remote")
+	if err != nil {
+		// git config exits non-zero when the key is unset
+		return "", "", nil
+	}
+	merge, err := runGit(repoPath, "config", "--get", "branch."+branch+".merge")
+	if err != nil {
+		return "", "", nil
+	}
+	return remote, strings.TrimPrefix(merge, "refs/heads/"), nil
+}
+
 // GetDefaultBranch detects the default branch dynamically.
 // It first tries to get it from the remote HEAD ref, falling back to checking
 // common branch names if that fails.
@@ -130,12 +174,44 @@ func PushForce(repoPath, branch string) error {
 	return err
 }
 
+// PushForceTo force-pushes a local branch to a branch on the given remote,
+// e.g. the head branch of a PR from a fork.
+func PushForceTo(repoPath, remote, localBranch, remoteBranch string) error {
+	_, err := runGit(repoPath, "push", "--force-with-lease", remote, localBranch+":"+remoteBranch)
+	return err
+}
+
 // PushDelete deletes a remote branch.
 func PushDelete(repoPath, branch string) error {
 	_, err := runGit(repoPath, "push", "origin", "--delete", branch)
 	return err
 }
 
+// DeleteBranch force-deletes a local branch.
+func DeleteBranch(repoPath, branch string) error {
+	_, err := runGit(repoPath, "branch", "-D", branch)
+	return err
+}
+
+// UpdateRef points a ref at a commit, creating it if needed.
+// A ref outside refs/heads (e.g. refs/trak/...) keeps a commit from being garbage collected.
+func UpdateRef(repoPath, ref, sha string) error {
+	_, err := runGit(repoPath, "update-ref", ref, sha)
+	return err
+}
+
+// DeleteRef deletes a ref.
+func DeleteRef(repoPath, ref string) error {
+	_, err := runGit(repoPath, "update-ref", "-d", ref)
+	return err
+}
+
+// ResetHard resets the current branch, index and working tree to a commit.
+func ResetHard(repoPath, ref string) error {
+	_, err := runGit(repoPath, "reset", "--hard", ref)
+	return err
+}
+
 // AheadBehind returns how many commits ahead and behind a branch is from base.
 // Uses git rev-list --left-right --count.
 func AheadBehind(repoPath, branch, baseBranch string) (ahead, behind int, err error) {
@@ -197,3 +273,15 @@ func Pull(repoPath, branch string) error {
 func GetCurrentBranch(repoPath string) (string, error) {
 	return runGit(repoPath, "rev-parse", "--abbrev-ref", "HEAD")
 }
+
+// CommitSubjects returns the subjects of commits reachable from head but not base, oldest first.
+func CommitSubjects(repoPath, base, head string) ([]string, error) {
+	output, err := runGit(repoPath, "log", "--reverse", "--format=%s", fmt.Sprintf("%s..%s", base, head))
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return []string{}, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
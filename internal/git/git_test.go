@@ -379,6 +379,65 @@ func TestPushDelete(t *testing.T) {
 	}
 }
 
+func TestRemoteURL(t *testing.T) {
+	repoPath, remotePath, cleanup := setupTestRepoWithRemote(t)
+	defer cleanup()
+
+	url, err := RemoteURL(repoPath)
+	if err != nil {
+		t.Fatalf("RemoteURL failed: %v", err)
+	}
+	if url != remotePath {
+		t.Errorf("expected %s, got %s", remotePath, url)
+	}
+}
+
+func TestFetchRefspecAndDeleteBranch(t *testing.T) {
+	repoPath, _, cleanup := setupTestRepoWithRemote(t)
+	defer cleanup()
+
+	// Publish a commit under a pull request ref, as GitHub does
+	CreateBranch(repoPath, "contrib", "main")
+	Checkout(repoPath, "contrib")
+	os.WriteFile(filepath.Join(repoPath, "contrib.txt"), []byte("contrib"), 0644)
+
+	cmd := exec.Command("git", "add", ".")
+	cmd.Dir = repoPath
+	cmd.Run()
+
+	cmd = exec.Command("git", "commit", "-m", "Contribution")
+	cmd.Dir = repoPath
+	cmd.Run()
+
+	cmd = exec.Command("git", "push", "origin", "contrib:refs/pull/7/head")
+	cmd.Dir = repoPath
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to push pull ref: %v", err)
+	}
+
+	wantSHA, _ := GetBranchSHA(repoPath, "contrib")
+	Checkout(repoPath, "main")
+
+	if err := FetchRefspec(repoPath, "+refs/pull/7/head:refs/heads/review/pr-7"); err != nil {
+		t.Fatalf("FetchRefspec failed: %v", err)
+	}
+
+	sha, err := GetBranchSHA(repoPath, "review/pr-7")
+	if err != nil {
+		t.Fatalf("expected review branch to exist: %v", err)
+	}
+	if sha != wantSHA {
+		t.Errorf("expected review branch at %s, got %s", wantSHA, sha)
+	}
+
+	if err := DeleteBranch(repoPath, "review/pr-7"); err != nil {
+		t.Fatalf("DeleteBranch failed: %v", err)
+	}
+	if _, err := GetBranchSHA(repoPath, "review/pr-7"); err == nil {
+		t.Error("expected review branch to be deleted")
+	}
+}
+
 func TestAheadBehind(t *testing.T) {
 	repoPath, _, cleanup := setupTestRepoWithRemote(t)
 	defer cleanup()
@@ -616,3 +675,131 @@ func TestPull(t *testing.T) {
 		t.Errorf("from-repo2.txt should exist after pull. Files in repo: %v", names)
 	}
 }
+
+func TestCommitSubjects(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	CreateBranch(repoPath, "subjects-test", "main")
+	Checkout(repoPath, "subjects-test")
+
+	for _, subject := range []string{"First change", "Second change"} {
+		testFile := filepath.Join(repoPath, "subjects.txt")
+		os.WriteFile(testFile, []byte(subject), 0644)
+
+		cmd := exec.Command("git", "add", ".")
+		cmd.Dir = repoPath
+		cmd.Run()
+
+		cmd = exec.Command("git", "commit", "-m", subject)
+		cmd.Dir = repoPath
+		cmd.Run()
+	}
+
+	subjects, err := CommitSubjects(repoPath, "main", "subjects-test")
+	if err != nil {
+		t.Fatalf("CommitSubjects failed: %v", err)
+	}
+
+	expected := []string{"First change", "Second change"}
+	if strings.Join(subjects, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected %v, got %v", expected, subjects)
+	}
+
+	empty, err := CommitSubjects(repoPath, "subjects-test", "main")
+	if err != nil {
+		t.Fatalf("CommitSubjects failed: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("expected no commits, got %v", empty)
+	}
+}
+
+func TestForkRemoteAndUpstream(t *testing.T) {
+	repoPath, remotePath, cleanup := setupTestRepoWithRemote(t)
+	defer cleanup()
+
+	// Use the origin repo as a stand-in for a contributor's fork
+	if HasRemote(repoPath, "alice") {
+		t.Fatal("expected remote 'alice' not to exist yet")
+	}
+	if err := AddRemote(repoPath, "alice", remotePath); err != nil {
+		t.Fatalf("AddRemote failed: %v", err)
+	}
+	if !HasRemote(repoPath, "alice") {
+		t.Error("expected remote 'alice' to exist")
+	}
+
+	if err := FetchBranch(repoPath, "alice", "main"); err != nil {
+		t.Fatalf("FetchBranch failed: %v", err)
+	}
+	if err := CreateBranch(repoPath, "alice/main", "alice/main"); err != nil {
+		t.Fatalf("CreateBranch failed: %v", err)
+	}
+
+	remote, remoteBranch, err := BranchUpstream(repoPath, "alice/main")
+	if err != nil {
+		t.Fatalf("BranchUpstream failed: %v", err)
+	}
+	if remote != "alice" || remoteBranch != "main" {
+		t.Errorf("expected upstream alice/main, got %s/%s", remote, remoteBranch)
+	}
+
+	// Branches without an upstream report none
+	CreateBranch(repoPath, "local-only", "main")
+	remote, remoteBranch, err = BranchUpstream(repoPath, "local-only")
+	if err != nil || remote != "" || remoteBranch != "" {
+		t.Errorf("expected no upstream, got %q %q %v", remote, remoteBranch, err)
+	}
+
+	// Push the fork branch under a different remote name
+	if err := PushForceTo(repoPath, "alice", "alice/main", "from-fork"); err != nil {
+		t.Fatalf("PushForceTo failed: %v", err)
+	}
+	cmd := exec.Command("git", "rev-parse", "--verify", "refs/heads/from-fork")
+	cmd.Dir = remotePath
+	if err := cmd.Run(); err != nil {
+		t.Error("expected from-fork branch on the remote")
+	}
+}
+
+func TestUpdateRefAndResetHard(t *testing.T) {
+	repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	first, err := GetHeadSHA(repoPath)
+	if err != nil {
+		t.Fatalf("GetHeadSHA failed: %v", err)
+	}
+	if err := UpdateRef(repoPath, "refs/trak/undo/1", first); err != nil {
+		t.Fatalf("UpdateRef failed: %v", err)
+	}
+
+	os.WriteFile(filepath.Join(repoPath, "file.txt"), []byte("change\n"), 0644)
+	cmd := exec.Command("git", "add", ".")
+	cmd.Dir = repoPath
+	cmd.Run()
+	cmd = exec.Command("git", "commit", "-m", "Second commit")
+	cmd.Dir = repoPath
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	if err := ResetHard(repoPath, "refs/trak/undo/1"); err != nil {
+		t.Fatalf("ResetHard failed: %v", err)
+	}
+	head, _ := GetHeadSHA(repoPath)
+	if head != first {
+		t.Errorf("expected HEAD to be reset to %s, got %s", first, head)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "file.txt")); !os.IsNotExist(err) {
+		t.Error("expected file from the second commit to be gone")
+	}
+
+	if err := DeleteRef(repoPath, "refs/trak/undo/1"); err != nil {
+		t.Fatalf("DeleteRef failed: %v", err)
+	}
+	if _, err := GetBranchSHA(repoPath, "refs/trak/undo/1"); err == nil {
+		t.Error("expected ref to be deleted")
+	}
+}
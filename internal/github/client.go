@@ -0,0 +1,331 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned (wrapped in an *APIError) by Client requests.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// APIError is a non-2xx response from the GitHub REST API.
+// It unwraps to ErrNotFound, ErrUnauthorized or ErrRateLimited where applicable.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration // Set for rate-limited responses when known
+	kind       error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s failed: %d %s", e.Method, e.Path, e.StatusCode, e.Message)
+}
+
+// Unwrap returns the sentinel error matching the response, if any.
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// RateLimit is the API quota reported by the last response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Used      int
+	Reset     time.Time
+	Resource  string // e.g. "core", "graphql", "search"
+}
+
+// cachedResponse is a GET response body kept for ETag revalidation.
+type cachedResponse struct {
+	etag string
+	body []byte
+	next string // Path of the next page, if any
+}
+
+// Client is a GitHub REST API client, a faster alternative to shelling out to gh.
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse // GET responses keyed by path
+	rate  RateLimit
+}
+
+// NewClient creates a REST client for the API at baseURL authenticated with token.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+		cache:   make(map[string]cachedResponse),
+	}
+}
+
+// NewClientFromEnv creates a REST client for the configured host,
+// using the token from ResolveToken.
+func NewClientFromEnv() (*Client, error) {
+	token, err := ResolveToken()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(APIBaseURL(Host()), token), nil
+}
+
+// APIBaseURL returns the REST API root for a GitHub host.
+func APIBaseURL(h string) string {
+	if h == "" || h == DefaultHost {
+		return "https://api.github.com"
+	}
+	return "https://" + h + "/api/v3"
+}
+
+// ResolveToken returns a GitHub token from GH_TOKEN or GITHUB_TOKEN
+// (GH_ENTERPRISE_TOKEN for non-default hosts), falling back to `gh auth token`.
+func ResolveToken() (string, error) {
+	envs := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != "" {
+		envs = append([]string{"GH_ENTERPRISE_TOKEN"}, envs...)
+	}
+	for _, env := range envs {
+		if v := os.Getenv(env); v != "" {
+			return v, nil
+		}
+	}
+
+	token, err := runGH("auth", "token", "--hostname", Host())
+	if err != nil {
+		return "", fmt.Errorf("failed to get GitHub token: %w", err)
+	}
+	if token == "" {
+		return "", fmt.Errorf("no GitHub token found for %s", Host())
+	}
+	return token, nil
+}
+
+// RateLimit returns the quota reported by the most recent response.
+func (c *Client) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rate
+}
+
+// get performs a GET request and decodes the JSON response into out.
+// Responses are revalidated with their ETag, and 304s are served from the cache
+// (they don't count against the rate limit).
+func (c *Client) get(path string, out any) error {
+	_, err := c.getPage(path, out)
+	return err
+}
+
+// getPage is get for a page of a list, also returning the path of the next page from the
+// Link header, "" on the last page.
+func (c *Client) getPage(path string, out any) (next string, err error) {
+	c.mu.Lock()
+	cached, hasCached := c.cache[path]
+	c.mu.Unlock()
+
+	header := http.Header{}
+	if hasCached {
+		header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, body, err := c.do(http.MethodGet, path, nil, header)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		body, next = cached.body, cached.next
+	} else {
+		next = c.nextPage(resp.Header.Get("Link"))
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			c.mu.Lock()
+			c.cache[path] = cachedResponse{etag: etag, body: body, next: next}
+			c.mu.Unlock()
+		}
+	}
+
+	return next, decodeJSON(path, body, out)
+}
+
+// nextPage returns the path of the rel="next" URL of a Link header, "" if there is none
+// or it points outside the API.
+func (c *Client) nextPage(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		target = strings.Trim(strings.TrimSpace(target), "<>")
+		if path, ok := strings.CutPrefix(target, c.baseURL); ok {
+			return path
+		}
+	}
+	return ""
+}
+
+// post performs a POST request with a JSON body and decodes the JSON response into out.
+func (c *Client) post(path string, in, out any) error {
+	_, body, err := c.do(http.MethodPost, path, in, nil)
+	if err != nil {
+		return err
+	}
+	return decodeJSON(path, body, out)
+}
+
+// do sends a request and returns the response and its body.
+// Rate-limited requests are retried with backoff (see withRetry).
+// Non-2xx responses other than 304 are returned as an *APIError.
+func (c *Client) do(method, path string, in any, header http.Header) (*http.Response, []byte, error) {
+	var resp *http.Response
+	var body []byte
+	err := withRetry(func() error {
+		var err error
+		resp, body, err = c.doOnce(method, path, in, header)
+		return err
+	})
+	return resp, body, err
+}
+
+// doOnce sends a request once.
+func (c *Client) doOnce(method, path string, in any, header http.Header) (*http.Response, []byte, error) {
+	var reader io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response from %s: %w", path, err)
+	}
+
+	c.updateRateLimit(resp.Header)
+
+	if resp.StatusCode == http.StatusNotModified || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return resp, body, nil
+	}
+	return nil, nil, newAPIError(method, path, resp, body)
+}
+
+// updateRateLimit records the X-RateLimit-* headers of a response.
+func (c *Client) updateRateLimit(h http.Header) {
+	if h.Get("X-RateLimit-Limit") == "" {
+		return
+	}
+
+	rate := RateLimit{Resource: h.Get("X-RateLimit-Resource")}
+	rate.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	rate.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	rate.Used, _ = strconv.Atoi(h.Get("X-RateLimit-Used"))
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rate.Reset = time.Unix(reset, 0)
+	}
+
+	c.mu.Lock()
+	c.rate = rate
+	c.mu.Unlock()
+	setLastRate(rate)
+}
+
+// newAPIError builds an *APIError from a failed response.
+func newAPIError(method, path string, resp *http.Response, body []byte) *APIError {
+	var payload struct {
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	if payload.Message == "" {
+		payload.Message = strings.TrimSpace(string(body))
+	}
+
+	apiErr := &APIError{
+		Method:     method,
+		Path:       path,
+		StatusCode: resp.StatusCode,
+		Message:    payload.Message,
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		apiErr.kind = ErrNotFound
+	case resp.StatusCode == http.StatusUnauthorized:
+		apiErr.kind = ErrUnauthorized
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && (resp.Header.Get("X-RateLimit-Remaining") == "0" ||
+			resp.Header.Get("Retry-After") != "" ||
+			strings.Contains(strings.ToLower(payload.Message), "rate limit")):
+		apiErr.kind = ErrRateLimited
+		apiErr.RetryAfter = retryAfter(resp.Header)
+	}
+
+	return apiErr
+}
+
+// retryAfter returns how long to wait before retrying a rate-limited request,
+// from the Retry-After header or else the rate limit reset time. Zero if unknown.
+func retryAfter(h http.Header) time.Duration {
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if d := time.Until(time.Unix(reset, 0)); d > 0 {
+				return d
+			}
+		}
+	}
+	return 0
+}
+
+// decodeJSON decodes a response body into out, if out is non-nil.
+func decodeJSON(path string, body []byte, out any) error {
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response from %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// fakeResponse is a canned response served by fakeGitHub.
+type fakeResponse struct {
+	Status int
+	Header map[string]string
+	Body   string
+}
+
+// fakeGitHub is an httptest server serving canned responses keyed by "METHOD /path?query".
+type fakeGitHub struct {
+	*httptest.Server
+	Responses map[string]fakeResponse
+	// Requests records each request received
+	Requests []*http.Request
+	// Bodies records the decoded JSON body of requests that had one
+	Bodies map[string]map[string]any
+}
+
+func newFakeGitHub(t *testing.T) *fakeGitHub {
+	t.Helper()
+	f := &fakeGitHub{
+		Responses: make(map[string]fakeResponse),
+		Bodies:    make(map[string]map[string]any),
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.RequestURI()
+		f.Requests = append(f.Requests, r.Clone(r.Context()))
+
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			var body map[string]any
+			if err := json.Unmarshal(data, &body); err == nil {
+				f.Bodies[key] = body
+			}
+		}
+
+		resp, ok := f.Responses[key]
+		if !ok {
+			t.Logf("unexpected request: %s", key)
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message": "Not Found"}`)
+			return
+		}
+		for k, v := range resp.Header {
+			w.Header().Set(k, v)
+		}
+		status := resp.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		w.WriteHeader(status)
+		io.WriteString(w, resp.Body)
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func TestClientTypedErrors(t *testing.T) {
+	recordSleeps(t)
+	tests := []struct {
+		name   string
+		resp   fakeResponse
+		target error
+	}{
+		{"not found", fakeResponse{Status: 404, Body: `{"message": "Not Found"}`}, ErrNotFound},
+		{"unauthorized", fakeResponse{Status: 401, Body: `{"message": "Bad credentials"}`}, ErrUnauthorized},
+		{"primary rate limit", fakeResponse{Status: 403, Header: map[string]string{"X-RateLimit-Remaining": "0"}, Body: `{"message": "API rate limit exceeded"}`}, ErrRateLimited},
+		{"secondary rate limit", fakeResponse{Status: 403, Header: map[string]string{"Retry-After": "60"}, Body: `{"message": "You have exceeded a secondary rate limit"}`}, ErrRateLimited},
+		{"too many requests", fakeResponse{Status: 429, Body: `{}`}, ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeGitHub(t)
+			f.Responses["GET /user"] = tt.resp
+
+			c := NewClient(f.URL, "token")
+			_, err := c.GetCurrentUser()
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("expected %v, got %v", tt.target, err)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tt.resp.Status {
+				t.Errorf("expected status %d, got %d", tt.resp.Status, apiErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	recordSleeps(t)
+	f := newFakeGitHub(t)
+	f.Responses["GET /user"] = fakeResponse{Status: 403, Header: map[string]string{"Retry-After": "30"}, Body: `{"message": "secondary rate limit"}`}
+
+	c := NewClient(f.URL, "token")
+	_, err := c.GetCurrentUser()
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("expected RetryAfter 30s, got %v", apiErr.RetryAfter)
+	}
+}
+
+func TestClientForbiddenIsNotRateLimited(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /user"] = fakeResponse{Status: 403, Header: map[string]string{"X-RateLimit-Remaining": "4000"}, Body: `{"message": "Resource not accessible by integration"}`}
+
+	c := NewClient(f.URL, "token")
+	_, err := c.GetCurrentUser()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if errors.Is(err, ErrRateLimited) {
+		t.Error("expected plain 403 not to be treated as rate limited")
+	}
+}
+
+func TestClientETag(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /user"] = fakeResponse{Header: map[string]string{"ETag": `"v1"`}, Body: `{"login": "alice"}`}
+
+	c := NewClient(f.URL, "token")
+	if user, err := c.GetCurrentUser(); err != nil || user != "alice" {
+		t.Fatalf("unexpected first response: %q, %v", user, err)
+	}
+
+	// The server now only answers 304; the cached body must be used
+	f.Responses["GET /user"] = fakeResponse{Status: 304}
+	user, err := c.GetCurrentUser()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user != "alice" {
+		t.Errorf("expected cached user 'alice', got %q", user)
+	}
+
+	if got := f.Requests[1].Header.Get("If-None-Match"); got != `"v1"` {
+		t.Errorf("expected If-None-Match %q, got %q", `"v1"`, got)
+	}
+	if got := f.Requests[0].Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("unexpected Authorization header: %q", got)
+	}
+}
+
+func TestClientRateLimitHeaders(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /user"] = fakeResponse{
+		Header: map[string]string{
+			"X-RateLimit-Limit":     "5000",
+			"X-RateLimit-Remaining": "4990",
+			"X-RateLimit-Used":      "10",
+			"X-RateLimit-Reset":     "1700000000",
+			"X-RateLimit-Resource":  "core",
+		},
+		Body: `{"login": "alice"}`,
+	}
+
+	c := NewClient(f.URL, "token")
+	if _, err := c.GetCurrentUser(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rate := c.RateLimit()
+	if rate.Limit != 5000 || rate.Remaining != 4990 || rate.Used != 10 || rate.Resource != "core" {
+		t.Errorf("unexpected rate limit: %+v", rate)
+	}
+	if !rate.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("unexpected reset time: %v", rate.Reset)
+	}
+}
+
+func TestAPIBaseURL(t *testing.T) {
+	if got := APIBaseURL("github.com"); got != "https://api.github.com" {
+		t.Errorf("unexpected github.com API URL: %s", got)
+	}
+	if got := APIBaseURL(""); got != "https://api.github.com" {
+		t.Errorf("unexpected default API URL: %s", got)
+	}
+	if got := APIBaseURL("ghe.example.com"); got != "https://ghe.example.com/api/v3" {
+		t.Errorf("unexpected GHE API URL: %s", got)
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "env-token")
+
+	token, err := ResolveToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "env-token" {
+		t.Errorf("expected env token, got %q", token)
+	}
+}
+
+func TestResolveTokenFromGH(t *testing.T) {
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh auth token --hostname github.com"] = "gh-token"
+
+	token, err := ResolveToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "gh-token" {
+		t.Errorf("expected gh token, got %q", token)
+	}
+}
@@ -5,7 +5,9 @@ package github
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -33,12 +35,25 @@ type CommandRunner interface {
 	Run(name string, args ...string) (string, error)
 }
 
+// EnvRunner is implemented by command runners that can set extra environment variables.
+type EnvRunner interface {
+	RunEnv(env []string, name string, args ...string) (string, error)
+}
+
 // DefaultRunner executes commands using os/exec.
 type DefaultRunner struct{}
 
 // Run executes a command and returns its output.
 func (r DefaultRunner) Run(name string, args ...string) (string, error) {
+	return r.RunEnv(nil, name, args...)
+}
+
+// RunEnv executes a command with extra environment variables and returns its output.
+func (r DefaultRunner) RunEnv(env []string, name string, args ...string) (string, error) {
 	cmd := exec.Command(name, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -66,9 +81,32 @@ func ResetRunner() {
 	runner = DefaultRunner{}
 }
 
-// runGH executes a gh command and returns output.
+// runGH executes a gh command against the configured host and returns output.
+// Rate-limited commands are retried with backoff, and fail with an error matching ErrRateLimited.
 func runGH(args ...string) (string, error) {
-	return runner.Run("gh", args...)
+	var output string
+	err := withRetry(func() error {
+		var err error
+		output, err = runGHOnce(args...)
+		if err != nil && isRateLimitOutput(err.Error()) {
+			return &rateLimitedError{err: err}
+		}
+		return err
+	})
+	return output, err
+}
+
+// runGHOnce executes a gh command against the configured host once.
+// A runner that can't pass GH_HOST fails rather than let gh use its default host.
+func runGHOnce(args ...string) (string, error) {
+	if host == "" {
+		return runner.Run("gh", args...)
+	}
+	r, ok := runner.(EnvRunner)
+	if !ok {
+		return "", fmt.Errorf("cannot run gh against %s: command runner doesn't support environment variables", host)
+	}
+	return r.RunEnv([]string{"GH_HOST=" + host}, "gh", args...)
 }
 
 // GetDefaultBranch returns the default branch for a repository.
@@ -172,21 +210,50 @@ func normalizeReviewStatus(decision string) string {
 	}
 }
 
-// CreatePR creates a new pull request and returns the PR number.
+// PROptions configures a pull request created with CreatePRWithOptions.
+type PROptions struct {
+	Title     string
+	Body      string
+	Reviewers []string
+	Labels    []string
+	Assignees []string
+	Draft     bool
+}
+
+// CreatePR creates a new pull request with an empty body and returns the PR number.
 func CreatePR(remote, branch, baseBranch, title string) (int, error) {
-	output, err := runGH("pr", "create",
+	return CreatePRWithOptions(remote, branch, baseBranch, PROptions{Title: title})
+}
+
+// CreatePRWithOptions creates a new pull request and returns the PR number.
+func CreatePRWithOptions(remote, branch, baseBranch string, opts PROptions) (int, error) {
+	args := []string{"pr", "create",
 		"--repo", remote,
 		"--head", branch,
 		"--base", baseBranch,
-		"--title", title,
-		"--body", "",
-	)
+		"--title", opts.Title,
+		"--body", opts.Body,
+	}
+	if len(opts.Reviewers) > 0 {
+		args = append(args, "--reviewer", strings.Join(opts.Reviewers, ","))
+	}
+	if len(opts.Labels) > 0 {
+		args = append(args, "--label", strings.Join(opts.Labels, ","))
+	}
+	if len(opts.Assignees) > 0 {
+		args = append(args, "--assignee", strings.Join(opts.Assignees, ","))
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+
+	output, err := runGH(args...)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create PR: %w", err)
 	}
 
 	// gh pr create outputs the PR URL, we need to extract the number
-	// URL format: https://github.com/owner/repo/pull/123
+	// URL format: https://<host>/owner/repo/pull/123
 	parts := strings.Split(output, "/")
 	if len(parts) == 0 {
 		return 0, fmt.Errorf("unexpected PR create output: %s", output)
@@ -203,7 +270,25 @@ func CreatePR(remote, branch, baseBranch, title string) (int, error) {
 
 // GetPRForBranch returns the PR for a specific branch, or nil if none exists.
 func GetPRForBranch(remote, branch string) (*PR, error) {
-	output, err := runGH("pr", "view", branch,
+	pr, err := viewPR(remote, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR for branch %s: %w", branch, err)
+	}
+	return pr, nil
+}
+
+// GetPR returns the PR with the given number, or nil if none exists.
+func GetPR(remote string, number int) (*PR, error) {
+	pr, err := viewPR(remote, strconv.Itoa(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+	return pr, nil
+}
+
+// viewPR runs gh pr view for a branch name or PR number, returning nil if no PR matches.
+func viewPR(remote, selector string) (*PR, error) {
+	output, err := runGH("pr", "view", selector,
 		"--repo", remote,
 		"--json", "number,headRefName,state,statusCheckRollup,reviewDecision",
 	)
@@ -213,7 +298,7 @@ func GetPRForBranch(remote, branch string) (*PR, error) {
 			strings.Contains(err.Error(), "Could not resolve") {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("failed to get PR for branch %s: %w", branch, err)
+		return nil, err
 	}
 
 	if output == "" {
@@ -270,6 +355,9 @@ func ListMyBranches(remote string) ([]RemoteBranch, error) {
 			fmt.Sprintf("repos/%s/branches/%s", remote, branchName),
 			"--jq", ".name, .commit.sha, .commit.commit.committer.date",
 		)
+		if errors.Is(err, ErrRateLimited) {
+			return nil, fmt.Errorf("failed to get branch %s: %w", branchName, err)
+		}
 		if err != nil {
 			// Branch might have been deleted, skip it
 			continue
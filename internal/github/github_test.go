@@ -208,6 +208,29 @@ func TestCreatePR_Error(t *testing.T) {
 	}
 }
 
+func TestCreatePRWithOptions(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh pr create --repo owner/repo --head feature-branch --base main --title Add thing --body Body text --reviewer alice,bob --label bug --assignee @me --draft"] = "https://github.com/owner/repo/pull/7"
+
+	prNum, err := CreatePRWithOptions("owner/repo", "feature-branch", "main", PROptions{
+		Title:     "Add thing",
+		Body:      "Body text",
+		Reviewers: []string{"alice", "bob"},
+		Labels:    []string{"bug"},
+		Assignees: []string{"@me"},
+		Draft:     true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prNum != 7 {
+		t.Errorf("expected PR number 7, got %d", prNum)
+	}
+}
+
 func TestGetPRForBranch(t *testing.T) {
 	mock := NewMockRunner()
 	SetRunner(mock)
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PRHead describes where a pull request's head branch lives.
+type PRHead struct {
+	Number int
+	Title  string
+	Branch string // Head branch name, in the fork for cross-repository PRs
+	Repo   string // Repository of the head branch, "owner/repo"
+	Owner  string // Owner of the head repository
+	IsFork bool   // Head branch lives in a fork
+}
+
+// ghPRHead is the JSON structure returned by gh pr view for a PR's head.
+type ghPRHead struct {
+	Number         int    `json:"number"`
+	Title          string `json:"title"`
+	HeadRefName    string `json:"headRefName"`
+	HeadRepository struct {
+		Name string `json:"name"`
+	} `json:"headRepository"`
+	HeadRepositoryOwner struct {
+		Login string `json:"login"`
+	} `json:"headRepositoryOwner"`
+	IsCrossRepository bool `json:"isCrossRepository"`
+}
+
+// GetPRHead returns the head branch and repository of a pull request.
+func GetPRHead(remote string, number int) (*PRHead, error) {
+	output, err := runGH("pr", "view", strconv.Itoa(number),
+		"--repo", remote,
+		"--json", "number,title,headRefName,headRepository,headRepositoryOwner,isCrossRepository",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+
+	var pr ghPRHead
+	if err := json.Unmarshal([]byte(output), &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse PR: %w", err)
+	}
+
+	head := &PRHead{
+		Number: pr.Number,
+		Title:  pr.Title,
+		Branch: pr.HeadRefName,
+		Repo:   remote,
+		Owner:  strings.SplitN(remote, "/", 2)[0],
+		IsFork: pr.IsCrossRepository,
+	}
+	if pr.IsCrossRepository {
+		if pr.HeadRepository.Name == "" || pr.HeadRepositoryOwner.Login == "" {
+			return nil, fmt.Errorf("the fork of PR #%d no longer exists", number)
+		}
+		head.Owner = pr.HeadRepositoryOwner.Login
+		head.Repo = head.Owner + "/" + pr.HeadRepository.Name
+	}
+	return head, nil
+}
+
+// ParsePRRef parses a pull request reference: a number ("1234" or "#1234") or a
+// PR URL ("https://github.com/owner/repo/pull/1234", optionally with a trailing
+// path such as "/files"). remote is "owner/repo" for URLs and empty for numbers.
+func ParsePRRef(ref string) (remote string, number int, err error) {
+	ref = strings.TrimSpace(ref)
+
+	if strings.Contains(ref, "://") {
+		u, err := url.Parse(ref)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid PR URL: %s", ref)
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) < 4 || parts[2] != "pull" {
+			return "", 0, fmt.Errorf("invalid PR URL: %s", ref)
+		}
+		remote = parts[0] + "/" + parts[1]
+		ref = parts[3]
+	}
+
+	number, err = strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	if err != nil || number <= 0 {
+		return "", 0, fmt.Errorf("invalid PR reference: %s", ref)
+	}
+	return remote, number, nil
+}
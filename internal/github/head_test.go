@@ -0,0 +1,75 @@
+package github
+
+import "testing"
+
+const prHeadCmd = "gh pr view 12 --repo owner/repo --json number,title,headRefName,headRepository,headRepositoryOwner,isCrossRepository"
+
+func TestGetPRHead(t *testing.T) {
+	mock := NewMockRunner()
+	mock.Responses[prHeadCmd] = `{"number": 12, "title": "Add feature", "headRefName": "feature", "headRepository": {"name": "repo"}, "headRepositoryOwner": {"login": "owner"}, "isCrossRepository": false}`
+	SetRunner(mock)
+	defer ResetRunner()
+
+	head, err := GetPRHead("owner/repo", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if head.Branch != "feature" || head.Repo != "owner/repo" || head.Owner != "owner" || head.IsFork {
+		t.Errorf("unexpected head: %+v", head)
+	}
+}
+
+func TestGetPRHeadFork(t *testing.T) {
+	mock := NewMockRunner()
+	mock.Responses[prHeadCmd] = `{"number": 12, "title": "Fix typo", "headRefName": "main", "headRepository": {"name": "repo-fork"}, "headRepositoryOwner": {"login": "alice"}, "isCrossRepository": true}`
+	SetRunner(mock)
+	defer ResetRunner()
+
+	head, err := GetPRHead("owner/repo", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if head.Branch != "main" || head.Repo != "alice/repo-fork" || head.Owner != "alice" || !head.IsFork {
+		t.Errorf("unexpected head: %+v", head)
+	}
+}
+
+func TestGetPRHeadDeletedFork(t *testing.T) {
+	mock := NewMockRunner()
+	mock.Responses[prHeadCmd] = `{"number": 12, "headRefName": "main", "headRepository": null, "headRepositoryOwner": null, "isCrossRepository": true}`
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if _, err := GetPRHead("owner/repo", 12); err == nil {
+		t.Error("expected error for a deleted fork")
+	}
+}
+
+func TestParsePRRef(t *testing.T) {
+	tests := []struct {
+		ref        string
+		wantRemote string
+		wantNumber int
+		wantErr    bool
+	}{
+		{"1234", "", 1234, false},
+		{"#1234", "", 1234, false},
+		{"https://github.com/owner/repo/pull/1234", "owner/repo", 1234, false},
+		{"https://github.example.com/owner/repo/pull/7/files", "owner/repo", 7, false},
+		{"https://github.com/owner/repo/issues/7", "", 0, true},
+		{"feature", "", 0, true},
+		{"0", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			remote, number, err := ParsePRRef(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePRRef(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			}
+			if remote != tt.wantRemote || number != tt.wantNumber {
+				t.Errorf("ParsePRRef(%q) = %q, %d, want %q, %d", tt.ref, remote, number, tt.wantRemote, tt.wantNumber)
+			}
+		})
+	}
+}
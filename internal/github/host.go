@@ -0,0 +1,107 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultHost is the host used when no GitHub Enterprise host is configured.
+const DefaultHost = "github.com"
+
+// host is the GitHub host gh commands run against. Empty means gh's default.
+var host string
+
+// SetHost sets the GitHub host used by gh commands and URL construction,
+// e.g. a GitHub Enterprise Server hostname. Empty or "github.com" restores the default.
+func SetHost(h string) {
+	if h == DefaultHost {
+		h = ""
+	}
+	host = h
+}
+
+// Host returns the GitHub host in use.
+func Host() string {
+	if host == "" {
+		return DefaultHost
+	}
+	return host
+}
+
+// PRURL returns the web URL of a pull request.
+func PRURL(remote string, number int) string {
+	return fmt.Sprintf("https://%s/%s/pull/%d", Host(), remote, number)
+}
+
+// CloneURL returns the HTTPS clone URL of a repository.
+func CloneURL(remote string) string {
+	return fmt.Sprintf("https://%s/%s.git", Host(), remote)
+}
+
+// HostFromURL extracts the hostname from a git remote URL.
+// Supports scp-like SSH ("git@host:owner/repo.git") and URL forms
+// ("https://host/owner/repo", "ssh://git@host:22/owner/repo").
+// Returns "" for local paths and unparseable URLs.
+func HostFromURL(remoteURL string) string {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if remoteURL == "" {
+		return ""
+	}
+
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil || u.Scheme == "file" {
+			return ""
+		}
+		return u.Hostname()
+	}
+
+	// scp-like syntax: [user@]host:path
+	colon := strings.Index(remoteURL, ":")
+	if colon <= 0 || strings.Contains(remoteURL[:colon], "/") {
+		return ""
+	}
+	h := remoteURL[:colon]
+	if at := strings.LastIndex(h, "@"); at >= 0 {
+		h = h[at+1:]
+	}
+	return h
+}
+
+// IsAuthenticatedHost reports whether gh has credentials for a host, i.e. whether it is
+// listed in gh's hosts.yml. The default host is always accepted, gh uses it anyway.
+func IsAuthenticatedHost(h string) bool {
+	if h == DefaultHost {
+		return true
+	}
+	data, err := os.ReadFile(filepath.Join(ghConfigDir(), "hosts.yml"))
+	if err != nil {
+		return false
+	}
+	var hosts map[string]any
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return false
+	}
+	_, ok := hosts[h]
+	return ok
+}
+
+// ghConfigDir returns the directory gh keeps its configuration in.
+func ghConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
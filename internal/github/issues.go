@@ -0,0 +1,47 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Issue is a GitHub issue.
+type Issue struct {
+	Number int
+	Title  string
+	State  string // "open", "closed"
+	URL    string
+}
+
+// ghIssue is the JSON structure returned by gh issue view.
+type ghIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+}
+
+// GetIssue returns the issue with the given number.
+func GetIssue(remote string, number int) (*Issue, error) {
+	output, err := runGH("issue", "view", strconv.Itoa(number),
+		"--repo", remote,
+		"--json", "number,title,state,url",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+
+	var iss ghIssue
+	if err := json.Unmarshal([]byte(output), &iss); err != nil {
+		return nil, fmt.Errorf("failed to parse issue: %w", err)
+	}
+
+	return &Issue{
+		Number: iss.Number,
+		Title:  iss.Title,
+		State:  strings.ToLower(iss.State),
+		URL:    iss.URL,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package github
+
+import "testing"
+
+func TestGetIssue(t *testing.T) {
+	mock := NewMockRunner()
+	mock.Responses["gh issue view 42 --repo owner/repo --json number,title,state,url"] = `{"number": 42, "title": "Fix login bug", "state": "OPEN", "url": "https://github.com/owner/repo/issues/42"}`
+	SetRunner(mock)
+	defer ResetRunner()
+
+	iss, err := GetIssue("owner/repo", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if iss.Number != 42 || iss.Title != "Fix login bug" || iss.State != "open" {
+		t.Errorf("unexpected issue: %+v", iss)
+	}
+	if iss.URL != "https://github.com/owner/repo/issues/42" {
+		t.Errorf("unexpected URL: %s", iss.URL)
+	}
+}
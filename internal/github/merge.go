@@ -0,0 +1,52 @@
+package github
+
+import "fmt"
+
+// Merge methods supported by GitHub.
+const (
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+	MergeMethodMerge  = "merge"
+)
+
+// DefaultMergeMethod is used when no merge method is configured.
+const DefaultMergeMethod = MergeMethodSquash
+
+// mergeMethodFlag returns the gh pr merge flag for a merge method.
+func mergeMethodFlag(method string) (string, error) {
+	switch method {
+	case "":
+		return "--" + DefaultMergeMethod, nil
+	case MergeMethodSquash, MergeMethodRebase, MergeMethodMerge:
+		return "--" + method, nil
+	default:
+		return "", fmt.Errorf("unknown merge method %q (expected squash, rebase or merge)", method)
+	}
+}
+
+// MergePR merges the PR for a branch immediately using the given method.
+func MergePR(remote, branch, method string) error {
+	flag, err := mergeMethodFlag(method)
+	if err != nil {
+		return err
+	}
+
+	if _, err := runGH("pr", "merge", branch, "--repo", remote, flag); err != nil {
+		return fmt.Errorf("failed to merge PR for branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+// EnableAutoMerge enables auto-merge on the PR for a branch, so that GitHub merges
+// it with the given method once all requirements are met.
+func EnableAutoMerge(remote, branch, method string) error {
+	flag, err := mergeMethodFlag(method)
+	if err != nil {
+		return err
+	}
+
+	if _, err := runGH("pr", "merge", branch, "--repo", remote, flag, "--auto"); err != nil {
+		return fmt.Errorf("failed to enable auto-merge for branch %s: %w", branch, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMergePR(t *testing.T) {
+	tests := []struct {
+		method string
+		cmd    string
+	}{
+		{"", "gh pr merge feature --repo owner/repo --squash"},
+		{"squash", "gh pr merge feature --repo owner/repo --squash"},
+		{"rebase", "gh pr merge feature --repo owner/repo --rebase"},
+		{"merge", "gh pr merge feature --repo owner/repo --merge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			mock := NewMockRunner()
+			SetRunner(mock)
+			defer ResetRunner()
+
+			mock.Responses[tt.cmd] = ""
+
+			if err := MergePR("owner/repo", "feature", tt.method); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMergePR_InvalidMethod(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if err := MergePR("owner/repo", "feature", "fast-forward"); err == nil {
+		t.Fatal("expected error for invalid merge method")
+	}
+	if len(mock.Calls) != 0 {
+		t.Errorf("expected no gh calls, got %v", mock.Calls)
+	}
+}
+
+func TestEnableAutoMerge(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	cmd := "gh pr merge feature --repo owner/repo --rebase --auto"
+	mock.Responses[cmd] = ""
+
+	if err := EnableAutoMerge("owner/repo", "feature", "rebase"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mock.Errors[cmd] = fmt.Errorf("auto-merge is not allowed for this repository")
+	if err := EnableAutoMerge("owner/repo", "feature", "rebase"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
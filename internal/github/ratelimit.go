@@ -0,0 +1,125 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxAttempts is how many times a rate-limited request is tried in total.
+	maxAttempts = 4
+	// baseBackoff is the first retry delay when the server doesn't say how long to wait.
+	// It doubles on each further retry.
+	baseBackoff = 2 * time.Second
+	// maxRetryWait caps a single wait. Longer waits (e.g. until the hourly quota resets)
+	// are not worth blocking on, so the error is returned instead.
+	maxRetryWait = time.Minute
+)
+
+// sleep waits between retries. It can be replaced in tests.
+var sleep = time.Sleep
+
+// rateLimitMarkers are substrings of gh error output that indicate a rate limit.
+var rateLimitMarkers = []string{
+	"api rate limit exceeded",
+	"secondary rate limit",
+	"http 429",
+}
+
+// isRateLimitOutput reports whether a gh error message indicates a rate limit.
+func isRateLimitOutput(msg string) bool {
+	msg = strings.ToLower(msg)
+	for _, marker := range rateLimitMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetry calls fn, retrying with exponential backoff while it fails with ErrRateLimited.
+// An *APIError's RetryAfter is honored instead of the backoff when known.
+func withRetry(fn func() error) error {
+	backoff := baseBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || !errors.Is(err, ErrRateLimited) || attempt == maxAttempts {
+			return err
+		}
+
+		wait := backoff
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		if wait > maxRetryWait {
+			return err
+		}
+
+		sleep(wait)
+		backoff *= 2
+	}
+}
+
+// rateLimitedError wraps a gh failure caused by a rate limit so it matches ErrRateLimited.
+type rateLimitedError struct {
+	err error
+}
+
+func (e *rateLimitedError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrRateLimited, e.err)
+}
+
+func (e *rateLimitedError) Unwrap() []error {
+	return []error{ErrRateLimited, e.err}
+}
+
+// lastRate is the most recent quota seen by any client or gh call.
+var (
+	lastRateMu sync.Mutex
+	lastRate   RateLimit
+)
+
+// setLastRate records the most recent quota.
+func setLastRate(rate RateLimit) {
+	lastRateMu.Lock()
+	lastRate = rate
+	lastRateMu.Unlock()
+}
+
+// LastRateLimit returns the most recently observed API quota.
+// The zero value means no quota has been observed yet.
+func LastRateLimit() RateLimit {
+	lastRateMu.Lock()
+	defer lastRateMu.Unlock()
+	return lastRate
+}
+
+// FetchRateLimit queries the core API quota via gh. The query itself doesn't count
+// against the quota.
+func FetchRateLimit() (RateLimit, error) {
+	output, err := runGH("api", "rate_limit", "--jq", ".resources.core | .limit, .remaining, .used, .reset")
+	if err != nil {
+		return RateLimit{}, fmt.Errorf("failed to get rate limit: %w", err)
+	}
+
+	var limit, remaining, used int
+	var reset int64
+	if _, err := fmt.Sscan(output, &limit, &remaining, &used, &reset); err != nil {
+		return RateLimit{}, fmt.Errorf("failed to parse rate limit: %w", err)
+	}
+
+	rate := RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		Reset:     time.Unix(reset, 0),
+		Resource:  "core",
+	}
+	setLastRate(rate)
+	return rate, nil
+}
@@ -0,0 +1,166 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// recordSleeps replaces sleep for the duration of a test and returns the recorded waits.
+func recordSleeps(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	sleep = func(d time.Duration) { waits = append(waits, d) }
+	t.Cleanup(func() { sleep = time.Sleep })
+	return &waits
+}
+
+func TestWithRetryBackoff(t *testing.T) {
+	waits := recordSleeps(t)
+
+	calls := 0
+	err := withRetry(func() error {
+		calls++
+		if calls < 3 {
+			return &rateLimitedError{err: errors.New("gh: API rate limit exceeded")}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+	want := []time.Duration{baseBackoff, 2 * baseBackoff}
+	if len(*waits) != len(want) || (*waits)[0] != want[0] || (*waits)[1] != want[1] {
+		t.Errorf("expected waits %v, got %v", want, *waits)
+	}
+}
+
+func TestWithRetryGivesUp(t *testing.T) {
+	waits := recordSleeps(t)
+
+	calls := 0
+	err := withRetry(func() error {
+		calls++
+		return &APIError{StatusCode: 429, kind: ErrRateLimited}
+	})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if calls != maxAttempts {
+		t.Errorf("expected %d calls, got %d", maxAttempts, calls)
+	}
+	if len(*waits) != maxAttempts-1 {
+		t.Errorf("expected %d waits, got %d", maxAttempts-1, len(*waits))
+	}
+}
+
+func TestWithRetryHonorsRetryAfter(t *testing.T) {
+	waits := recordSleeps(t)
+
+	calls := 0
+	_ = withRetry(func() error {
+		calls++
+		if calls == 1 {
+			return &APIError{StatusCode: 403, RetryAfter: 7 * time.Second, kind: ErrRateLimited}
+		}
+		return nil
+	})
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Errorf("expected a single 7s wait, got %v", *waits)
+	}
+
+	// Waits beyond maxRetryWait are not worth blocking on
+	*waits = nil
+	calls = 0
+	err := withRetry(func() error {
+		calls++
+		return &APIError{StatusCode: 403, RetryAfter: time.Hour, kind: ErrRateLimited}
+	})
+	if !errors.Is(err, ErrRateLimited) || calls != 1 || len(*waits) != 0 {
+		t.Errorf("expected immediate failure, got err=%v calls=%d waits=%v", err, calls, *waits)
+	}
+}
+
+func TestWithRetryIgnoresOtherErrors(t *testing.T) {
+	recordSleeps(t)
+
+	calls := 0
+	err := withRetry(func() error {
+		calls++
+		return errors.New("boom")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected one failing call, got err=%v calls=%d", err, calls)
+	}
+}
+
+func TestRunGHRetriesRateLimit(t *testing.T) {
+	waits := recordSleeps(t)
+	mock := NewMockRunner()
+	mock.Errors["gh api user --jq .login"] = errors.New("gh api user failed: exit status 1\nstderr: HTTP 403: API rate limit exceeded for user ID 1")
+	SetRunner(mock)
+	defer ResetRunner()
+
+	_, err := GetCurrentUser()
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if len(mock.Calls) != maxAttempts {
+		t.Errorf("expected %d attempts, got %d", maxAttempts, len(mock.Calls))
+	}
+	if len(*waits) != maxAttempts-1 {
+		t.Errorf("expected %d waits, got %d", maxAttempts-1, len(*waits))
+	}
+}
+
+func TestClientRetriesRateLimit(t *testing.T) {
+	recordSleeps(t)
+
+	calls := 0
+	f := newFakeGitHub(t)
+	f.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Write([]byte(`{"login": "alice"}`))
+	})
+
+	c := NewClient(f.URL, "token")
+	user, err := c.GetCurrentUser()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user != "alice" || calls != 2 {
+		t.Errorf("expected alice after 2 calls, got %q after %d", user, calls)
+	}
+	if got := LastRateLimit().Remaining; got != 4999 {
+		t.Errorf("expected last rate limit remaining 4999, got %d", got)
+	}
+}
+
+func TestFetchRateLimit(t *testing.T) {
+	mock := NewMockRunner()
+	mock.Responses["gh api rate_limit --jq .resources.core | .limit, .remaining, .used, .reset"] = "5000\n4200\n800\n1700000000"
+	SetRunner(mock)
+	defer ResetRunner()
+
+	rate, err := FetchRateLimit()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rate.Limit != 5000 || rate.Remaining != 4200 || rate.Used != 800 {
+		t.Errorf("unexpected rate limit: %+v", rate)
+	}
+	if !rate.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("unexpected reset: %v", rate.Reset)
+	}
+}
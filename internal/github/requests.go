@@ -0,0 +1,82 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ReviewRequest is an open pull request awaiting the current user's review.
+type ReviewRequest struct {
+	Number    int
+	Title     string
+	Branch    string // Head branch name, in the fork for cross-repository PRs
+	Author    string
+	URL       string
+	IsFork    bool // Head branch lives in a fork
+	Draft     bool
+	UpdatedAt time.Time
+}
+
+// reviewRequestFields are the gh pr list JSON fields used for review requests.
+const reviewRequestFields = "number,title,headRefName,author,url,isCrossRepository,isDraft,updatedAt"
+
+// reviewRequestLimit caps the review requests listed, well above gh's default of 30, so
+// that review tracks of PRs beyond it aren't taken as reviewed and cleaned up.
+const reviewRequestLimit = "1000"
+
+// ghReviewRequest is the JSON structure returned by gh pr list for review requests.
+type ghReviewRequest struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	HeadRefName string `json:"headRefName"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	URL               string `json:"url"`
+	IsCrossRepository bool   `json:"isCrossRepository"`
+	IsDraft           bool   `json:"isDraft"`
+	UpdatedAt         string `json:"updatedAt"`
+}
+
+// ListReviewRequests returns the open PRs where the current user's review is requested.
+// A PR drops out of this list once the review is submitted.
+func ListReviewRequests(remote string) ([]ReviewRequest, error) {
+	output, err := runGH("pr", "list",
+		"--repo", remote,
+		"--search", "review-requested:@me",
+		"--state", "open",
+		"--limit", reviewRequestLimit,
+		"--json", reviewRequestFields,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review requests: %w", err)
+	}
+
+	if output == "" || output == "[]" {
+		return []ReviewRequest{}, nil
+	}
+
+	var ghReqs []ghReviewRequest
+	if err := json.Unmarshal([]byte(output), &ghReqs); err != nil {
+		return nil, fmt.Errorf("failed to parse review requests: %w", err)
+	}
+
+	reqs := make([]ReviewRequest, len(ghReqs))
+	for i, r := range ghReqs {
+		reqs[i] = ReviewRequest{
+			Number: r.Number,
+			Title:  r.Title,
+			Branch: r.HeadRefName,
+			Author: r.Author.Login,
+			URL:    r.URL,
+			IsFork: r.IsCrossRepository,
+			Draft:  r.IsDraft,
+		}
+		if t, err := time.Parse(time.RFC3339, r.UpdatedAt); err == nil {
+			reqs[i].UpdatedAt = t
+		}
+	}
+
+	return reqs, nil
+}
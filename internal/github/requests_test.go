@@ -0,0 +1,93 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestListReviewRequests(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh pr list --repo owner/repo --search review-requested:@me --state open --limit 1000 --json number,title,headRefName,author,url,isCrossRepository,isDraft,updatedAt"] = `[
+		{"number": 12, "title": "Add widgets", "headRefName": "alice/widgets", "author": {"login": "alice"}, "url": "https://github.com/owner/repo/pull/12", "isCrossRepository": false, "isDraft": false, "updatedAt": "2024-01-15T10:30:00Z"},
+		{"number": 15, "title": "Fix typo", "headRefName": "patch-1", "author": {"login": "bob"}, "url": "https://github.com/owner/repo/pull/15", "isCrossRepository": true, "isDraft": true, "updatedAt": "bogus"}
+	]`
+
+	reqs, err := ListReviewRequests("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 review requests, got %d", len(reqs))
+	}
+
+	if reqs[0].Number != 12 || reqs[0].Author != "alice" || reqs[0].Branch != "alice/widgets" {
+		t.Errorf("unexpected first request: %+v", reqs[0])
+	}
+	if reqs[0].IsFork {
+		t.Error("expected first request not to be a fork")
+	}
+	if reqs[0].UpdatedAt.IsZero() {
+		t.Error("expected UpdatedAt to be parsed")
+	}
+
+	if !reqs[1].IsFork || !reqs[1].Draft {
+		t.Errorf("expected second request to be a draft from a fork: %+v", reqs[1])
+	}
+	if !reqs[1].UpdatedAt.IsZero() {
+		t.Error("expected invalid UpdatedAt to be left zero")
+	}
+}
+
+func TestListReviewRequests_Empty(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh pr list --repo owner/repo --search review-requested:@me --state open --limit 1000 --json number,title,headRefName,author,url,isCrossRepository,isDraft,updatedAt"] = "[]"
+
+	reqs, err := ListReviewRequests("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reqs) != 0 {
+		t.Errorf("expected no review requests, got %d", len(reqs))
+	}
+}
+
+func TestGetPR(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh pr view 12 --repo owner/repo --json number,headRefName,state,statusCheckRollup,reviewDecision"] = `{"number": 12, "headRefName": "patch-1", "state": "MERGED", "statusCheckRollup": "SUCCESS", "reviewDecision": "APPROVED"}`
+
+	pr, err := GetPR("owner/repo", 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr == nil {
+		t.Fatal("expected PR, got nil")
+	}
+	if pr.Branch != "patch-1" || pr.State != "merged" {
+		t.Errorf("unexpected PR: %+v", pr)
+	}
+}
+
+func TestGetPR_NotFound(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Errors["gh pr view 99 --repo owner/repo --json number,headRefName,state,statusCheckRollup,reviewDecision"] = fmt.Errorf("Could not resolve to a PullRequest with the number of 99")
+
+	pr, err := GetPR("owner/repo", 99)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr != nil {
+		t.Errorf("expected nil PR, got %+v", pr)
+	}
+}
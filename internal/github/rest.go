@@ -0,0 +1,351 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// restPull is a pull request as returned by the REST API.
+type restPull struct {
+	Number int    `json:"number"`
+	State  string `json:"state"` // open, closed
+	Merged bool   `json:"merged"`
+	// MergedAt is set for merged PRs in list responses, which omit Merged
+	MergedAt *string `json:"merged_at"`
+	Head     struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// restReview is a pull request review as returned by the REST API.
+type restReview struct {
+	State string `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING
+	User  struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// repoPath returns the API path of a repository.
+func repoPath(remote string) string {
+	return "/repos/" + remote
+}
+
+// GetDefaultBranch returns the default branch for a repository.
+func (c *Client) GetDefaultBranch(remote string) (string, error) {
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := c.get(repoPath(remote), &repo); err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+	if repo.DefaultBranch == "" {
+		return "", fmt.Errorf("no default branch found for %s", remote)
+	}
+	return repo.DefaultBranch, nil
+}
+
+// GetCurrentUser returns the authenticated GitHub username.
+func (c *Client) GetCurrentUser() (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := c.get("/user", &user); err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+	if user.Login == "" {
+		return "", fmt.Errorf("no user logged in")
+	}
+	return user.Login, nil
+}
+
+// ListMyPRs returns all open PRs authored by the current user for a repository.
+func (c *Client) ListMyPRs(remote string) ([]PR, error) {
+	user, err := c.GetCurrentUser()
+	if err != nil {
+		return nil, err
+	}
+
+	prs := []PR{}
+	for path := repoPath(remote) + "/pulls?state=open&per_page=100"; path != ""; {
+		var pulls []restPull
+		next, err := c.getPage(path, &pulls)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PRs: %w", err)
+		}
+		for _, p := range pulls {
+			if p.User.Login == user {
+				prs = append(prs, c.withStatus(remote, p))
+			}
+		}
+		path = next
+	}
+	return prs, nil
+}
+
+// GetPRForBranch returns the PR for a specific branch, or nil if none exists.
+func (c *Client) GetPRForBranch(remote, branch string) (*PR, error) {
+	owner, _, err := splitRemote(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("head", owner+":"+branch)
+	q.Set("state", "all")
+	q.Set("per_page", "1")
+
+	var pulls []restPull
+	if err := c.get(repoPath(remote)+"/pulls?"+q.Encode(), &pulls); err != nil {
+		return nil, fmt.Errorf("failed to get PR for branch %s: %w", branch, err)
+	}
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+
+	pr := c.withStatus(remote, pulls[0])
+	return &pr, nil
+}
+
+// GetPR returns the PR with the given number, or nil if none exists.
+func (c *Client) GetPR(remote string, number int) (*PR, error) {
+	var p restPull
+	if err := c.get(fmt.Sprintf("%s/pulls/%d", repoPath(remote), number), &p); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+	pr := c.withStatus(remote, p)
+	return &pr, nil
+}
+
+// CreatePRWithOptions creates a new pull request and returns the PR number.
+// Reviewers, labels and assignees are added after creation.
+func (c *Client) CreatePRWithOptions(remote, branch, baseBranch string, opts PROptions) (int, error) {
+	req := map[string]any{
+		"title": opts.Title,
+		"head":  branch,
+		"base":  baseBranch,
+		"body":  opts.Body,
+		"draft": opts.Draft,
+	}
+
+	var p restPull
+	if err := c.post(repoPath(remote)+"/pulls", req, &p); err != nil {
+		return 0, fmt.Errorf("failed to create PR: %w", err)
+	}
+
+	if len(opts.Reviewers) > 0 {
+		reviewers, err := c.resolveMe(opts.Reviewers)
+		if err != nil {
+			return p.Number, err
+		}
+		path := fmt.Sprintf("%s/pulls/%d/requested_reviewers", repoPath(remote), p.Number)
+		if err := c.post(path, map[string]any{"reviewers": reviewers}, nil); err != nil {
+			return p.Number, fmt.Errorf("created PR #%d, but failed to request reviewers: %w", p.Number, err)
+		}
+	}
+	if len(opts.Labels) > 0 {
+		path := fmt.Sprintf("%s/issues/%d/labels", repoPath(remote), p.Number)
+		if err := c.post(path, map[string]any{"labels": opts.Labels}, nil); err != nil {
+			return p.Number, fmt.Errorf("created PR #%d, but failed to add labels: %w", p.Number, err)
+		}
+	}
+	if len(opts.Assignees) > 0 {
+		assignees, err := c.resolveMe(opts.Assignees)
+		if err != nil {
+			return p.Number, err
+		}
+		path := fmt.Sprintf("%s/issues/%d/assignees", repoPath(remote), p.Number)
+		if err := c.post(path, map[string]any{"assignees": assignees}, nil); err != nil {
+			return p.Number, fmt.Errorf("created PR #%d, but failed to add assignees: %w", p.Number, err)
+		}
+	}
+
+	return p.Number, nil
+}
+
+// resolveMe replaces "@me" in a list of usernames with the current user.
+func (c *Client) resolveMe(names []string) ([]string, error) {
+	out := make([]string, len(names))
+	for i, n := range names {
+		if n == "@me" {
+			user, err := c.GetCurrentUser()
+			if err != nil {
+				return nil, err
+			}
+			n = user
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// ListMyBranches returns remote branches where the current user has open PRs.
+func (c *Client) ListMyBranches(remote string) ([]RemoteBranch, error) {
+	prs, err := c.ListMyPRs(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	branches := make([]RemoteBranch, 0, len(prs))
+	for _, pr := range prs {
+		if seen[pr.Branch] {
+			continue
+		}
+		seen[pr.Branch] = true
+
+		var b struct {
+			Name   string `json:"name"`
+			Commit struct {
+				SHA    string `json:"sha"`
+				Commit struct {
+					Committer struct {
+						Date string `json:"date"`
+					} `json:"committer"`
+				} `json:"commit"`
+			} `json:"commit"`
+		}
+		if err := c.get(repoPath(remote)+"/branches/"+url.PathEscape(pr.Branch), &b); err != nil {
+			if errors.Is(err, ErrRateLimited) {
+				return nil, fmt.Errorf("failed to get branch %s: %w", pr.Branch, err)
+			}
+			// Branch might have been deleted, skip it
+			continue
+		}
+
+		var age time.Duration
+		if t, err := time.Parse(time.RFC3339, b.Commit.Commit.Committer.Date); err == nil {
+			age = time.Since(t)
+		}
+
+		branches = append(branches, RemoteBranch{
+			Name:       b.Name,
+			LastCommit: b.Commit.SHA,
+			Age:        age,
+		})
+	}
+	return branches, nil
+}
+
+// withStatus converts a REST pull request, filling in CI and review status.
+// Failures to fetch either leave them unknown.
+func (c *Client) withStatus(remote string, p restPull) PR {
+	state := p.State
+	if p.Merged || p.MergedAt != nil {
+		state = "merged"
+	}
+
+	pr := PR{
+		Number:       p.Number,
+		Branch:       p.Head.Ref,
+		State:        state,
+		CIStatus:     "unknown",
+		ReviewStatus: "unknown",
+	}
+
+	if p.Head.SHA != "" {
+		if status, err := c.ciStatus(remote, p.Head.SHA); err == nil {
+			pr.CIStatus = status
+		}
+	}
+
+	var reviews []restReview
+	if err := c.get(fmt.Sprintf("%s/pulls/%d/reviews?per_page=100", repoPath(remote), p.Number), &reviews); err == nil {
+		pr.ReviewStatus = reviewStatusFromReviews(reviews)
+	}
+
+	return pr
+}
+
+// ciStatus combines the check runs and commit statuses of a commit into one status.
+func (c *Client) ciStatus(remote, sha string) (string, error) {
+	var checks struct {
+		CheckRuns []struct {
+			Status     string `json:"status"` // queued, in_progress, completed
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := c.get(fmt.Sprintf("%s/commits/%s/check-runs?per_page=100", repoPath(remote), sha), &checks); err != nil {
+		return "", err
+	}
+
+	var combined struct {
+		State    string `json:"state"` // success, failure, pending
+		Statuses []any  `json:"statuses"`
+	}
+	if err := c.get(fmt.Sprintf("%s/commits/%s/status", repoPath(remote), sha), &combined); err != nil {
+		return "", err
+	}
+
+	states := make([]string, 0, len(checks.CheckRuns)+1)
+	for _, run := range checks.CheckRuns {
+		if run.Status != "completed" {
+			states = append(states, "pending")
+			continue
+		}
+		switch run.Conclusion {
+		case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+			states = append(states, "failure")
+		default:
+			states = append(states, "success")
+		}
+	}
+	// The combined state is "pending" when there are no statuses at all
+	if len(combined.Statuses) > 0 {
+		states = append(states, normalizeCIStatus(combined.State))
+	}
+
+	return combineCIStates(states), nil
+}
+
+// combineCIStates reduces individual CI states: any failure fails,
+// otherwise any pending is pending, otherwise success. Unknown if empty.
+func combineCIStates(states []string) string {
+	if len(states) == 0 {
+		return "unknown"
+	}
+	result := "success"
+	for _, s := range states {
+		switch s {
+		case "failure":
+			return "failure"
+		case "pending":
+			result = "pending"
+		}
+	}
+	return result
+}
+
+// reviewStatusFromReviews derives a review decision from each reviewer's latest review.
+func reviewStatusFromReviews(reviews []restReview) string {
+	latest := make(map[string]string)
+	for _, r := range reviews {
+		switch r.State {
+		case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+			latest[strings.ToLower(r.User.Login)] = r.State
+		}
+	}
+
+	approved := false
+	for _, state := range latest {
+		switch state {
+		case "CHANGES_REQUESTED":
+			return "changes_requested"
+		case "APPROVED":
+			approved = true
+		}
+	}
+	if approved {
+		return "approved"
+	}
+	return "pending"
+}
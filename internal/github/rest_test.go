@@ -0,0 +1,175 @@
+package github
+
+import "testing"
+
+func TestClientGetPRForBranch(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /repos/owner/repo/pulls?head=owner%3Afeature&per_page=1&state=all"] = fakeResponse{Body: `[{"number": 42, "state": "open", "head": {"ref": "feature", "sha": "abc"}}]`}
+	f.Responses["GET /repos/owner/repo/commits/abc/check-runs?per_page=100"] = fakeResponse{Body: `{"check_runs": [{"status": "completed", "conclusion": "success"}, {"status": "in_progress"}]}`}
+	f.Responses["GET /repos/owner/repo/commits/abc/status"] = fakeResponse{Body: `{"state": "pending", "statuses": []}`}
+	f.Responses["GET /repos/owner/repo/pulls/42/reviews?per_page=100"] = fakeResponse{Body: `[
+		{"state": "CHANGES_REQUESTED", "user": {"login": "bob"}},
+		{"state": "APPROVED", "user": {"login": "bob"}},
+		{"state": "COMMENTED", "user": {"login": "carol"}}
+	]`}
+
+	c := NewClient(f.URL, "token")
+	pr, err := c.GetPRForBranch("owner/repo", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr == nil {
+		t.Fatal("expected PR, got nil")
+	}
+	if pr.Number != 42 || pr.Branch != "feature" || pr.State != "open" {
+		t.Errorf("unexpected PR: %+v", pr)
+	}
+	if pr.CIStatus != "pending" {
+		t.Errorf("expected CI status 'pending', got %q", pr.CIStatus)
+	}
+	if pr.ReviewStatus != "approved" {
+		t.Errorf("expected review status 'approved', got %q", pr.ReviewStatus)
+	}
+}
+
+func TestClientGetPRForBranch_NotFound(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /repos/owner/repo/pulls?head=owner%3Afeature&per_page=1&state=all"] = fakeResponse{Body: `[]`}
+
+	c := NewClient(f.URL, "token")
+	pr, err := c.GetPRForBranch("owner/repo", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr != nil {
+		t.Errorf("expected nil PR, got %+v", pr)
+	}
+}
+
+func TestClientGetPR_NotFound(t *testing.T) {
+	f := newFakeGitHub(t)
+
+	c := NewClient(f.URL, "token")
+	pr, err := c.GetPR("owner/repo", 99)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pr != nil {
+		t.Errorf("expected nil PR, got %+v", pr)
+	}
+}
+
+func TestClientListMyPRs(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /user"] = fakeResponse{Body: `{"login": "alice"}`}
+	f.Responses["GET /repos/owner/repo/pulls?state=open&per_page=100"] = fakeResponse{
+		Header: map[string]string{"Link": `<` + f.URL + `/repos/owner/repo/pulls?state=open&per_page=100&page=2>; rel="next", <` + f.URL + `/repos/owner/repo/pulls?state=open&per_page=100&page=2>; rel="last"`},
+		Body: `[
+		{"number": 1, "state": "open", "head": {"ref": "mine"}, "user": {"login": "alice"}},
+		{"number": 2, "state": "open", "head": {"ref": "theirs"}, "user": {"login": "bob"}}
+	]`}
+	f.Responses["GET /repos/owner/repo/pulls?state=open&per_page=100&page=2"] = fakeResponse{Body: `[
+		{"number": 3, "state": "open", "head": {"ref": "also-mine"}, "user": {"login": "alice"}}
+	]`}
+	f.Responses["GET /repos/owner/repo/pulls/1/reviews?per_page=100"] = fakeResponse{Body: `[]`}
+	f.Responses["GET /repos/owner/repo/pulls/3/reviews?per_page=100"] = fakeResponse{Body: `[]`}
+
+	c := NewClient(f.URL, "token")
+	prs, err := c.ListMyPRs("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(prs) != 2 || prs[0].Branch != "mine" || prs[1].Branch != "also-mine" {
+		t.Fatalf("expected only alice's PRs from both pages, got %+v", prs)
+	}
+	if prs[0].CIStatus != "unknown" {
+		t.Errorf("expected unknown CI without a head SHA, got %q", prs[0].CIStatus)
+	}
+	if prs[0].ReviewStatus != "pending" {
+		t.Errorf("expected pending review, got %q", prs[0].ReviewStatus)
+	}
+}
+
+func TestClientCreatePRWithOptions(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /user"] = fakeResponse{Body: `{"login": "alice"}`}
+	f.Responses["POST /repos/owner/repo/pulls"] = fakeResponse{Status: 201, Body: `{"number": 7}`}
+	f.Responses["POST /repos/owner/repo/pulls/7/requested_reviewers"] = fakeResponse{Status: 201, Body: `{}`}
+	f.Responses["POST /repos/owner/repo/issues/7/labels"] = fakeResponse{Body: `[]`}
+	f.Responses["POST /repos/owner/repo/issues/7/assignees"] = fakeResponse{Status: 201, Body: `{}`}
+
+	c := NewClient(f.URL, "token")
+	num, err := c.CreatePRWithOptions("owner/repo", "feature", "main", PROptions{
+		Title:     "Add feature",
+		Body:      "Details",
+		Reviewers: []string{"bob"},
+		Labels:    []string{"enhancement"},
+		Assignees: []string{"@me"},
+		Draft:     true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if num != 7 {
+		t.Errorf("expected PR 7, got %d", num)
+	}
+
+	pull := f.Bodies["POST /repos/owner/repo/pulls"]
+	if pull["head"] != "feature" || pull["base"] != "main" || pull["draft"] != true {
+		t.Errorf("unexpected create body: %v", pull)
+	}
+	assignees := f.Bodies["POST /repos/owner/repo/issues/7/assignees"]
+	if a, ok := assignees["assignees"].([]any); !ok || len(a) != 1 || a[0] != "alice" {
+		t.Errorf("expected @me to resolve to alice, got %v", assignees)
+	}
+}
+
+func TestClientGetDefaultBranch(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /repos/owner/repo"] = fakeResponse{Body: `{"default_branch": "trunk"}`}
+
+	c := NewClient(f.URL, "token")
+	branch, err := c.GetDefaultBranch("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if branch != "trunk" {
+		t.Errorf("expected 'trunk', got %q", branch)
+	}
+}
+
+func TestClientListMyBranches(t *testing.T) {
+	f := newFakeGitHub(t)
+	f.Responses["GET /user"] = fakeResponse{Body: `{"login": "alice"}`}
+	f.Responses["GET /repos/owner/repo/pulls?state=open&per_page=100"] = fakeResponse{Body: `[
+		{"number": 1, "state": "open", "head": {"ref": "feature/a"}, "user": {"login": "alice"}}
+	]`}
+	f.Responses["GET /repos/owner/repo/pulls/1/reviews?per_page=100"] = fakeResponse{Body: `[]`}
+	f.Responses["GET /repos/owner/repo/branches/feature%2Fa"] = fakeResponse{Body: `{"name": "feature/a", "commit": {"sha": "abc", "commit": {"committer": {"date": "2024-01-15T10:30:00Z"}}}}`}
+
+	c := NewClient(f.URL, "token")
+	branches, err := c.ListMyBranches("owner/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(branches) != 1 || branches[0].Name != "feature/a" || branches[0].LastCommit != "abc" {
+		t.Errorf("unexpected branches: %+v", branches)
+	}
+}
+
+func TestCombineCIStates(t *testing.T) {
+	tests := []struct {
+		states []string
+		want   string
+	}{
+		{nil, "unknown"},
+		{[]string{"success"}, "success"},
+		{[]string{"success", "pending"}, "pending"},
+		{[]string{"pending", "failure"}, "failure"},
+	}
+	for _, tt := range tests {
+		if got := combineCIStates(tt.states); got != tt.want {
+			t.Errorf("combineCIStates(%v) = %q, want %q", tt.states, got, tt.want)
+		}
+	}
+}
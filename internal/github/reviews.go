@@ -0,0 +1,171 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReviewThread represents an inline review comment thread on a pull request.
+type ReviewThread struct {
+	ID       string // GraphQL node ID, used to resolve the thread
+	Path     string
+	Line     int // 0 if the thread is no longer attached to a line (outdated)
+	Author   string
+	Body     string // Body of the first comment in the thread
+	URL      string
+	Replies  int // Number of comments after the first
+	Resolved bool
+}
+
+// reviewThreadsQuery fetches a page of the review threads of a pull request, with the
+// first comment of each.
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          isResolved
+          path
+          line
+          originalLine
+          comments(first: 1) {
+            totalCount
+            nodes { author { login } body url }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// resolveThreadMutation marks a review thread as resolved.
+const resolveThreadMutation = `mutation($id: ID!) {
+  resolveReviewThread(input: {threadId: $id}) { thread { isResolved } }
+}`
+
+// ghReviewThreads is the JSON structure returned by reviewThreadsQuery.
+type ghReviewThreads struct {
+	Data struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []ghReviewThread `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+}
+
+// ghReviewThread is a review thread in a ghReviewThreads page.
+type ghReviewThread struct {
+	ID           string `json:"id"`
+	IsResolved   bool   `json:"isResolved"`
+	Path         string `json:"path"`
+	Line         int    `json:"line"`
+	OriginalLine int    `json:"originalLine"`
+	Comments     struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Author struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Body string `json:"body"`
+			URL  string `json:"url"`
+		} `json:"nodes"`
+	} `json:"comments"`
+}
+
+// splitRemote splits an "owner/repo" remote into its owner and name.
+func splitRemote(remote string) (owner, name string, err error) {
+	parts := strings.SplitN(remote, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid remote %q, expected owner/repo", remote)
+	}
+	return parts[0], parts[1], nil
+}
+
+// ListReviewThreads returns the unresolved review threads of a pull request.
+func ListReviewThreads(remote string, prNumber int) ([]ReviewThread, error) {
+	owner, name, err := splitRemote(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]ReviewThread, 0)
+	cursor := ""
+	for {
+		args := []string{"api", "graphql",
+			"-f", "query=" + reviewThreadsQuery,
+			"-f", "owner=" + owner,
+			"-f", "name=" + name,
+			"-F", fmt.Sprintf("number=%d", prNumber),
+		}
+		if cursor != "" {
+			args = append(args, "-f", "cursor="+cursor)
+		}
+		output, err := runGH(args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list review threads for PR #%d: %w", prNumber, err)
+		}
+
+		var resp ghReviewThreads
+		if err := json.Unmarshal([]byte(output), &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse review threads: %w", err)
+		}
+
+		page := resp.Data.Repository.PullRequest.ReviewThreads
+		threads = append(threads, unresolvedThreads(page.Nodes)...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return threads, nil
+		}
+		cursor = page.PageInfo.EndCursor
+	}
+}
+
+// unresolvedThreads converts the unresolved threads of a page.
+func unresolvedThreads(nodes []ghReviewThread) []ReviewThread {
+	var threads []ReviewThread
+	for _, n := range nodes {
+		if n.IsResolved {
+			continue
+		}
+
+		thread := ReviewThread{
+			ID:       n.ID,
+			Path:     n.Path,
+			Line:     n.Line,
+			Resolved: n.IsResolved,
+		}
+		if thread.Line == 0 {
+			thread.Line = n.OriginalLine
+		}
+		if len(n.Comments.Nodes) > 0 {
+			first := n.Comments.Nodes[0]
+			thread.Author = first.Author.Login
+			thread.Body = first.Body
+			thread.URL = first.URL
+			thread.Replies = n.Comments.TotalCount - 1
+		}
+		threads = append(threads, thread)
+	}
+	return threads
+}
+
+// ResolveReviewThread marks a review thread as resolved.
+func ResolveReviewThread(threadID string) error {
+	_, err := runGH("api", "graphql",
+		"-f", "query="+resolveThreadMutation,
+		"-F", "id="+threadID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve review thread: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestListReviewThreads(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	cmd := "gh api graphql -f query=" + reviewThreadsQuery + " -f owner=owner -f name=repo -F number=42"
+	mock.Responses[cmd] = `{"data": {"repository": {"pullRequest": {"reviewThreads": {"pageInfo": {"hasNextPage": true, "endCursor": "c1"}, "nodes": [
+		{"id": "T1", "isResolved": false, "path": "main.go", "line": 12, "originalLine": 10,
+		 "comments": {"totalCount": 2, "nodes": [{"author": {"login": "alice"}, "body": "Please rename", "url": "https://x/1"}]}},
+		{"id": "T2", "isResolved": true, "path": "other.go", "line": 3,
+		 "comments": {"totalCount": 1, "nodes": [{"author": {"login": "bob"}, "body": "done", "url": "https://x/2"}]}},
+		{"id": "T3", "isResolved": false, "path": "old.go", "line": 0, "originalLine": 7,
+		 "comments": {"totalCount": 1, "nodes": [{"author": {"login": "carol"}, "body": "outdated", "url": "https://x/3"}]}}
+	]}}}}}`
+	mock.Responses[cmd+" -f cursor=c1"] = `{"data": {"repository": {"pullRequest": {"reviewThreads": {"pageInfo": {"hasNextPage": false}, "nodes": [
+		{"id": "T4", "isResolved": false, "path": "late.go", "line": 1,
+		 "comments": {"totalCount": 1, "nodes": [{"author": {"login": "dave"}, "body": "one more", "url": "https://x/4"}]}}
+	]}}}}}`
+
+	threads, err := ListReviewThreads("owner/repo", 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(threads) != 3 {
+		t.Fatalf("expected 3 unresolved threads across both pages, got %d", len(threads))
+	}
+
+	first := threads[0]
+	if first.ID != "T1" || first.Path != "main.go" || first.Line != 12 {
+		t.Errorf("unexpected first thread: %+v", first)
+	}
+	if first.Author != "alice" || first.Body != "Please rename" || first.Replies != 1 {
+		t.Errorf("unexpected first thread comment: %+v", first)
+	}
+
+	// Outdated threads fall back to their original line
+	if threads[1].Line != 7 {
+		t.Errorf("expected original line 7, got %d", threads[1].Line)
+	}
+	if threads[2].ID != "T4" {
+		t.Errorf("expected the thread of the second page last, got %+v", threads[2])
+	}
+}
+
+func TestListReviewThreads_InvalidRemote(t *testing.T) {
+	if _, err := ListReviewThreads("not-a-remote", 1); err == nil {
+		t.Fatal("expected error for invalid remote")
+	}
+}
+
+func TestResolveReviewThread(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	cmd := "gh api graphql -f query=" + resolveThreadMutation + " -F id=T1"
+	mock.Responses[cmd] = `{}`
+
+	if err := ResolveReviewThread("T1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mock.Errors[cmd] = fmt.Errorf("forbidden")
+	if err := ResolveReviewThread("T1"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
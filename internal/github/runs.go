@@ -0,0 +1,246 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// WorkflowRun represents a GitHub Actions workflow run.
+type WorkflowRun struct {
+	ID         int64
+	Name       string
+	Status     string // "queued", "in_progress", "completed"
+	Conclusion string // "success", "failure", "cancelled", ... (empty while running)
+	HeadSHA    string
+	HeadBranch string
+	URL        string
+}
+
+// ghRun is the JSON structure returned by gh run list.
+type ghRun struct {
+	DatabaseID   int64  `json:"databaseId"`
+	WorkflowName string `json:"workflowName"`
+	Status       string `json:"status"`
+	Conclusion   string `json:"conclusion"`
+	HeadSHA      string `json:"headSha"`
+	HeadBranch   string `json:"headBranch"`
+	URL          string `json:"url"`
+}
+
+// runFields is the list of fields requested from gh run list.
+const runFields = "databaseId,workflowName,status,conclusion,headSha,headBranch,url"
+
+// parseRuns converts gh run list JSON output into WorkflowRuns.
+func parseRuns(output string) ([]WorkflowRun, error) {
+	if output == "" || output == "[]" {
+		return []WorkflowRun{}, nil
+	}
+
+	var ghRuns []ghRun
+	if err := json.Unmarshal([]byte(output), &ghRuns); err != nil {
+		return nil, fmt.Errorf("failed to parse run list: %w", err)
+	}
+
+	runs := make([]WorkflowRun, len(ghRuns))
+	for i, r := range ghRuns {
+		runs[i] = WorkflowRun{
+			ID:         r.DatabaseID,
+			Name:       r.WorkflowName,
+			Status:     strings.ToLower(r.Status),
+			Conclusion: strings.ToLower(r.Conclusion),
+			HeadSHA:    r.HeadSHA,
+			HeadBranch: r.HeadBranch,
+			URL:        r.URL,
+		}
+	}
+	return runs, nil
+}
+
+// ListRunsForBranch returns the most recent workflow runs for a branch, newest first.
+func ListRunsForBranch(remote, branch string) ([]WorkflowRun, error) {
+	output, err := runGH("run", "list",
+		"--repo", remote,
+		"--branch", branch,
+		"--json", runFields,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs for branch %s: %w", branch, err)
+	}
+	return parseRuns(output)
+}
+
+// IsFailed reports whether the run completed unsuccessfully.
+func (r WorkflowRun) IsFailed() bool {
+	switch r.Conclusion {
+	case "failure", "timed_out", "startup_failure":
+		return true
+	default:
+		return false
+	}
+}
+
+// GetFailedRunLogs returns the logs of the failed jobs of the latest failed run on a branch.
+// Only the latest run of each workflow counts: a failure since fixed by a later run isn't
+// reported. Returns the run along with its logs, or an error if no workflow is failing.
+func GetFailedRunLogs(remote, branch string) (*WorkflowRun, string, error) {
+	runs, err := ListRunsForBranch(remote, branch)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var failed *WorkflowRun
+	latest := latestPerWorkflow(runs)
+	for i := range latest {
+		if latest[i].IsFailed() {
+			failed = &latest[i]
+			break
+		}
+	}
+	if failed == nil {
+		return nil, "", fmt.Errorf("no failing runs found for branch %s", branch)
+	}
+
+	output, err := runGH("run", "view", fmt.Sprintf("%d", failed.ID),
+		"--repo", remote,
+		"--log-failed",
+	)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch logs for run %d: %w", failed.ID, err)
+	}
+
+	return failed, output, nil
+}
+
+// logTimestampRegex matches the ISO-8601 timestamp GitHub Actions prefixes to log lines.
+var logTimestampRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+
+// errorLineRegex matches lines that typically mark the start of a failure.
+var errorLineRegex = regexp.MustCompile(`(?i)(##\[error\]|\berror\b|\bfail(ed|ure)?\b|\bpanic:|exception|traceback)`)
+
+// StripLogPrefixes removes the "job<TAB>step<TAB>timestamp" prefix that
+// gh run view --log-failed adds to every line.
+func StripLogPrefixes(logs string) string {
+	lines := strings.Split(logs, "\n")
+	for i, line := range lines {
+		if parts := strings.SplitN(line, "\t", 3); len(parts) == 3 {
+			line = parts[2]
+		}
+		lines[i] = logTimestampRegex.ReplaceAllString(line, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ExtractErrorBlock heuristically extracts the last error block from CI logs.
+// It finds the last run of lines that look like errors and returns them with
+// up to contextLines lines of leading context. If no error marker is found,
+// the last contextLines lines are returned.
+func ExtractErrorBlock(logs string, contextLines int) string {
+	lines := strings.Split(StripLogPrefixes(strings.TrimRight(logs, "\n")), "\n")
+	if len(lines) == 0 {
+		return ""
+	}
+
+	// Find the last line that looks like an error
+	last := -1
+	for i := len(lines) - 1; i >= 0; i-- {
+		if errorLineRegex.MatchString(lines[i]) {
+			last = i
+			break
+		}
+	}
+
+	if last == -1 {
+		start := len(lines) - contextLines
+		if start < 0 {
+			start = 0
+		}
+		return strings.Join(lines[start:], "\n")
+	}
+
+	// Walk back over the contiguous error lines to find where the block starts
+	first := last
+	for first > 0 && errorLineRegex.MatchString(lines[first-1]) {
+		first--
+	}
+
+	// Include leading context, stopping at a blank line
+	start := first
+	for start > 0 && first-start < contextLines && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+
+	return strings.Join(lines[start:last+1], "\n")
+}
+
+// GetPRHeadSHA returns the head commit SHA of the PR for a branch.
+func GetPRHeadSHA(remote, branch string) (string, error) {
+	output, err := runGH("pr", "view", branch,
+		"--repo", remote,
+		"--json", "headRefOid",
+		"--jq", ".headRefOid",
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get PR head for branch %s: %w", branch, err)
+	}
+	if output == "" {
+		return "", fmt.Errorf("no PR head found for branch %s", branch)
+	}
+	return output, nil
+}
+
+// ListRunsForCommit returns the workflow runs triggered for a commit, newest first.
+func ListRunsForCommit(remote, sha string) ([]WorkflowRun, error) {
+	output, err := runGH("run", "list",
+		"--repo", remote,
+		"--commit", sha,
+		"--json", runFields,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list runs for commit %s: %w", sha, err)
+	}
+	return parseRuns(output)
+}
+
+// latestPerWorkflow keeps only the newest run of each workflow.
+// Runs must be ordered newest first, as returned by gh run list.
+func latestPerWorkflow(runs []WorkflowRun) []WorkflowRun {
+	seen := make(map[string]bool)
+	latest := make([]WorkflowRun, 0, len(runs))
+	for _, r := range runs {
+		if seen[r.Name] {
+			continue
+		}
+		seen[r.Name] = true
+		latest = append(latest, r)
+	}
+	return latest
+}
+
+// RerunFailed re-runs the failed jobs of the latest workflow runs for a branch's PR head.
+// Returns the runs that were re-run; an empty slice means nothing had failed.
+func RerunFailed(remote, branch string) ([]WorkflowRun, error) {
+	sha, err := GetPRHeadSHA(remote, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := ListRunsForCommit(remote, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	rerun := make([]WorkflowRun, 0)
+	for _, r := range latestPerWorkflow(runs) {
+		if !r.IsFailed() && r.Conclusion != "cancelled" {
+			continue
+		}
+		if _, err := runGH("run", "rerun", fmt.Sprintf("%d", r.ID), "--repo", remote, "--failed"); err != nil {
+			return rerun, fmt.Errorf("failed to re-run %s (run %d): %w", r.Name, r.ID, err)
+		}
+		rerun = append(rerun, r)
+	}
+
+	return rerun, nil
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestListRunsForBranch(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh run list --repo owner/repo --branch feature --json "+runFields] = `[
+		{"databaseId": 2, "workflowName": "CI", "status": "COMPLETED", "conclusion": "FAILURE", "headSha": "abc", "headBranch": "feature", "url": "https://github.com/owner/repo/actions/runs/2"},
+		{"databaseId": 1, "workflowName": "Lint", "status": "in_progress", "conclusion": "", "headSha": "abc", "headBranch": "feature", "url": "https://github.com/owner/repo/actions/runs/1"}
+	]`
+
+	runs, err := ListRunsForBranch("owner/repo", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(runs))
+	}
+	if runs[0].ID != 2 || runs[0].Name != "CI" {
+		t.Errorf("unexpected first run: %+v", runs[0])
+	}
+	if !runs[0].IsFailed() {
+		t.Error("expected first run to be failed")
+	}
+	if runs[1].IsFailed() {
+		t.Error("expected in-progress run not to be failed")
+	}
+}
+
+func TestGetFailedRunLogs(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh run list --repo owner/repo --branch feature --json "+runFields] = `[
+		{"databaseId": 7, "workflowName": "Lint", "status": "completed", "conclusion": "success"},
+		{"databaseId": 5, "workflowName": "CI", "status": "completed", "conclusion": "failure"},
+		{"databaseId": 3, "workflowName": "Lint", "status": "completed", "conclusion": "failure"}
+	]`
+	mock.Responses["gh run view 5 --repo owner/repo --log-failed"] = "build\tgo test\t2024-01-01T00:00:00.0000000Z FAIL foo"
+
+	run, logs, err := GetFailedRunLogs("owner/repo", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if run.ID != 5 {
+		t.Errorf("expected run 5, got %d", run.ID)
+	}
+	if !strings.Contains(logs, "FAIL foo") {
+		t.Errorf("unexpected logs: %q", logs)
+	}
+}
+
+func TestGetFailedRunLogs_NoFailures(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	// The failure was fixed by a later run of the workflow
+	mock.Responses["gh run list --repo owner/repo --branch feature --json "+runFields] = `[
+		{"databaseId": 7, "workflowName": "CI", "status": "completed", "conclusion": "success"},
+		{"databaseId": 5, "workflowName": "CI", "status": "completed", "conclusion": "failure"}
+	]`
+
+	_, _, err := GetFailedRunLogs("owner/repo", "feature")
+	if err == nil || !strings.Contains(err.Error(), "no failing runs") {
+		t.Fatalf("expected no failing runs, got %v", err)
+	}
+}
+
+func TestGetFailedRunLogs_ListError(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Errors["gh run list --repo owner/repo --branch feature --json "+runFields] = fmt.Errorf("boom")
+
+	_, _, err := GetFailedRunLogs("owner/repo", "feature")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestStripLogPrefixes(t *testing.T) {
+	input := "test\tRun tests\t2024-05-01T10:00:00.1234567Z --- FAIL: TestFoo\nplain line"
+	want := "--- FAIL: TestFoo\nplain line"
+
+	if got := StripLogPrefixes(input); got != want {
+		t.Errorf("StripLogPrefixes() = %q, want %q", got, want)
+	}
+}
+
+func TestExtractErrorBlock(t *testing.T) {
+	tests := []struct {
+		name    string
+		logs    string
+		context int
+		want    string
+	}{
+		{
+			name:    "last error block with context",
+			logs:    "setup ok\n\nrunning tests\nfoo_test.go:12: expected 1\n--- FAIL: TestFoo\nFAIL pkg\n",
+			context: 5,
+			want:    "running tests\nfoo_test.go:12: expected 1\n--- FAIL: TestFoo\nFAIL pkg",
+		},
+		{
+			name:    "context limit",
+			logs:    "a\nb\nc\nerror: boom",
+			context: 1,
+			want:    "c\nerror: boom",
+		},
+		{
+			name:    "no error markers returns tail",
+			logs:    "one\ntwo\nthree",
+			context: 2,
+			want:    "two\nthree",
+		},
+		{
+			name:    "picks the last of several blocks",
+			logs:    "error: first\n\nok\n\n##[error]Process completed with exit code 1.",
+			context: 3,
+			want:    "##[error]Process completed with exit code 1.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractErrorBlock(tt.logs, tt.context)
+			if got != tt.want {
+				t.Errorf("ExtractErrorBlock() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRerunFailed(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh pr view feature --repo owner/repo --json headRefOid --jq .headRefOid"] = "abc123"
+	mock.Responses["gh run list --repo owner/repo --commit abc123 --json "+runFields] = `[
+		{"databaseId": 30, "workflowName": "CI", "status": "completed", "conclusion": "failure"},
+		{"databaseId": 20, "workflowName": "Lint", "status": "completed", "conclusion": "success"},
+		{"databaseId": 10, "workflowName": "Lint", "status": "completed", "conclusion": "failure"}
+	]`
+	mock.Responses["gh run rerun 30 --repo owner/repo --failed"] = ""
+
+	rerun, err := RerunFailed("owner/repo", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rerun) != 1 || rerun[0].ID != 30 {
+		t.Errorf("expected only run 30 to be re-run, got %+v", rerun)
+	}
+
+	// The superseded Lint failure must not be re-run
+	for _, call := range mock.Calls {
+		if strings.Contains(call, "rerun 10") {
+			t.Error("re-ran an outdated workflow run")
+		}
+	}
+}
+
+func TestRerunFailed_NothingFailed(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Responses["gh pr view feature --repo owner/repo --json headRefOid --jq .headRefOid"] = "abc123"
+	mock.Responses["gh run list --repo owner/repo --commit abc123 --json "+runFields] = `[
+		{"databaseId": 30, "workflowName": "CI", "status": "completed", "conclusion": "success"}
+	]`
+
+	rerun, err := RerunFailed("owner/repo", "feature")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rerun) != 0 {
+		t.Errorf("expected nothing re-run, got %+v", rerun)
+	}
+}
+
+func TestRerunFailed_NoPR(t *testing.T) {
+	mock := NewMockRunner()
+	SetRunner(mock)
+	defer ResetRunner()
+
+	mock.Errors["gh pr view feature --repo owner/repo --json headRefOid --jq .headRefOid"] = fmt.Errorf("no pull requests found")
+
+	if _, err := RerunFailed("owner/repo", "feature"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
@@ -0,0 +1,89 @@
+package issue
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/laurent/trak/internal/github"
+)
+
+// GitHub is the Tracker backed by GitHub Issues.
+type GitHub struct {
+	remote   string
+	getIssue func(remote string, number int) (*github.Issue, error)
+}
+
+// NewGitHub creates a GitHub Issues tracker. Bare issue numbers refer to remote ("owner/repo").
+func NewGitHub(remote string) *GitHub {
+	return &GitHub{remote: remote, getIssue: github.GetIssue}
+}
+
+// Name returns "github".
+func (g *GitHub) Name() string { return KindGitHub }
+
+// GetIssue looks up an issue by "42", "#42", "owner/repo#42" or issue URL.
+func (g *GitHub) GetIssue(ref string) (*Issue, error) {
+	remote, number, err := g.parseRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	iss, err := g.getIssue(remote, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Issue{
+		Ref:   g.canonicalRef(remote, number),
+		Key:   strconv.Itoa(number),
+		Title: iss.Title,
+		URL:   iss.URL,
+	}, nil
+}
+
+// PRReference returns "Closes <ref>", or "Refs <ref>" when close is false.
+func (g *GitHub) PRReference(ref string, close bool) string {
+	if close {
+		return "Closes " + ref
+	}
+	return "Refs " + ref
+}
+
+// parseRef splits an issue reference into its repository and number.
+func (g *GitHub) parseRef(ref string) (string, int, error) {
+	ref = strings.TrimSpace(ref)
+	remote := g.remote
+
+	if strings.Contains(ref, "://") {
+		// https://github.com/owner/repo/issues/42
+		u, err := url.Parse(ref)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid issue URL: %s", ref)
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) != 4 || parts[2] != "issues" {
+			return "", 0, fmt.Errorf("invalid issue URL: %s", ref)
+		}
+		remote = parts[0] + "/" + parts[1]
+		ref = parts[3]
+	} else if repo, num, ok := strings.Cut(ref, "#"); ok && repo != "" {
+		remote = repo
+		ref = num
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	if err != nil || number <= 0 {
+		return "", 0, fmt.Errorf("invalid issue reference: %s", ref)
+	}
+	return remote, number, nil
+}
+
+// canonicalRef returns "#N" for issues in the tracker's repository, "owner/repo#N" otherwise.
+func (g *GitHub) canonicalRef(remote string, number int) string {
+	if strings.EqualFold(remote, g.remote) {
+		return fmt.Sprintf("#%d", number)
+	}
+	return fmt.Sprintf("%s#%d", remote, number)
+}
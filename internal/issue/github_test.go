@@ -0,0 +1,75 @@
+package issue
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/laurent/trak/internal/github"
+)
+
+// fakeGitHub returns a GitHub tracker serving issues from a map keyed by "owner/repo#N".
+func fakeGitHub(remote string, issues map[string]string) *GitHub {
+	g := NewGitHub(remote)
+	g.getIssue = func(remote string, number int) (*github.Issue, error) {
+		title, ok := issues[fmt.Sprintf("%s#%d", remote, number)]
+		if !ok {
+			return nil, fmt.Errorf("issue not found")
+		}
+		return &github.Issue{
+			Number: number,
+			Title:  title,
+			URL:    fmt.Sprintf("https://github.com/%s/issues/%d", remote, number),
+		}, nil
+	}
+	return g
+}
+
+func TestGitHubGetIssue(t *testing.T) {
+	g := fakeGitHub("owner/repo", map[string]string{
+		"owner/repo#42": "Fix login bug",
+		"other/lib#7":   "Upstream fix",
+	})
+
+	tests := []struct {
+		ref     string
+		wantRef string
+		wantKey string
+	}{
+		{"42", "#42", "42"},
+		{"#42", "#42", "42"},
+		{"owner/repo#42", "#42", "42"},
+		{"other/lib#7", "other/lib#7", "7"},
+		{"https://github.com/other/lib/issues/7", "other/lib#7", "7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			iss, err := g.GetIssue(tt.ref)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if iss.Ref != tt.wantRef || iss.Key != tt.wantKey {
+				t.Errorf("expected ref %q key %q, got %+v", tt.wantRef, tt.wantKey, iss)
+			}
+		})
+	}
+}
+
+func TestGitHubGetIssueInvalidRef(t *testing.T) {
+	g := fakeGitHub("owner/repo", nil)
+	for _, ref := range []string{"", "abc", "#-1", "https://github.com/owner/repo/pull/3"} {
+		if _, err := g.GetIssue(ref); err == nil {
+			t.Errorf("expected error for %q", ref)
+		}
+	}
+}
+
+func TestGitHubPRReference(t *testing.T) {
+	g := NewGitHub("owner/repo")
+	if got := g.PRReference("#42", true); got != "Closes #42" {
+		t.Errorf("unexpected closing reference: %q", got)
+	}
+	if got := g.PRReference("#42", false); got != "Refs #42" {
+		t.Errorf("unexpected mention: %q", got)
+	}
+}
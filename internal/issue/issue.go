@@ -0,0 +1,122 @@
+// Package issue links tracks to issues in an issue tracker.
+// GitHub Issues and Jira are supported.
+package issue
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Issue is an issue a track works on.
+type Issue struct {
+	Ref   string // Canonical reference stored on the track, e.g. "#42", "owner/repo#42" or "PROJ-123"
+	Key   string // Short key used in branch names, e.g. "42" or "PROJ-123"
+	Title string
+	URL   string
+}
+
+// Tracker is an issue tracker.
+type Tracker interface {
+	// Name returns the tracker kind, e.g. "github".
+	Name() string
+	// GetIssue looks up an issue by reference, in any form the tracker accepts
+	// (number, key or URL).
+	GetIssue(ref string) (*Issue, error)
+	// PRReference returns the line that links a PR body to an issue. When close is set
+	// and the tracker supports it, the line closes the issue once the PR merges.
+	PRReference(ref string, close bool) string
+}
+
+// Tracker kinds.
+const (
+	KindGitHub = "github"
+	KindJira   = "jira"
+)
+
+// Environment variables holding Jira credentials. With JIRA_EMAIL set, JIRA_API_TOKEN
+// is used for basic auth (Jira Cloud), otherwise as a bearer token (Jira Data Center).
+const (
+	JiraEmailEnv = "JIRA_EMAIL"
+	JiraTokenEnv = "JIRA_API_TOKEN"
+)
+
+// DefaultBranchTemplate is the branch name template used when none is configured.
+const DefaultBranchTemplate = "{{key}}-{{slug}}"
+
+// maxSlugLength caps the length of the title slug in branch names.
+const maxSlugLength = 40
+
+// ValidateKind returns an error if kind is not a supported tracker. Empty means GitHub.
+func ValidateKind(kind string) error {
+	switch kind {
+	case "", KindGitHub, KindJira:
+		return nil
+	default:
+		return fmt.Errorf("unknown issue tracker %q (expected %s or %s)", kind, KindGitHub, KindJira)
+	}
+}
+
+// New returns the tracker of the given kind. remote is the GitHub repository
+// ("owner/repo") that bare issue numbers refer to; jiraURL is the Jira base URL.
+func New(kind, remote, jiraURL string) (Tracker, error) {
+	if err := ValidateKind(kind); err != nil {
+		return nil, err
+	}
+	if kind == KindJira {
+		if jiraURL == "" {
+			return nil, fmt.Errorf("issue tracker jira requires jira_url to be configured")
+		}
+		return NewJira(jiraURL, os.Getenv(JiraEmailEnv), os.Getenv(JiraTokenEnv)), nil
+	}
+	return NewGitHub(remote), nil
+}
+
+// BranchName renders a branch name template for an issue. The template may use
+// {{user}}, {{key}} and {{slug}} (the issue title, lowercased and hyphenated).
+func BranchName(template, user string, iss Issue) string {
+	if template == "" {
+		template = DefaultBranchTemplate
+	}
+	name := strings.NewReplacer(
+		"{{user}}", user,
+		"{{key}}", iss.Key,
+		"{{slug}}", Slug(iss.Title),
+	).Replace(template)
+
+	// Tidy up after empty placeholders, e.g. a missing user or title
+	name = strings.TrimLeft(name, "/-")
+	name = strings.TrimRight(name, "/-")
+	return name
+}
+
+// Slug turns an issue title into a branch-name friendly slug: lowercase ASCII
+// letters and digits separated by single hyphens, cut at a word boundary.
+func Slug(title string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+			continue
+		}
+		hyphen = true
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		cut := slug[:maxSlugLength]
+		// Drop a partial trailing word
+		if slug[maxSlugLength] != '-' {
+			if i := strings.LastIndexByte(cut, '-'); i > 0 {
+				cut = cut[:i]
+			}
+		}
+		slug = cut
+	}
+	return slug
+}
@@ -0,0 +1,74 @@
+package issue
+
+import "testing"
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Fix login bug", "fix-login-bug"},
+		{"  [API] Handle 429's   gracefully!  ", "api-handle-429-s-gracefully"},
+		{"Ünïcode café", "n-code-caf"},
+		{"", ""},
+		{"A very long issue title that keeps going well past the limit", "a-very-long-issue-title-that-keeps-going"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := Slug(tt.title); got != tt.want {
+				t.Errorf("Slug(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	iss := Issue{Key: "PROJ-123", Title: "Fix login bug"}
+
+	tests := []struct {
+		name     string
+		template string
+		user     string
+		want     string
+	}{
+		{"default", "", "alice", "PROJ-123-fix-login-bug"},
+		{"with user", "{{user}}/{{key}}-{{slug}}", "alice", "alice/PROJ-123-fix-login-bug"},
+		{"missing user", "{{user}}/{{key}}-{{slug}}", "", "PROJ-123-fix-login-bug"},
+		{"key only", "feature/{{key}}", "alice", "feature/PROJ-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BranchName(tt.template, tt.user, iss); got != tt.want {
+				t.Errorf("BranchName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tr, err := New("", "owner/repo", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Name() != KindGitHub {
+		t.Errorf("expected github tracker by default, got %s", tr.Name())
+	}
+
+	if _, err := New(KindJira, "owner/repo", ""); err == nil {
+		t.Error("expected error for jira without jira_url")
+	}
+
+	tr, err = New(KindJira, "owner/repo", "https://jira.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Name() != KindJira {
+		t.Errorf("expected jira tracker, got %s", tr.Name())
+	}
+
+	if _, err := New("linear", "owner/repo", ""); err == nil {
+		t.Error("expected error for unknown tracker")
+	}
+}
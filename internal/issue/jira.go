@@ -0,0 +1,118 @@
+package issue
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// jiraKeyPattern matches a Jira issue key such as PROJ-123.
+var jiraKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[0-9]+$`)
+
+// Jira is the Tracker backed by the Jira REST API (v2).
+type Jira struct {
+	baseURL string
+	email   string
+	token   string
+	http    *http.Client
+}
+
+// NewJira creates a Jira tracker for the instance at baseURL (e.g. https://example.atlassian.net).
+// With an email the token is sent as basic auth (Jira Cloud API token), otherwise as a
+// bearer token (Jira Data Center personal access token).
+func NewJira(baseURL, email, token string) *Jira {
+	return &Jira{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		email:   email,
+		token:   token,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns "jira".
+func (j *Jira) Name() string { return KindJira }
+
+// GetIssue looks up an issue by key ("PROJ-123", case-insensitive) or browse URL.
+func (j *Jira) GetIssue(ref string) (*Issue, error) {
+	key, err := parseJiraRef(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, j.baseURL+"/rest/api/2/issue/"+url.PathEscape(key)+"?fields=summary", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case j.email != "":
+		req.SetBasicAuth(j.email, j.token)
+	case j.token != "":
+		req.Header.Set("Authorization", "Bearer "+j.token)
+	}
+
+	resp, err := j.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue %s: %w", key, err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("issue %s not found", key)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to get issue %s: %s", key, resp.Status)
+	}
+
+	var payload struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse issue %s: %w", key, err)
+	}
+	if payload.Key != "" {
+		key = payload.Key
+	}
+
+	return &Issue{
+		Ref:   key,
+		Key:   key,
+		Title: payload.Fields.Summary,
+		URL:   j.browseURL(key),
+	}, nil
+}
+
+// PRReference links to the issue. Jira issues aren't closed by PRs, so close is ignored.
+func (j *Jira) PRReference(ref string, close bool) string {
+	return fmt.Sprintf("Jira: [%s](%s)", ref, j.browseURL(ref))
+}
+
+// browseURL returns the web URL of an issue.
+func (j *Jira) browseURL(key string) string {
+	return j.baseURL + "/browse/" + key
+}
+
+// parseJiraRef extracts the issue key from a key or browse URL.
+func parseJiraRef(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if i := strings.Index(ref, "/browse/"); i >= 0 {
+		ref = strings.Trim(ref[i+len("/browse/"):], "/")
+	}
+	key := strings.ToUpper(ref)
+	if !jiraKeyPattern.MatchString(key) {
+		return "", fmt.Errorf("invalid Jira issue key: %s", ref)
+	}
+	return key, nil
+}
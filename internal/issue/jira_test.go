@@ -0,0 +1,68 @@
+package issue
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestJiraGetIssue(t *testing.T) {
+	var gotPath, gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.RequestURI()
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Path != "/rest/api/2/issue/PROJ-123" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"key": "PROJ-123", "fields": {"summary": "Fix login bug"}}`))
+	}))
+	defer ts.Close()
+
+	j := NewJira(ts.URL+"/", "", "secret")
+	iss, err := j.GetIssue("proj-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/rest/api/2/issue/PROJ-123?fields=summary" {
+		t.Errorf("unexpected request: %s", gotPath)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("expected bearer auth, got %q", gotAuth)
+	}
+	if iss.Ref != "PROJ-123" || iss.Key != "PROJ-123" || iss.Title != "Fix login bug" {
+		t.Errorf("unexpected issue: %+v", iss)
+	}
+	if iss.URL != ts.URL+"/browse/PROJ-123" {
+		t.Errorf("unexpected URL: %s", iss.URL)
+	}
+
+	// Browse URLs work too, and an email switches to basic auth
+	j = NewJira(ts.URL, "alice@example.com", "secret")
+	if _, err := j.GetIssue(ts.URL + "/browse/PROJ-123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth == "" || gotAuth == "Bearer secret" {
+		t.Errorf("expected basic auth, got %q", gotAuth)
+	}
+
+	if _, err := j.GetIssue("PROJ-999"); err == nil {
+		t.Error("expected error for missing issue")
+	}
+}
+
+func TestParseJiraRef(t *testing.T) {
+	for _, ref := range []string{"", "123", "PROJ", "PROJ-", "https://jira.example.com/browse/"} {
+		if _, err := parseJiraRef(ref); err == nil {
+			t.Errorf("expected error for %q", ref)
+		}
+	}
+}
+
+func TestJiraPRReference(t *testing.T) {
+	j := NewJira("https://jira.example.com", "", "")
+	want := "Jira: [PROJ-123](https://jira.example.com/browse/PROJ-123)"
+	if got := j.PRReference("PROJ-123", true); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
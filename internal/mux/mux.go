@@ -0,0 +1,107 @@
+// Package mux abstracts the terminal multiplexer track windows live in.
+// tmux and zellij are supported; without a multiplexer, trak prints the commands
+// to run instead.
+package mux
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// Window is a window (a tab in zellij) of a session.
+type Window struct {
+	ID      string // Backend ID: a tmux window ID such as "@4", or the zellij tab name
+	Session string
+	Name    string
+	Tag     string // Value identifying the track the window belongs to, "" if untagged
+	Dir     string // Start directory, only known for windows returned by EnsureWindow
+}
+
+// WindowSpec describes the window EnsureWindow finds or creates.
+type WindowSpec struct {
+	Name string // Display name
+	Dir  string // Start directory of a new window, "" for the default
+	// Tag identifies the window so it's found in any session even after it's renamed.
+	// Without a tag, or with a backend that can't tag windows, the window is found by
+	// its name in the session.
+	Tag string
+	// ID is where the window was last seen, tried before searching for the tag.
+	ID string
+}
+
+// Multiplexer is a terminal multiplexer hosting track windows.
+type Multiplexer interface {
+	// Name returns the multiplexer kind, e.g. "tmux".
+	Name() string
+	// EnsureSession creates a detached session unless it already exists.
+	EnsureSession(session string) error
+	// EnsureWindow returns the window matching spec, creating it in session if there is none.
+	// created reports whether the window was created.
+	EnsureWindow(session string, spec WindowSpec) (w Window, created bool, err error)
+	// Run types a command into a window and runs it.
+	Run(w Window, command string) error
+	// Focus shows a window, attaching to its session when outside the multiplexer.
+	Focus(w Window) error
+	// Kill closes a window and everything running in it.
+	Kill(w Window) error
+	// List returns the windows of all sessions that EnsureWindow can find by tag. Backends
+	// that can't tag windows return all windows, untagged.
+	List() ([]Window, error)
+}
+
+// Supported multiplexer kinds.
+const (
+	KindTmux   = "tmux"
+	KindZellij = "zellij"
+	KindPrint  = "print" // No multiplexer: commands are printed for the user to run
+)
+
+// ValidateKind returns an error if kind is neither empty nor a supported multiplexer.
+func ValidateKind(kind string) error {
+	switch kind {
+	case "", KindTmux, KindZellij, KindPrint:
+		return nil
+	default:
+		return fmt.Errorf("unsupported multiplexer %q (expected %s, %s or %s)", kind, KindTmux, KindZellij, KindPrint)
+	}
+}
+
+// lookPath finds an executable (can be swapped for testing).
+var lookPath = exec.LookPath
+
+// DetectKind returns the multiplexer trak runs in, from $TMUX and $ZELLIJ. Outside
+// both, it's the one that is installed, preferring tmux, or KindPrint if neither is.
+func DetectKind() string {
+	switch {
+	case os.Getenv("TMUX") != "":
+		return KindTmux
+	case os.Getenv("ZELLIJ") != "":
+		return KindZellij
+	}
+	for _, kind := range []string{KindTmux, KindZellij} {
+		if _, err := lookPath(kind); err == nil {
+			return kind
+		}
+	}
+	return KindPrint
+}
+
+// New returns the multiplexer of the given kind, detecting it if kind is empty.
+// The print backend writes to out.
+func New(kind string, out io.Writer) (Multiplexer, error) {
+	if kind == "" {
+		kind = DetectKind()
+	}
+	switch kind {
+	case KindTmux:
+		return Tmux{}, nil
+	case KindZellij:
+		return Zellij{}, nil
+	case KindPrint:
+		return Print{Out: out}, nil
+	default:
+		return nil, ValidateKind(kind)
+	}
+}
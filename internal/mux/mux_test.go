@@ -0,0 +1,184 @@
+package mux
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/laurent/trak/internal/tmux"
+	"github.com/laurent/trak/internal/zellij"
+)
+
+// fakeRunner answers commands by their arguments and records them.
+type fakeRunner struct {
+	outputs map[string]string // joined args -> output; missing args fail
+	calls   []string
+}
+
+func (f *fakeRunner) Run(name string, args ...string) (string, error) {
+	key := strings.Join(args, " ")
+	f.calls = append(f.calls, key)
+	if out, ok := f.outputs[key]; ok {
+		return out, nil
+	}
+	return "", errors.New("exit status 1")
+}
+
+func (f *fakeRunner) Exec(name string, args ...string) error {
+	f.calls = append(f.calls, strings.Join(args, " "))
+	return nil
+}
+
+func TestValidateKind(t *testing.T) {
+	for _, kind := range []string{"", KindTmux, KindZellij, KindPrint} {
+		if err := ValidateKind(kind); err != nil {
+			t.Errorf("ValidateKind(%q) = %v", kind, err)
+		}
+	}
+	if err := ValidateKind("screen"); err == nil {
+		t.Error("expected an error for screen")
+	}
+	if _, err := New("screen", nil); err == nil {
+		t.Error("expected New to fail for screen")
+	}
+}
+
+func TestDetectKind(t *testing.T) {
+	defer func() { lookPath = exec.LookPath }()
+
+	tests := []struct {
+		name      string
+		tmux      string
+		zellij    string
+		installed []string
+		want      string
+	}{
+		{"inside tmux", "/tmp/tmux-1000/default,1,0", "", nil, KindTmux},
+		{"inside zellij", "", "0", []string{"tmux"}, KindZellij},
+		{"tmux installed", "", "", []string{"tmux", "zellij"}, KindTmux},
+		{"zellij installed", "", "", []string{"zellij"}, KindZellij},
+		{"nothing installed", "", "", nil, KindPrint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TMUX", tt.tmux)
+			t.Setenv("ZELLIJ", tt.zellij)
+			lookPath = func(file string) (string, error) {
+				for _, name := range tt.installed {
+					if name == file {
+						return "/usr/bin/" + file, nil
+					}
+				}
+				return "", exec.ErrNotFound
+			}
+
+			if got := DetectKind(); got != tt.want {
+				t.Errorf("DetectKind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var out bytes.Buffer
+	m, err := New(KindPrint, &out)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	w, created, err := m.EnsureWindow("trak", WindowSpec{Name: "feature", Dir: "/wt/it's"})
+	if err != nil || !created {
+		t.Fatalf("EnsureWindow() = %v, %v", created, err)
+	}
+	if err := m.Run(w, "make test"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if err := m.Focus(w); err != nil {
+		t.Fatalf("Focus failed: %v", err)
+	}
+
+	want := "cd '/wt/it'\\''s' && make test\ncd '/wt/it'\\''s'\n"
+	if out.String() != want {
+		t.Errorf("unexpected output:\ngot  %q\nwant %q", out.String(), want)
+	}
+}
+
+func TestTmuxEnsureWindow(t *testing.T) {
+	fake := &fakeRunner{outputs: map[string]string{
+		"list-windows -a -F #{window_id}\t#{session_name}\t#{window_name}\t#{@trak_track_id}": "@3\ttrak\tzsh\t7",
+		"has-session -t =repo": "",
+		"new-window -t =repo: -n other -P -F #{window_id} -c /wt": "@4",
+		"set-option -w -t @4 @trak_track_id 8":                    "",
+	}}
+	tmux.SetRunner(fake)
+	defer tmux.ResetRunner()
+
+	// A tagged window is found in any session, whatever its name
+	w, created, err := Tmux{}.EnsureWindow("repo", WindowSpec{Name: "feature", Tag: "7"})
+	if err != nil || created {
+		t.Fatalf("EnsureWindow() = %v, %v", created, err)
+	}
+	if w != (Window{ID: "@3", Session: "trak", Name: "zsh", Tag: "7"}) {
+		t.Errorf("expected the tagged window, got %+v", w)
+	}
+
+	w, created, err = Tmux{}.EnsureWindow("repo", WindowSpec{Name: "other", Dir: "/wt", Tag: "8"})
+	if err != nil || !created {
+		t.Fatalf("EnsureWindow() = %v, %v", created, err)
+	}
+	if w.ID != "@4" || w.Session != "repo" {
+		t.Errorf("expected new window repo:@4, got %+v", w)
+	}
+	if last := fake.calls[len(fake.calls)-1]; last != "set-option -w -t @4 @trak_track_id 8" {
+		t.Errorf("expected the new window to be tagged, got %q", last)
+	}
+}
+
+func TestZellij(t *testing.T) {
+	fake := &fakeRunner{outputs: map[string]string{
+		"list-sessions --short --no-formatting":                                      "repo\nexited",
+		"--session repo action query-tab-names":                                      "Tab #1\nfeature",
+		"--session repo action new-tab --name review --cwd /wt":                      "",
+		"--session repo action go-to-tab-name feature":                               "",
+		"--session repo action write-chars make":                                     "",
+		"--session repo action write 13":                                             "",
+		"--session repo-feature-login action query-tab-names":                        "",
+		"attach --create-background repo-feature-login":                              "",
+		"--session repo-feature-login action new-tab --name feature/login --cwd /wt": "",
+	}}
+	zellij.SetRunner(fake)
+	defer zellij.ResetRunner()
+
+	m := Zellij{}
+	w, created, err := m.EnsureWindow("repo", WindowSpec{Name: "feature", Dir: "/wt", Tag: "7"})
+	if err != nil || created || w.ID != "feature" {
+		t.Fatalf("expected the existing tab, got %+v, %v, %v", w, created, err)
+	}
+	if _, created, err := m.EnsureWindow("repo", WindowSpec{Name: "review", Dir: "/wt"}); err != nil || !created {
+		t.Errorf("expected a new tab, got %v, %v", created, err)
+	}
+	w, created, err = m.EnsureWindow("repo-feature/login", WindowSpec{Name: "feature/login", Dir: "/wt"})
+	if err != nil || !created || w.Session != "repo-feature-login" {
+		t.Errorf("expected a tab in a new session, got %+v, %v, %v", w, created, err)
+	}
+
+	if err := m.Run(Window{Session: "repo", Name: "feature"}, "make"); err != nil {
+		t.Errorf("Run failed: %v", err)
+	}
+
+	windows, err := m.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	want := []Window{
+		{ID: "Tab #1", Session: "repo", Name: "Tab #1"},
+		{ID: "feature", Session: "repo", Name: "feature"},
+	}
+	if !reflect.DeepEqual(windows, want) {
+		t.Errorf("List() = %+v, want %+v", windows, want)
+	}
+}
@@ -0,0 +1,63 @@
+package mux
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Print is the backend for terminals without a multiplexer. There are no windows to
+// switch to, so it prints the commands that take the user to a track instead, e.g. for
+// eval "$(trak jump feature)".
+type Print struct {
+	Out io.Writer // Defaults to os.Stdout
+}
+
+// Name returns "print".
+func (Print) Name() string { return KindPrint }
+
+func (p Print) out() io.Writer {
+	if p.Out == nil {
+		return os.Stdout
+	}
+	return p.Out
+}
+
+// EnsureSession does nothing.
+func (Print) EnsureSession(session string) error { return nil }
+
+// EnsureWindow returns a window standing for the directory in spec. Nothing ever exists
+// to be found, so it is always created.
+func (Print) EnsureWindow(session string, spec WindowSpec) (Window, bool, error) {
+	return Window{Session: session, Name: spec.Name, Tag: spec.Tag, Dir: spec.Dir}, true, nil
+}
+
+// Run prints the command, run in the window's directory.
+func (p Print) Run(w Window, command string) error {
+	if w.Dir != "" {
+		command = "cd " + quote(w.Dir) + " && " + command
+	}
+	_, err := fmt.Fprintln(p.out(), command)
+	return err
+}
+
+// Focus prints the command changing to the window's directory.
+func (p Print) Focus(w Window) error {
+	if w.Dir == "" {
+		return nil
+	}
+	_, err := fmt.Fprintln(p.out(), "cd "+quote(w.Dir))
+	return err
+}
+
+// Kill does nothing.
+func (Print) Kill(w Window) error { return nil }
+
+// List returns no windows.
+func (Print) List() ([]Window, error) { return nil, nil }
+
+// quote quotes a string for POSIX shells.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,130 @@
+package mux
+
+import (
+	"fmt"
+
+	"github.com/laurent/trak/internal/tmux"
+)
+
+// Tmux hosts track windows in tmux. Windows are tagged with tmux.TrackOption.
+type Tmux struct{}
+
+// Name returns "tmux".
+func (Tmux) Name() string { return KindTmux }
+
+// EnsureSession creates a detached session unless it already exists.
+func (Tmux) EnsureSession(session string) error {
+	exists, err := tmux.SessionExists(session)
+	if err != nil {
+		return fmt.Errorf("failed to check session: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := tmux.CreateSession(session); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// EnsureWindow returns the window matching spec, creating and tagging it if there is none.
+// New windows of a missing session are created with the session, so it has no stray shell window.
+func (t Tmux) EnsureWindow(session string, spec WindowSpec) (Window, bool, error) {
+	w, err := t.find(session, spec)
+	if err != nil {
+		return Window{}, false, err
+	}
+	if w != nil {
+		return *w, false, nil
+	}
+
+	exists, err := tmux.SessionExists(session)
+	if err != nil {
+		return Window{}, false, fmt.Errorf("failed to check session: %w", err)
+	}
+	var id string
+	if exists {
+		id, err = tmux.CreateWindow(session, spec.Name, spec.Dir)
+	} else {
+		id, err = tmux.NewSession(session, spec.Name, spec.Dir)
+	}
+	if err != nil {
+		return Window{}, false, fmt.Errorf("failed to create window: %w", err)
+	}
+
+	if spec.Tag != "" {
+		if err := tmux.SetWindowOption(id, tmux.TrackOption, spec.Tag); err != nil {
+			return Window{}, false, fmt.Errorf("failed to tag window: %w", err)
+		}
+	}
+	return Window{ID: id, Session: session, Name: spec.Name, Tag: spec.Tag, Dir: spec.Dir}, true, nil
+}
+
+// find returns the window matching spec, or nil. The last known ID only counts while the
+// window is still tagged with spec.Tag, since tmux reuses IDs after a server restart.
+func (t Tmux) find(session string, spec WindowSpec) (*Window, error) {
+	if spec.Tag == "" {
+		exists, _ := tmux.WindowExists(session, spec.Name)
+		if !exists {
+			return nil, nil
+		}
+		id, err := tmux.WindowID(tmux.WindowTarget(session, spec.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get window ID: %w", err)
+		}
+		return &Window{ID: id, Session: session, Name: spec.Name}, nil
+	}
+
+	if spec.ID != "" {
+		if tw, err := tmux.DescribeWindow(spec.ID, tmux.TrackOption); err == nil && tw.Value == spec.Tag {
+			w := tmuxWindow(tw)
+			return &w, nil
+		}
+	}
+	windows, err := t.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range windows {
+		if w.Tag == spec.Tag {
+			return &w, nil
+		}
+	}
+	return nil, nil
+}
+
+// Run types a command into the active pane of a window and runs it.
+func (Tmux) Run(w Window, command string) error {
+	return tmux.RunInWindow(w.Session, w.ID, command)
+}
+
+// Focus switches to a window, attaching to its session when outside tmux.
+func (Tmux) Focus(w Window) error {
+	if tmux.IsInsideTmux() {
+		return tmux.SwitchToWindow(w.Session, w.ID)
+	}
+	_ = tmux.SelectWindow(w.Session, w.ID)
+	return tmux.AttachSession(w.Session)
+}
+
+// Kill kills a window.
+func (Tmux) Kill(w Window) error {
+	return tmux.KillWindow(w.Session, w.ID)
+}
+
+// List returns the windows of all sessions tagged with tmux.TrackOption.
+func (Tmux) List() ([]Window, error) {
+	tagged, err := tmux.TaggedWindows(tmux.TrackOption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+	windows := make([]Window, len(tagged))
+	for i, tw := range tagged {
+		windows[i] = tmuxWindow(tw)
+	}
+	return windows, nil
+}
+
+func tmuxWindow(tw tmux.TaggedWindow) Window {
+	return Window{ID: tw.ID, Session: tw.Session, Name: tw.Name, Tag: tw.Value}
+}
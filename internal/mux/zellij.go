@@ -0,0 +1,100 @@
+package mux
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/laurent/trak/internal/zellij"
+)
+
+// Zellij hosts track windows as zellij tabs. Tabs can't carry tags, so they are found by
+// name: a renamed tab is no longer found, and a new one is created instead.
+type Zellij struct{}
+
+// Name returns "zellij".
+func (Zellij) Name() string { return KindZellij }
+
+// EnsureSession creates a background session unless it already exists.
+func (Zellij) EnsureSession(session string) error {
+	session = zellij.SanitizeSessionName(session)
+	exists, err := zellij.SessionExists(session)
+	if err != nil {
+		return fmt.Errorf("failed to check session: %w", err)
+	}
+	if exists {
+		return nil
+	}
+	if err := zellij.CreateSession(session); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// EnsureWindow returns the tab of a session named spec.Name, creating it if there is none.
+func (z Zellij) EnsureWindow(session string, spec WindowSpec) (Window, bool, error) {
+	if err := z.EnsureSession(session); err != nil {
+		return Window{}, false, err
+	}
+	session = zellij.SanitizeSessionName(session)
+	w := Window{ID: spec.Name, Session: session, Name: spec.Name, Dir: spec.Dir}
+
+	names, err := zellij.TabNames(session)
+	if err != nil {
+		return Window{}, false, fmt.Errorf("failed to list tabs: %w", err)
+	}
+	if slices.Contains(names, spec.Name) {
+		return w, false, nil
+	}
+	if err := zellij.NewTab(session, spec.Name, spec.Dir); err != nil {
+		return Window{}, false, fmt.Errorf("failed to create tab: %w", err)
+	}
+	return w, true, nil
+}
+
+// Run types a command into the focused pane of a tab and runs it.
+func (Zellij) Run(w Window, command string) error {
+	return zellij.RunInTab(w.Session, w.Name, command)
+}
+
+// Focus switches to a tab, attaching to its session when outside zellij. The zellij CLI
+// can't switch the client to another session, so inside zellij the tab must be in the
+// current session.
+func (Zellij) Focus(w Window) error {
+	if err := zellij.GoToTab(w.Session, w.Name); err != nil {
+		return err
+	}
+	switch {
+	case !zellij.IsInsideZellij():
+		return zellij.AttachSession(w.Session)
+	case zellij.CurrentSession() != w.Session:
+		return fmt.Errorf("tab %s is in zellij session %s, switch to it to see the tab", w.Name, w.Session)
+	default:
+		return nil
+	}
+}
+
+// Kill closes a tab.
+func (Zellij) Kill(w Window) error {
+	return zellij.CloseTab(w.Session, w.Name)
+}
+
+// List returns the tabs of all sessions, untagged.
+func (Zellij) List() ([]Window, error) {
+	sessions, err := zellij.ListSessions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zellij sessions: %w", err)
+	}
+
+	windows := make([]Window, 0)
+	for _, session := range sessions {
+		names, err := zellij.TabNames(session)
+		if err != nil {
+			// Exited sessions are listed until they are deleted, but have no tabs to query
+			continue
+		}
+		for _, name := range names {
+			windows = append(windows, Window{ID: name, Session: session, Name: name})
+		}
+	}
+	return windows, nil
+}
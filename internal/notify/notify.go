@@ -0,0 +1,187 @@
+// Package notify delivers notifications about track changes to pluggable sinks:
+// desktop notifications, the tmux status line, the terminal bell, or a user command.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/laurent/trak/internal/tmux"
+)
+
+// Notification is a single message about a track.
+type Notification struct {
+	Branch  string `json:"branch"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+}
+
+// Sink delivers notifications.
+type Sink interface {
+	// Name returns the sink kind, e.g. "desktop".
+	Name() string
+	// Send delivers a notification.
+	Send(n Notification) error
+}
+
+// Sink kinds.
+const (
+	SinkDesktop = "desktop"
+	SinkTmux    = "tmux"
+	SinkBell    = "bell"
+	SinkCommand = "command"
+)
+
+// DefaultSinks are used when none are configured.
+var DefaultSinks = []string{SinkDesktop}
+
+// CommandRunner runs notification commands, allowing for mocking in tests.
+type CommandRunner interface {
+	Run(stdin []byte, env []string, name string, args ...string) error
+}
+
+// DefaultRunner executes commands using os/exec.
+type DefaultRunner struct{}
+
+// Run executes a command with the given stdin and extra environment variables.
+func (DefaultRunner) Run(stdin []byte, env []string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s failed: %w\nstderr: %s", name, strings.Join(args, " "), err, stderr.String())
+	}
+	return nil
+}
+
+// runner is the command runner used by this package.
+// It can be replaced with a mock for testing.
+var runner CommandRunner = DefaultRunner{}
+
+// SetRunner sets the command runner (used for testing).
+func SetRunner(r CommandRunner) {
+	runner = r
+}
+
+// ResetRunner resets the command runner to the default.
+func ResetRunner() {
+	runner = DefaultRunner{}
+}
+
+// New returns the sinks with the given names. command is the shell command run
+// by the "command" sink, which is required when that sink is selected.
+func New(names []string, command string) ([]Sink, error) {
+	if len(names) == 0 {
+		names = DefaultSinks
+	}
+
+	sinks := make([]Sink, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case SinkDesktop:
+			sinks = append(sinks, Desktop{})
+		case SinkTmux:
+			sinks = append(sinks, Tmux{})
+		case SinkBell:
+			sinks = append(sinks, Bell{Out: os.Stdout})
+		case SinkCommand:
+			if command == "" {
+				return nil, fmt.Errorf("notification sink %q requires a command to be configured", SinkCommand)
+			}
+			sinks = append(sinks, Command{Command: command})
+		default:
+			return nil, fmt.Errorf("unknown notification sink %q (expected %s, %s, %s or %s)",
+				name, SinkDesktop, SinkTmux, SinkBell, SinkCommand)
+		}
+	}
+	return sinks, nil
+}
+
+// SendAll delivers a notification to every sink, returning the errors of those that failed.
+func SendAll(sinks []Sink, n Notification) error {
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Send(n); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Desktop shows desktop notifications via notify-send, or osascript on macOS.
+type Desktop struct{}
+
+// Name returns "desktop".
+func (Desktop) Name() string { return SinkDesktop }
+
+// Send shows a desktop notification.
+func (Desktop) Send(n Notification) error {
+	if runtime.GOOS == "darwin" {
+		script := fmt.Sprintf("display notification %q with title %q", n.Message, n.Title)
+		return runner.Run(nil, nil, "osascript", "-e", script)
+	}
+	return runner.Run(nil, nil, "notify-send", "--app-name=trak", n.Title, n.Message)
+}
+
+// Tmux shows notifications in the tmux status line.
+type Tmux struct{}
+
+// Name returns "tmux".
+func (Tmux) Name() string { return SinkTmux }
+
+// Send displays the notification as a tmux message.
+func (Tmux) Send(n Notification) error {
+	return tmux.DisplayMessage(n.Title + ": " + n.Message)
+}
+
+// Bell rings the terminal bell and prints the notification.
+type Bell struct {
+	Out io.Writer
+}
+
+// Name returns "bell".
+func (Bell) Name() string { return SinkBell }
+
+// Send rings the bell.
+func (b Bell) Send(n Notification) error {
+	_, err := fmt.Fprintf(b.Out, "\a%s: %s\n", n.Title, n.Message)
+	return err
+}
+
+// Command runs a shell command for each notification, e.g. to post to a webhook.
+// The notification is passed as JSON on stdin and in the TRAK_BRANCH, TRAK_TITLE,
+// TRAK_MESSAGE and TRAK_URL environment variables.
+type Command struct {
+	Command string
+}
+
+// Name returns "command".
+func (Command) Name() string { return SinkCommand }
+
+// Send runs the command.
+func (c Command) Send(n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	env := []string{
+		"TRAK_BRANCH=" + n.Branch,
+		"TRAK_TITLE=" + n.Title,
+		"TRAK_MESSAGE=" + n.Message,
+		"TRAK_URL=" + n.URL,
+	}
+	return runner.Run(payload, env, "sh", "-c", c.Command)
+}
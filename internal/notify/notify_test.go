@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// MockRunner is a mock command runner for testing.
+type MockRunner struct {
+	// Err is returned by every call when set
+	Err error
+	// Calls records all commands that were executed
+	Calls []MockCall
+}
+
+// MockCall records a single command.
+type MockCall struct {
+	Stdin []byte
+	Env   []string
+	Name  string
+	Args  []string
+}
+
+func (m *MockRunner) Run(stdin []byte, env []string, name string, args ...string) error {
+	m.Calls = append(m.Calls, MockCall{Stdin: stdin, Env: env, Name: name, Args: args})
+	return m.Err
+}
+
+func TestNew(t *testing.T) {
+	sinks, err := New(nil, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sinks) != 1 || sinks[0].Name() != SinkDesktop {
+		t.Errorf("expected default desktop sink, got %v", sinks)
+	}
+
+	sinks, err = New([]string{SinkTmux, SinkBell, SinkCommand}, "curl -d @- https://hooks.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sinks) != 3 {
+		t.Errorf("expected 3 sinks, got %d", len(sinks))
+	}
+
+	if _, err := New([]string{SinkCommand}, ""); err == nil {
+		t.Error("expected error for command sink without a command")
+	}
+	if _, err := New([]string{"pager"}, ""); err == nil {
+		t.Error("expected error for unknown sink")
+	}
+}
+
+func TestDesktopSend(t *testing.T) {
+	mock := &MockRunner{}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if err := (Desktop{}).Send(Notification{Title: "feature", Message: "CI passed"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Calls) != 1 {
+		t.Fatalf("expected 1 call, got %d", len(mock.Calls))
+	}
+	want := "notify-send"
+	if runtime.GOOS == "darwin" {
+		want = "osascript"
+	}
+	if mock.Calls[0].Name != want {
+		t.Errorf("expected %s, got %s", want, mock.Calls[0].Name)
+	}
+}
+
+func TestBellSend(t *testing.T) {
+	var out bytes.Buffer
+	if err := (Bell{Out: &out}).Send(Notification{Title: "feature", Message: "Approved"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "\afeature: Approved\n" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func TestCommandSend(t *testing.T) {
+	mock := &MockRunner{}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	n := Notification{Branch: "feature", Title: "feature", Message: "PR merged", URL: "https://github.com/o/r/pull/1"}
+	if err := (Command{Command: "./hook.sh"}).Send(n); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	call := mock.Calls[0]
+	if call.Name != "sh" || strings.Join(call.Args, " ") != "-c ./hook.sh" {
+		t.Errorf("unexpected command: %s %v", call.Name, call.Args)
+	}
+
+	var got Notification
+	if err := json.Unmarshal(call.Stdin, &got); err != nil {
+		t.Fatalf("expected JSON on stdin: %v", err)
+	}
+	if got != n {
+		t.Errorf("expected %+v on stdin, got %+v", n, got)
+	}
+	if !strings.Contains(strings.Join(call.Env, "\n"), "TRAK_MESSAGE=PR merged") {
+		t.Errorf("expected TRAK_MESSAGE in env, got %v", call.Env)
+	}
+}
+
+func TestSendAll(t *testing.T) {
+	mock := &MockRunner{Err: errors.New("boom")}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	var out bytes.Buffer
+	err := SendAll([]Sink{Bell{Out: &out}, Desktop{}}, Notification{Title: "t", Message: "m"})
+	if err == nil || !strings.Contains(err.Error(), "desktop: boom") {
+		t.Errorf("expected desktop error, got %v", err)
+	}
+	if out.Len() == 0 {
+		t.Error("expected other sinks to still be notified")
+	}
+}
@@ -0,0 +1,208 @@
+package ops
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/laurent/trak/internal/config"
+	"github.com/laurent/trak/internal/mux"
+	"github.com/laurent/trak/internal/tmux"
+	"github.com/laurent/trak/internal/track"
+)
+
+// MainPane selects the first pane of a track's window (see CaptureOptions.Pane).
+const MainPane = -1
+
+// capturePrefix starts the names of the files SaveCapture writes to a track's log directory.
+const capturePrefix = "capture-"
+
+// CaptureOptions configures what is captured from a track's window.
+type CaptureOptions struct {
+	Pane  int // tmux pane index, or MainPane
+	Lines int // Lines of scrollback to include above the visible pane; 0 for all of it
+}
+
+// GrepMatch is a line of captured output matching a pattern.
+type GrepMatch struct {
+	Branch string // Branch of the track, or its log directory if it's no longer tracked
+	Path   string
+	Line   int
+	Text   string
+}
+
+// trackPane returns the ID and index of a pane of a track's window. Only tmux panes can be captured.
+func (o *Ops) trackPane(branch string, index int) (string, int, error) {
+	if o.mux.Name() != mux.KindTmux {
+		return "", 0, fmt.Errorf("capturing output requires tmux, not %s", o.mux.Name())
+	}
+	trk, err := o.getTrack(branch)
+	if err != nil {
+		return "", 0, err
+	}
+	w, err := o.findTrackWindow(trk)
+	if err != nil {
+		return "", 0, err
+	}
+	if w == nil {
+		return "", 0, fmt.Errorf("track %s has no tmux window", branch)
+	}
+
+	panes, err := tmux.ListPanes(w.ID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to list panes: %w", err)
+	}
+	for _, p := range panes {
+		if index == MainPane || p.Index == index {
+			return p.ID, p.Index, nil
+		}
+	}
+	return "", 0, fmt.Errorf("window %s has no pane %d", w.ID, index)
+}
+
+// CapturePane returns the output of a pane of a track's window.
+func (o *Ops) CapturePane(branch string, opts CaptureOptions) (string, error) {
+	paneID, _, err := o.trackPane(branch, opts.Pane)
+	if err != nil {
+		return "", err
+	}
+	output, err := tmux.CapturePane(paneID, opts.Lines)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture pane: %w", err)
+	}
+	return output, nil
+}
+
+// SaveCapture captures a pane of a track's window to the track's log directory, so it
+// outlives the scrollback and can be searched with GrepCaptures. Returns the file's path.
+func (o *Ops) SaveCapture(branch string, opts CaptureOptions) (string, error) {
+	paneID, index, err := o.trackPane(branch, opts.Pane)
+	if err != nil {
+		return "", err
+	}
+	output, err := tmux.CapturePane(paneID, opts.Lines)
+	if err != nil {
+		return "", fmt.Errorf("failed to capture pane: %w", err)
+	}
+
+	dir := config.GetTrackLogDir(track.Slugify(o.config.Repo.Remote), track.Slugify(branch))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	// Microseconds keep captures taken in the same second apart
+	name := fmt.Sprintf("%s%s-pane%d.log", capturePrefix, time.Now().Format("20060102-150405.000000"), index)
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(output+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("failed to write capture: %w", err)
+	}
+	return path, nil
+}
+
+// TailPane returns the last lines of the main pane of a track's window, or nil if the
+// track has no window or the multiplexer isn't tmux.
+func (o *Ops) TailPane(branch string, lines int) ([]string, error) {
+	if o.mux.Name() != mux.KindTmux {
+		return nil, nil
+	}
+	trk, err := o.getTrack(branch)
+	if err != nil {
+		return nil, err
+	}
+	if w, err := o.findTrackWindow(trk); err != nil || w == nil {
+		return nil, err
+	}
+
+	output, err := o.CapturePane(branch, CaptureOptions{Pane: MainPane, Lines: lines})
+	if err != nil {
+		return nil, err
+	}
+
+	// The capture also includes the visible pane
+	all := strings.Split(output, "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return all, nil
+}
+
+// GrepCaptures searches the captured output of the repo's tracks, oldest capture first.
+func (o *Ops) GrepCaptures(pattern *regexp.Regexp) ([]GrepMatch, error) {
+	// Log directories are named after track slugs, map them back to branches
+	branches := make(map[string]string)
+	if tracks, err := o.RepoTracks(); err == nil {
+		for _, trk := range tracks {
+			branches[track.Slugify(trk.Branch)] = trk.Branch
+		}
+	}
+
+	root := config.GetRepoLogDir(track.Slugify(o.config.Repo.Remote))
+	dirs, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read log directory: %w", err)
+	}
+
+	type capture struct{ path, branch string }
+	var captures []capture
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		branch, ok := branches[dir.Name()]
+		if !ok {
+			branch = dir.Name()
+		}
+
+		paths, _ := filepath.Glob(filepath.Join(root, dir.Name(), capturePrefix+"*.log"))
+		for _, path := range paths {
+			captures = append(captures, capture{path, branch})
+		}
+	}
+	// Capture names start with their timestamp, so they sort by time across tracks
+	sort.SliceStable(captures, func(i, j int) bool {
+		return filepath.Base(captures[i].path) < filepath.Base(captures[j].path)
+	})
+
+	var matches []GrepMatch
+	for _, c := range captures {
+		found, err := grepFile(c.path, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range found {
+			m.Branch = c.branch
+			matches = append(matches, m)
+		}
+	}
+	return matches, nil
+}
+
+// grepFile returns the lines of a file matching a pattern.
+func grepFile(path string, pattern *regexp.Regexp) ([]GrepMatch, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var matches []GrepMatch
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if text := scanner.Text(); pattern.MatchString(text) {
+			matches = append(matches, GrepMatch{Path: path, Line: line, Text: text})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return matches, nil
+}
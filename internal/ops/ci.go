@@ -0,0 +1,169 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/laurent/trak/internal/config"
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/github"
+	"github.com/laurent/trak/internal/mux"
+	"github.com/laurent/trak/internal/tmux"
+	"github.com/laurent/trak/internal/track"
+)
+
+// ciExcerptContext is the number of leading context lines kept around the error block.
+const ciExcerptContext = 40
+
+// aiStartupDelay is how long to wait for the AI agent to start before sending it a prompt.
+var aiStartupDelay = 3 * time.Second
+
+// CILogs contains the failing job logs of a track's latest failed CI run.
+type CILogs struct {
+	RunID   int64
+	RunName string
+	RunURL  string
+	Logs    string // Full logs of the failed jobs
+	Excerpt string // Heuristically extracted last error block
+}
+
+// GetCILogs fetches the failing job logs for the latest failed CI run of a branch.
+func (o *Ops) GetCILogs(branch string) (*CILogs, error) {
+	if err := o.requireGitHub("fetching CI logs"); err != nil {
+		return nil, err
+	}
+	run, logs, err := github.GetFailedRunLogs(o.config.Repo.Remote, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CILogs{
+		RunID:   run.ID,
+		RunName: run.Name,
+		RunURL:  run.URL,
+		Logs:    logs,
+		Excerpt: github.ExtractErrorBlock(logs, ciExcerptContext),
+	}, nil
+}
+
+// RerunFailedCI re-runs the failed jobs of the latest CI runs for a branch's PR.
+// Returns the names of the workflows that were re-run.
+func (o *Ops) RerunFailedCI(branch string) ([]string, error) {
+	if err := o.requireGitHub("re-running CI"); err != nil {
+		return nil, err
+	}
+	runs, err := github.RerunFailed(o.config.Repo.Remote, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(runs))
+	for i, r := range runs {
+		names[i] = r.Name
+	}
+	return names, nil
+}
+
+// SaveCILogs writes the full logs and the excerpt to the track's log directory.
+// Returns the paths of the written log and excerpt files.
+func (o *Ops) SaveCILogs(branch string, logs *CILogs) (logPath, excerptPath string, err error) {
+	dir := config.GetTrackLogDir(track.Slugify(o.config.Repo.Remote), track.Slugify(branch))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	logPath = filepath.Join(dir, fmt.Sprintf("ci-%d.log", logs.RunID))
+	if err := os.WriteFile(logPath, []byte(github.StripLogPrefixes(logs.Logs)), 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write CI log: %w", err)
+	}
+
+	excerptPath = filepath.Join(dir, fmt.Sprintf("ci-%d-excerpt.log", logs.RunID))
+	if err := os.WriteFile(excerptPath, []byte(logs.Excerpt+"\n"), 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write CI excerpt: %w", err)
+	}
+
+	return logPath, excerptPath, nil
+}
+
+// OpenCILogsWindow opens a saved CI log in a pager inside a dedicated window.
+func (o *Ops) OpenCILogsWindow(branch, logPath string) error {
+	sessionName, err := o.sessionName(branch)
+	if err != nil {
+		return err
+	}
+
+	w, _, err := o.mux.EnsureWindow(sessionName, mux.WindowSpec{Name: track.SanitizeForTmux("ci-" + branch)})
+	if err != nil {
+		return err
+	}
+
+	// Open at the end of the file, where failures usually are
+	if err := o.mux.Run(w, "less -R +G "+shellQuote(logPath)); err != nil {
+		return fmt.Errorf("failed to open pager: %w", err)
+	}
+
+	return o.mux.Focus(w)
+}
+
+// SendCIExcerptToAI asks the AI agent in the track's window to investigate a CI failure.
+// If the window is idle at a shell prompt, the agent is started first. Only tmux tells
+// what runs in a window, so with other multiplexers the agent is always started.
+func (o *Ops) SendCIExcerptToAI(branch, excerptPath string) error {
+	remote := o.config.Repo.Remote
+
+	trk, err := o.db.GetTrack(remote, branch)
+	if err != nil {
+		return fmt.Errorf("failed to get track: %w", err)
+	}
+	if trk == nil {
+		return fmt.Errorf("track not found for branch: %s", branch)
+	}
+	if trk.Type != db.TrackTypeWorktree {
+		return fmt.Errorf("AI assistant is not supported for devbox tracks")
+	}
+	if trk.Path == nil {
+		return fmt.Errorf("worktree track has no path")
+	}
+
+	w, err := o.ensureTrackWindow(trk)
+	if err != nil {
+		return err
+	}
+
+	current := ""
+	if o.mux.Name() == mux.KindTmux {
+		current, _ = tmux.CurrentCommand(w.Session, w.ID)
+	}
+	if current == "" || isShell(current) {
+		cmd := fmt.Sprintf("%s %s", o.config.AI.AgentCommand(), *trk.Path)
+		if err := o.mux.Run(w, cmd); err != nil {
+			return fmt.Errorf("failed to run AI command: %w", err)
+		}
+		time.Sleep(aiStartupDelay)
+	}
+
+	prompt := fmt.Sprintf("CI is failing on branch %s. The failing log excerpt is in %s. Please diagnose the failure and fix it.", branch, excerptPath)
+	if err := o.mux.Run(w, prompt); err != nil {
+		return fmt.Errorf("failed to send prompt to AI: %w", err)
+	}
+
+	return o.mux.Focus(w)
+}
+
+// isShell reports whether a pane command is an interactive shell.
+func isShell(command string) bool {
+	switch strings.TrimPrefix(filepath.Base(command), "-") {
+	case "sh", "bash", "zsh", "fish", "dash", "ksh", "tcsh", "csh", "nu":
+		return true
+	default:
+		return false
+	}
+}
+
+// shellQuote quotes a string for safe use as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
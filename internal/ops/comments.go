@@ -0,0 +1,93 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/github"
+)
+
+// ListReviewThreads returns the unresolved review threads on a branch's PR.
+func (o *Ops) ListReviewThreads(branch string) ([]github.ReviewThread, error) {
+	remote := o.config.Repo.Remote
+	if err := o.requireGitHub("review threads"); err != nil {
+		return nil, err
+	}
+
+	trk, err := o.getTrack(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := o.prForTrack(trk)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, fmt.Errorf("no PR found for branch: %s", branch)
+	}
+
+	return github.ListReviewThreads(remote, pr.Number)
+}
+
+// OpenThreadInEditor opens the file a review thread refers to in $EDITOR,
+// at the thread's line, inside the track's window.
+func (o *Ops) OpenThreadInEditor(branch string, thread github.ReviewThread) error {
+	trk, err := o.getTrack(branch)
+	if err != nil {
+		return err
+	}
+	if trk.Type != db.TrackTypeWorktree {
+		return fmt.Errorf("opening files is not supported for devbox tracks")
+	}
+	if trk.Path == nil {
+		return fmt.Errorf("worktree track has no path")
+	}
+
+	w, err := o.ensureTrackWindow(trk)
+	if err != nil {
+		return err
+	}
+
+	cmd := editorCommand(thread.Path, thread.Line)
+	if err := o.mux.Run(w, cmd); err != nil {
+		return fmt.Errorf("failed to open editor: %w", err)
+	}
+
+	return o.mux.Focus(w)
+}
+
+// editorCommand returns the command opening a file in $EDITOR (vi by default) at a line,
+// 0 for none. It works in POSIX shells and fish: $EDITOR is resolved here, and left
+// unquoted so that editors with arguments work.
+func editorCommand(path string, line int) string {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	if line == 0 {
+		return fmt.Sprintf("%s %s", editor, shellQuote(path))
+	}
+	return fmt.Sprintf("%s +%d %s", editor, line, shellQuote(path))
+}
+
+// ResolveReviewThread marks a review thread as resolved.
+func (o *Ops) ResolveReviewThread(threadID string) error {
+	if err := o.requireGitHub("review threads"); err != nil {
+		return err
+	}
+	return github.ResolveReviewThread(threadID)
+}
+
+// getTrack returns the track for a branch, or an error if it isn't tracked.
+func (o *Ops) getTrack(branch string) (*db.Track, error) {
+	trk, err := o.db.GetTrack(o.config.Repo.Remote, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get track: %w", err)
+	}
+	if trk == nil {
+		return nil, fmt.Errorf("track not found for branch: %s", branch)
+	}
+	return trk, nil
+}
@@ -0,0 +1,90 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/laurent/trak/internal/config"
+	"github.com/laurent/trak/internal/track"
+)
+
+// remoteBranchCacheTTL is how long remote branches cached for completion are used
+// before the forge is asked again.
+const remoteBranchCacheTTL = 10 * time.Minute
+
+// TrackBranches returns the branches of the repo's tracks, most recently accessed first.
+func (o *Ops) TrackBranches() ([]string, error) {
+	tracks, err := o.RepoTracks()
+	if err != nil {
+		return nil, err
+	}
+	branches := make([]string, len(tracks))
+	for i, trk := range tracks {
+		branches[i] = trk.Branch
+	}
+	return branches, nil
+}
+
+// UntrackedRemoteBranches returns the remote branches ListRemoteBranches lists that have no
+// track yet, for completion. They come from the cache ListRemoteBranches writes while it's
+// fresh, so completing doesn't wait for the forge; a stale cache is used if the forge fails.
+func (o *Ops) UntrackedRemoteBranches() ([]string, error) {
+	names, fresh := o.loadRemoteBranchCache()
+	if !fresh {
+		branches, err := o.ListRemoteBranches()
+		switch {
+		case err == nil:
+			names = make([]string, len(branches))
+			for i, b := range branches {
+				names[i] = b.Name
+			}
+		case names == nil:
+			return nil, err
+		}
+	}
+
+	tracked, err := o.TrackBranches()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(names, func(name string) bool { return slices.Contains(tracked, name) }), nil
+}
+
+// remoteBranchCachePath returns the file caching the remote branches of the repo.
+func (o *Ops) remoteBranchCachePath() string {
+	return filepath.Join(config.GetCacheDir(), fmt.Sprintf("remote-branches-%s.txt", track.Slugify(o.config.Repo.Remote)))
+}
+
+// loadRemoteBranchCache returns the cached remote branch names, nil if there are none,
+// and whether they are recent enough to be used without asking the forge.
+func (o *Ops) loadRemoteBranchCache() ([]string, bool) {
+	path := o.remoteBranchCachePath()
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	names := strings.Fields(string(data))
+	return names, time.Since(info.ModTime()) < remoteBranchCacheTTL
+}
+
+// saveRemoteBranchCache caches the names of remote branches for completion. Failures only
+// make completion slower, so they are ignored.
+func (o *Ops) saveRemoteBranchCache(branches []RemoteBranch) {
+	var b strings.Builder
+	for _, rb := range branches {
+		b.WriteString(rb.Name + "\n")
+	}
+	path := o.remoteBranchCachePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(b.String()), 0644)
+}
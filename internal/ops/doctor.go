@@ -0,0 +1,196 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/mux"
+	"github.com/laurent/trak/internal/tmux"
+	"github.com/laurent/trak/internal/track"
+)
+
+// legacySessionName is the session all track windows went in before sessions were
+// configurable and windows were tagged.
+const legacySessionName = "trak"
+
+// DoctorIssue is a problem found by Doctor.
+type DoctorIssue struct {
+	Branch  string // "" for issues not tied to a track
+	Problem string
+	Fix     string // What fixes it, or what was done if Fixed
+	Fixable bool   // Doctor fixes it when asked to
+	Fixed   bool
+}
+
+// Doctor checks that tracks and their tmux windows are consistent: worktrees exist,
+// recorded window IDs point at the window tagged with the track, and every tagged window
+// belongs to a track. With fix, recorded window IDs are corrected and windows created
+// before trak tagged them are adopted. Nothing is ever deleted or killed.
+// Windows are only checked with tmux, the only multiplexer that tags them.
+func (o *Ops) Doctor(fix bool) ([]DoctorIssue, error) {
+	tracks, err := o.db.ListTracks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracks: %w", err)
+	}
+	checkWindows := o.mux.Name() == mux.KindTmux
+	var windows []tmux.TaggedWindow
+	if checkWindows {
+		windows, err = tmux.TaggedWindows(tmux.TrackOption)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tmux windows: %w", err)
+		}
+	}
+
+	tagged := make(map[string][]tmux.TaggedWindow)
+	taggedNames := make(map[string]bool)
+	for _, w := range windows {
+		tagged[w.Value] = append(tagged[w.Value], w)
+		if w.Session == legacySessionName {
+			taggedNames[w.Name] = true
+		}
+	}
+
+	var issues []DoctorIssue
+	known := make(map[string]bool)
+	for i := range tracks {
+		trk := &tracks[i]
+		tag := trackTag(trk)
+		known[tag] = true
+
+		if trk.Type == db.TrackTypeWorktree && trk.Path != nil {
+			if _, err := os.Stat(*trk.Path); os.IsNotExist(err) {
+				issues = append(issues, DoctorIssue{
+					Branch:  trk.Branch,
+					Problem: fmt.Sprintf("worktree %s does not exist", *trk.Path),
+					Fix:     "run trak delete " + trk.Branch,
+				})
+			}
+		}
+
+		if !checkWindows {
+			continue
+		}
+		trackWindows := tagged[tag]
+		switch {
+		case len(trackWindows) > 1:
+			ids := make([]string, len(trackWindows))
+			for j, w := range trackWindows {
+				ids[j] = w.ID
+			}
+			issues = append(issues, DoctorIssue{
+				Branch:  trk.Branch,
+				Problem: fmt.Sprintf("%d windows are tagged with this track: %s", len(ids), strings.Join(ids, ", ")),
+				Fix:     "kill all but one with tmux kill-window -t <id>",
+			})
+		case len(trackWindows) == 1:
+			if issue, ok := o.checkRecordedWindow(trk, trackWindows[0].ID, fix); ok {
+				issues = append(issues, issue)
+			}
+		default:
+			if issue, ok := o.checkUntaggedWindow(trk, taggedNames, fix); ok {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	values := make([]string, 0, len(tagged))
+	for value := range tagged {
+		if !known[value] {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		for _, w := range tagged[value] {
+			issues = append(issues, DoctorIssue{
+				Problem: fmt.Sprintf("window %s (%s:%s) is tagged with unknown track %s", w.ID, w.Session, w.Name, value),
+				Fix:     "run tmux kill-window -t " + w.ID,
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// checkRecordedWindow reports a track whose recorded window ID isn't the window tagged with it.
+func (o *Ops) checkRecordedWindow(trk *db.Track, windowID string, fix bool) (DoctorIssue, bool) {
+	if trk.TmuxWindow != nil && *trk.TmuxWindow == windowID {
+		return DoctorIssue{}, false
+	}
+
+	issue := DoctorIssue{
+		Branch:  trk.Branch,
+		Problem: fmt.Sprintf("recorded window %s is not the track's window %s", formatWindowID(trk.TmuxWindow), windowID),
+		Fix:     "record " + windowID,
+		Fixable: true,
+	}
+	if fix {
+		o.saveTrackWindow(trk, windowID)
+		issue.Fixed = true
+	}
+	return issue, true
+}
+
+// checkUntaggedWindow reports a track without a tagged window. Windows created before trak
+// tagged them are named after the branch in the trak session; they are adopted when the
+// name is unambiguous and not already used by a tagged window; otherwise a stale recorded
+// ID is cleared.
+func (o *Ops) checkUntaggedWindow(trk *db.Track, taggedNames map[string]bool, fix bool) (DoctorIssue, bool) {
+	sessionName := legacySessionName
+	windowName := track.SanitizeForTmux(trk.Branch)
+
+	names, _ := tmux.ListWindows(sessionName)
+	matches := 0
+	for _, name := range names {
+		if name == windowName {
+			matches++
+		}
+	}
+
+	if matches == 1 && !taggedNames[windowName] {
+		issue := DoctorIssue{
+			Branch:  trk.Branch,
+			Problem: fmt.Sprintf("window %s:%s is not tagged with the track", sessionName, windowName),
+			Fix:     "tag it",
+			Fixable: true,
+		}
+		if fix {
+			windowID, err := tmux.WindowID(tmux.WindowTarget(sessionName, windowName))
+			if err == nil {
+				err = o.tagTrackWindow(trk, windowID)
+			}
+			if err != nil {
+				issue.Fix = fmt.Sprintf("failed to tag it: %v", err)
+			} else {
+				issue.Fixed = true
+			}
+		}
+		return issue, true
+	}
+
+	if trk.TmuxWindow == nil {
+		return DoctorIssue{}, false
+	}
+	issue := DoctorIssue{
+		Branch:  trk.Branch,
+		Problem: fmt.Sprintf("recorded window %s no longer exists", *trk.TmuxWindow),
+		Fix:     "clear it",
+		Fixable: true,
+	}
+	if fix {
+		o.saveTrackWindow(trk, "")
+		issue.Fixed = true
+	}
+	return issue, true
+}
+
+// formatWindowID formats a recorded window ID for display.
+func formatWindowID(id *string) string {
+	if id == nil {
+		return "(none)"
+	}
+	return *id
+}
@@ -0,0 +1,104 @@
+package ops
+
+import (
+	"fmt"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/git"
+)
+
+// ListEvents returns the history of a track, newest first.
+// An empty branch returns the history of all tracks, including deleted ones.
+// A limit of 0 or less returns all events.
+func (o *Ops) ListEvents(branch string, limit int) ([]db.Event, error) {
+	return o.db.ListEvents(o.config.Repo.Remote, branch, limit)
+}
+
+// recordEvent appends an event to the history of a track.
+// History is best-effort: failing to record it never fails the operation itself.
+func (o *Ops) recordEvent(event db.Event) {
+	event.RemoteURL = o.config.Repo.Remote
+	_ = o.db.InsertEvent(event)
+}
+
+// recordError records that an operation on a track failed. It does nothing if err is nil.
+func (o *Ops) recordError(branch, operation string, err error) {
+	if err == nil {
+		return
+	}
+	o.recordEvent(db.Event{
+		Branch: branch,
+		Kind:   db.EventError,
+		Detail: fmt.Sprintf("%s: %v", operation, err),
+	})
+}
+
+// recordSync records the outcome of a sync, and the push and PR creation it led to.
+func (o *Ops) recordSync(branch, beforeSHA string, result *SyncResult, err error) {
+	if err != nil {
+		o.recordError(branch, "sync", err)
+		return
+	}
+
+	afterSHA := o.trackHeadSHA(branch)
+	if result.HasConflicts {
+		o.recordEvent(db.Event{
+			Branch:    branch,
+			Kind:      db.EventConflict,
+			Detail:    "rebase stopped on conflicts in " + result.ConflictsPath,
+			BeforeSHA: beforeSHA,
+			AfterSHA:  afterSHA,
+		})
+		return
+	}
+
+	detail := "already up to date"
+	if beforeSHA != afterSHA {
+		detail = "rebased"
+	}
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventSync, Detail: detail, BeforeSHA: beforeSHA, AfterSHA: afterSHA})
+
+	if result.Pushed {
+		o.recordEvent(db.Event{Branch: branch, Kind: db.EventPush, Detail: "force-pushed after sync", AfterSHA: afterSHA})
+	}
+	if result.PRCreated {
+		o.recordEvent(db.Event{Branch: branch, Kind: db.EventPRCreate, Detail: fmt.Sprintf("created PR #%d", result.PRNumber)})
+	}
+}
+
+// trackHeadSHA returns the current head SHA of a track's worktree.
+// Falls back to the SHA recorded in the database, or "" if the track doesn't exist.
+func (o *Ops) trackHeadSHA(branch string) string {
+	trk, err := o.db.GetTrack(o.config.Repo.Remote, branch)
+	if err != nil || trk == nil {
+		return ""
+	}
+	if trk.Type == db.TrackTypeWorktree && trk.Path != nil {
+		if sha, err := git.GetHeadSHA(*trk.Path); err == nil {
+			return sha
+		}
+	}
+	return trk.HeadSHA
+}
+
+// EventSHA formats the head SHA change of an event for display, e.g. "1a2b3c4→5d6e7f8".
+func EventSHA(e db.Event) string {
+	switch {
+	case e.BeforeSHA != "" && e.AfterSHA != "" && e.BeforeSHA != e.AfterSHA:
+		return ShortSHA(e.BeforeSHA) + "→" + ShortSHA(e.AfterSHA)
+	case e.AfterSHA != "":
+		return ShortSHA(e.AfterSHA)
+	case e.BeforeSHA != "":
+		return ShortSHA(e.BeforeSHA)
+	default:
+		return "—"
+	}
+}
+
+// ShortSHA abbreviates a commit SHA to 7 characters.
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
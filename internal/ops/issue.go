@@ -0,0 +1,63 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/laurent/trak/internal/forge"
+	"github.com/laurent/trak/internal/github"
+	"github.com/laurent/trak/internal/issue"
+)
+
+// IssueBranch looks up an issue and derives a branch name for it from the
+// configured branch template. Returns the branch and the issue.
+func (o *Ops) IssueBranch(ref string) (string, *issue.Issue, error) {
+	iss, err := o.issues.GetIssue(ref)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get issue %s: %w", ref, err)
+	}
+
+	tmpl := o.config.Issue.BranchTemplate
+	var user string
+	if strings.Contains(tmpl, "{{user}}") {
+		user = o.currentUser()
+	}
+
+	branch := issue.BranchName(tmpl, user, *iss)
+	if branch == "" {
+		return "", nil, fmt.Errorf("branch template %q produced an empty branch name", tmpl)
+	}
+	return branch, iss, nil
+}
+
+// LinkIssue links a track to an issue by its canonical reference.
+func (o *Ops) LinkIssue(branch, ref string) error {
+	if err := o.db.SetIssueRef(o.config.Repo.Remote, branch, ref); err != nil {
+		return fmt.Errorf("failed to link issue: %w", err)
+	}
+	return nil
+}
+
+// issueLine returns the line linking a PR body to the track's issue, or "" if none is linked.
+func (o *Ops) issueLine(ref *string) string {
+	if ref == nil || *ref == "" {
+		return ""
+	}
+	return o.issues.PRReference(*ref, !o.config.Issue.MentionOnly)
+}
+
+// currentUser returns the username used in branch templates: the GitHub login
+// on GitHub, and the local user elsewhere. Empty if unknown.
+func (o *Ops) currentUser() string {
+	if g, ok := o.forge.(forge.GitHub); ok {
+		getUser := github.GetCurrentUser
+		if g.Client != nil {
+			getUser = g.Client.GetCurrentUser
+		}
+		if user, err := getUser(); err == nil {
+			return user
+		}
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,76 @@
+package ops
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/laurent/trak/internal/config"
+	"github.com/laurent/trak/internal/tmux"
+)
+
+// applyLayout splits a freshly created window into the panes of a layout and starts
+// their commands. The window's initial pane becomes the layout's first pane.
+func applyLayout(sessionName, windowID, workDir string, layout *config.Layout) error {
+	first, err := tmux.ActivePane(sessionName, windowID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, len(layout.Panes))
+	named := make(map[string]string)
+	focus := first
+	for i, p := range layout.Panes {
+		id := first
+		if i > 0 {
+			from := ids[i-1]
+			if p.From != "" {
+				from = named[p.From]
+			}
+			id, err = tmux.SplitPane(from, tmux.SplitOptions{
+				Right:    p.Split == config.SplitRight,
+				Size:     p.Size,
+				StartDir: paneDir(workDir, p.Dir),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to split pane %d: %w", i+1, err)
+			}
+		}
+		ids[i] = id
+		if p.Name != "" {
+			named[p.Name] = id
+		}
+		if p.Focus {
+			focus = id
+		}
+	}
+
+	if layout.Select != "" {
+		if err := tmux.SelectLayout(sessionName, windowID, layout.Select); err != nil {
+			return err
+		}
+	}
+
+	// Commands start once panes have their final size, so full-screen programs lay out correctly
+	for i, p := range layout.Panes {
+		if p.Command == "" {
+			continue
+		}
+		if err := tmux.RunInPane(ids[i], p.Command); err != nil {
+			return fmt.Errorf("failed to run %q: %w", p.Command, err)
+		}
+	}
+
+	return tmux.SelectPane(focus)
+}
+
+// paneDir resolves a pane's start directory against the worktree.
+func paneDir(workDir, dir string) string {
+	if dir == "" {
+		return workDir
+	}
+	dir = expandHome(dir)
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(workDir, dir)
+}
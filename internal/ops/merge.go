@@ -0,0 +1,79 @@
+package ops
+
+import (
+	"fmt"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/github"
+)
+
+// MergeOptions configures how a track's PR is merged.
+type MergeOptions struct {
+	Auto    bool   // Enable auto-merge instead of merging right away
+	Method  string // Overrides the configured merge method
+	Cleanup *bool  // Overrides the configured cleanup_after_merge; nil uses config
+}
+
+// MergeResult contains the result of a merge operation.
+type MergeResult struct {
+	Method    string
+	AutoMerge bool // True if auto-merge was enabled rather than merging now
+	CleanedUp bool // True if the track and remote branch were deleted
+}
+
+// MergeTrack merges a track's PR, or enables auto-merge on it.
+// When the PR is merged right away and cleanup is enabled, the track and its
+// remote branch are deleted afterwards.
+func (o *Ops) MergeTrack(branch string, opts MergeOptions) (_ *MergeResult, err error) {
+	defer func() { o.recordError(branch, "merge", err) }()
+
+	if err := o.requireGitHub("merging PRs"); err != nil {
+		return nil, err
+	}
+
+	remote := o.config.Repo.Remote
+
+	trk, err := o.getTrack(branch)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWritable(trk); err != nil {
+		return nil, err
+	}
+
+	method := opts.Method
+	if method == "" {
+		method = o.config.Repo.MergeMethod
+	}
+	if method == "" {
+		method = github.DefaultMergeMethod
+	}
+
+	result := &MergeResult{Method: method, AutoMerge: opts.Auto}
+
+	if opts.Auto {
+		if err := github.EnableAutoMerge(remote, branch, method); err != nil {
+			return nil, err
+		}
+		o.recordEvent(db.Event{Branch: branch, Kind: db.EventMerge, Detail: "enabled auto-merge (" + method + ")"})
+		return result, nil
+	}
+
+	if err := github.MergePR(remote, branch, method); err != nil {
+		return nil, err
+	}
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventMerge, Detail: "merged (" + method + ")", BeforeSHA: trk.HeadSHA})
+
+	cleanup := o.config.Repo.CleanupAfterMerge
+	if opts.Cleanup != nil {
+		cleanup = *opts.Cleanup
+	}
+	if cleanup {
+		if err := o.DeleteTrack(branch, DeleteOptions{Remote: true}); err != nil {
+			return result, fmt.Errorf("PR merged, but failed to clean up track: %w", err)
+		}
+		result.CleanedUp = true
+	}
+
+	return result, nil
+}
@@ -1,5 +1,5 @@
 // Package ops provides high-level orchestration operations for trak.
-// It coordinates between git, github, db, tmux, devbox, and config modules.
+// It coordinates between git, github, db, mux, devbox, and config modules.
 package ops
 
 import (
@@ -11,9 +11,11 @@ import (
 	"github.com/laurent/trak/internal/config"
 	"github.com/laurent/trak/internal/db"
 	"github.com/laurent/trak/internal/devbox"
+	"github.com/laurent/trak/internal/forge"
 	"github.com/laurent/trak/internal/git"
 	"github.com/laurent/trak/internal/github"
-	"github.com/laurent/trak/internal/tmux"
+	"github.com/laurent/trak/internal/issue"
+	"github.com/laurent/trak/internal/mux"
 	"github.com/laurent/trak/internal/track"
 )
 
@@ -21,12 +23,16 @@ import (
 type Ops struct {
 	db     *db.DB
 	config *config.Config
+	forge  forge.Forge
+	issues issue.Tracker
+	mux    mux.Multiplexer
 }
 
 // TrackWithStatus combines a track from the database with its live status.
 type TrackWithStatus struct {
 	Track  db.Track
 	Status track.TrackStatus
+	Err    error // Set when the PR status couldn't be fetched; Status has only the git state
 }
 
 // RemoteBranch represents a remote branch with metadata.
@@ -39,16 +45,85 @@ type RemoteBranch struct {
 }
 
 // New creates a new Ops instance with the given database and config.
+// It selects the repo's forge and multiplexer and points the github package at the repo's host.
 func New(database *db.DB, cfg *config.Config) *Ops {
+	host := resolveHost(cfg.Repo)
+	github.SetHost(githubHost(cfg.Repo, host))
+
+	f, err := forge.New(cfg.Repo.Forge, host)
+	if err != nil {
+		// Callers validate the forge kind up front, fall back to GitHub
+		fmt.Fprintf(os.Stderr, "warning: %v, using GitHub\n", err)
+		f = forge.GitHub{}
+	}
+
+	if _, ok := f.(forge.GitHub); ok && cfg.Repo.GitHubAPI == config.GitHubAPIREST {
+		client, err := github.NewClientFromEnv()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v, using gh\n", err)
+		} else {
+			f = forge.GitHub{Client: client}
+		}
+	}
+
+	tracker, err := issue.New(cfg.Issue.Tracker, cfg.Repo.Remote, cfg.Issue.JiraURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v, using GitHub Issues\n", err)
+		tracker = issue.NewGitHub(cfg.Repo.Remote)
+	}
+
+	m, err := mux.New(cfg.Mux.Backend, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v, using tmux\n", err)
+		m = mux.Tmux{}
+	}
+
 	return &Ops{
 		db:     database,
 		config: cfg,
+		forge:  f,
+		issues: tracker,
+		mux:    m,
 	}
 }
 
+// NewCached creates an Ops that only reads what trak has recorded: Prompt, TrackAt and
+// CachedTrackStatus. It skips New's git, gh and multiplexer setup, so that it is cheap
+// enough for every shell prompt; other methods must not be called on it.
+func NewCached(database *db.DB, cfg *config.Config) *Ops {
+	return &Ops{db: database, config: cfg}
+}
+
+// resolveHost returns the configured forge host, or infers it from the repo's origin URL.
+// Returns "" if neither is available.
+func resolveHost(repo config.RepoConfig) string {
+	if repo.Host != "" {
+		return repo.Host
+	}
+	if repo.Path == "" {
+		return ""
+	}
+	url, err := git.RemoteURL(repo.Path)
+	if err != nil {
+		return ""
+	}
+	return github.HostFromURL(url)
+}
+
+// githubHost returns the host to point gh at, "" for gh's default. An inferred host is only
+// used if gh is authenticated for it: SSH config aliases like "github-work" aren't GitHub hosts.
+func githubHost(repo config.RepoConfig, host string) string {
+	if repo.Host != "" || github.IsAuthenticatedHost(host) {
+		return host
+	}
+	return ""
+}
+
 // NewTrackWorktree creates a new worktree-based track for the given branch.
 // It creates the branch if it doesn't exist, adds a git worktree, and records it in the database.
-func (o *Ops) NewTrackWorktree(branch string) error {
+func (o *Ops) NewTrackWorktree(branch string) (err error) {
+	defer func() { o.recordError(branch, "create", err) }()
+
 	repoPath := o.config.Repo.Path
 	remote := o.config.Repo.Remote
 
@@ -85,25 +160,9 @@ func (o *Ops) NewTrackWorktree(branch string) error {
 		}
 	}
 
-	// Get the SHA for the worktree path generation
-	sha, err := git.GetBranchSHA(repoPath, branch)
+	worktreePath, sha, err := addWorktree(repoPath, branch)
 	if err != nil {
-		return fmt.Errorf("failed to get branch SHA: %w", err)
-	}
-
-	// Generate worktree path
-	slug := track.GenerateSlug(branch, sha)
-	worktreeBase := config.GetWorktreeBaseDir()
-	worktreePath := filepath.Join(worktreeBase, slug)
-
-	// Ensure worktree base directory exists
-	if err := os.MkdirAll(worktreeBase, 0755); err != nil {
-		return fmt.Errorf("failed to create worktree base directory: %w", err)
-	}
-
-	// Add the worktree
-	if err := git.WorktreeAdd(repoPath, worktreePath, branch); err != nil {
-		return fmt.Errorf("failed to add worktree: %w", err)
+		return err
 	}
 
 	// Record in database
@@ -124,12 +183,42 @@ func (o *Ops) NewTrackWorktree(branch string) error {
 		return fmt.Errorf("failed to record track in database: %w", err)
 	}
 
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventCreate, Detail: "worktree at " + worktreePath, AfterSHA: sha})
 	return nil
 }
 
+// addWorktree adds a worktree for an existing local branch under the worktree base directory.
+// Returns the worktree path and the branch's SHA.
+func addWorktree(repoPath, branch string) (worktreePath, sha string, err error) {
+	// Get the SHA for the worktree path generation
+	sha, err = git.GetBranchSHA(repoPath, branch)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get branch SHA: %w", err)
+	}
+
+	// Generate worktree path
+	slug := track.GenerateSlug(branch, sha)
+	worktreeBase := config.GetWorktreeBaseDir()
+	worktreePath = filepath.Join(worktreeBase, slug)
+
+	// Ensure worktree base directory exists
+	if err := os.MkdirAll(worktreeBase, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create worktree base directory: %w", err)
+	}
+
+	// Add the worktree
+	if err := git.WorktreeAdd(repoPath, worktreePath, branch); err != nil {
+		return "", "", fmt.Errorf("failed to add worktree: %w", err)
+	}
+
+	return worktreePath, sha, nil
+}
+
 // NewTrackDevbox creates a new devbox-based track for the given branch.
 // It creates a remote k8s dev environment and records it in the database.
-func (o *Ops) NewTrackDevbox(branch string) error {
+func (o *Ops) NewTrackDevbox(branch string) (err error) {
+	defer func() { o.recordError(branch, "create", err) }()
+
 	remote := o.config.Repo.Remote
 	repoPath := o.config.Repo.Path
 
@@ -146,8 +235,7 @@ func (o *Ops) NewTrackDevbox(branch string) error {
 	}
 
 	// Create the devbox
-	// Note: remote format for devbox might need to be full URL
-	repoURL := fmt.Sprintf("https://github.com/%s.git", remote)
+	repoURL := github.CloneURL(remote)
 	if err := devbox.Create(devboxName, repoURL, branch); err != nil {
 		return fmt.Errorf("failed to create devbox: %w", err)
 	}
@@ -181,11 +269,21 @@ func (o *Ops) NewTrackDevbox(branch string) error {
 		return fmt.Errorf("failed to record track in database: %w", err)
 	}
 
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventCreate, Detail: "devbox " + devboxName, AfterSHA: sha})
 	return nil
 }
 
-// DeleteTrack deletes a track (worktree or devbox) and optionally the remote branch.
-func (o *Ops) DeleteTrack(branch string, deleteRemote bool) error {
+// DeleteOptions configures what is deleted along with a track.
+type DeleteOptions struct {
+	Remote     bool // Also delete the remote branch
+	KeepWindow bool // Leave the track's tmux window open
+}
+
+// DeleteTrack deletes a track (worktree or devbox), closes its tmux window unless kept,
+// and optionally deletes the remote branch.
+func (o *Ops) DeleteTrack(branch string, opts DeleteOptions) (err error) {
+	defer func() { o.recordError(branch, "delete", err) }()
+
 	remote := o.config.Repo.Remote
 	repoPath := o.config.Repo.Path
 
@@ -198,6 +296,19 @@ func (o *Ops) DeleteTrack(branch string, deleteRemote bool) error {
 		return fmt.Errorf("track not found for branch: %s", branch)
 	}
 
+	// Journal the track so the delete can be undone
+	undoID, err := o.journalDelete(trk, opts.Remote && !trk.Review)
+	if err != nil {
+		return err
+	}
+	// Once the environment is gone, the journal is the only way to get the track back
+	removed := false
+	defer func() {
+		if err != nil && !removed {
+			o.discardJournal(undoID)
+		}
+	}()
+
 	// Delete the local environment based on type
 	switch trk.Type {
 	case db.TrackTypeWorktree:
@@ -208,6 +319,10 @@ func (o *Ops) DeleteTrack(branch string, deleteRemote bool) error {
 			// Prune stale worktree references
 			_ = git.WorktreePrune(repoPath)
 		}
+		if trk.Review {
+			// Review branches are local copies of someone else's PR head
+			_ = git.DeleteBranch(repoPath, branch)
+		}
 
 	case db.TrackTypeDevbox:
 		if trk.DevboxName != nil {
@@ -216,13 +331,26 @@ func (o *Ops) DeleteTrack(branch string, deleteRemote bool) error {
 			}
 		}
 	}
+	removed = true
 
-	// Optionally delete remote branch
-	if deleteRemote {
+	// The window's shells now point at a removed directory
+	if !opts.KeepWindow && !o.config.Tmux.OnDelete.Keep {
+		if err := o.closeTrackWindow(trk); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to close tmux window: %v\n", err)
+		}
+	} else if err := o.releaseTrackWindow(trk); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to untag tmux window: %v\n", err)
+	}
+
+	// Optionally delete remote branch (never for review tracks, the branch isn't ours)
+	remoteDeleted := false
+	if opts.Remote && !trk.Review {
 		if err := git.PushDelete(repoPath, branch); err != nil {
 			// Log but don't fail if remote delete fails
 			// The branch might not exist on remote
 			fmt.Fprintf(os.Stderr, "warning: failed to delete remote branch: %v\n", err)
+		} else {
+			remoteDeleted = true
 		}
 	}
 
@@ -230,23 +358,53 @@ func (o *Ops) DeleteTrack(branch string, deleteRemote bool) error {
 	if err := o.db.DeleteTrack(remote, branch); err != nil {
 		return fmt.Errorf("failed to remove track from database: %w", err)
 	}
+	// A track recreated later starts watching afresh
+	_ = o.db.DeleteSnapshot(remote, branch)
+	_ = o.db.DeleteStatus(remote, branch)
 
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventDelete, Detail: deleteDetail(trk, remoteDeleted), BeforeSHA: trk.HeadSHA})
 	return nil
 }
 
+// deleteDetail describes what was removed along with a track.
+func deleteDetail(trk *db.Track, remoteDeleted bool) string {
+	var detail string
+	switch {
+	case trk.Path != nil:
+		detail = "removed worktree " + *trk.Path
+	case trk.DevboxName != nil:
+		detail = "deleted devbox " + *trk.DevboxName
+	default:
+		detail = "removed track"
+	}
+	if remoteDeleted {
+		detail += " and remote branch"
+	}
+	return detail
+}
+
 // SyncResult contains the result of a sync operation.
 type SyncResult struct {
 	Rebased       bool
 	Pushed        bool
 	PRCreated     bool
 	PRNumber      int
+	PRError       error // Set if the PR couldn't be built or created
 	HasConflicts  bool
 	ConflictsPath string // Path to worktree with conflicts
 }
 
 // SyncTrack syncs a track: pulls default branch, rebases, pushes, creates PR if needed.
 // Returns a SyncResult and an error. If there are rebase conflicts, HasConflicts will be true.
+// The outcome is recorded in the track's history.
 func (o *Ops) SyncTrack(branch string) (*SyncResult, error) {
+	beforeSHA := o.trackHeadSHA(branch)
+	result, err := o.syncTrack(branch)
+	o.recordSync(branch, beforeSHA, result, err)
+	return result, err
+}
+
+func (o *Ops) syncTrack(branch string) (*SyncResult, error) {
 	remote := o.config.Repo.Remote
 
 	// Get the track from database
@@ -257,6 +415,9 @@ func (o *Ops) SyncTrack(branch string) (*SyncResult, error) {
 	if trk == nil {
 		return nil, fmt.Errorf("track not found for branch: %s", branch)
 	}
+	if err := checkWritable(trk); err != nil {
+		return nil, err
+	}
 
 	// Determine the working directory
 	var workDir string
@@ -283,6 +444,12 @@ func (o *Ops) SyncTrack(branch string) (*SyncResult, error) {
 		return nil, fmt.Errorf("failed to get default branch: %w", err)
 	}
 
+	// Remember the tip before the rebase so the sync can be undone
+	beforeSHA, err := git.GetHeadSHA(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head SHA: %w", err)
+	}
+
 	// Rebase onto default branch
 	conflicts, err := git.Rebase(workDir, "origin/"+defaultBranch)
 	if err != nil {
@@ -297,8 +464,12 @@ func (o *Ops) SyncTrack(branch string) (*SyncResult, error) {
 
 	result.Rebased = true
 
-	// Push to remote (force after rebase)
-	if err := git.PushForce(workDir, branch); err != nil {
+	// Push to remote (force after rebase). Tracks of fork PRs push to the fork.
+	// The rebase is journaled even if the push fails, it already rewrote the local branch.
+	if _, err := o.journalSync(trk, workDir, beforeSHA); err != nil {
+		return nil, err
+	}
+	if err := pushTrack(workDir, branch); err != nil {
 		return nil, fmt.Errorf("failed to push: %w", err)
 	}
 	result.Pushed = true
@@ -309,19 +480,25 @@ func (o *Ops) SyncTrack(branch string) (*SyncResult, error) {
 		_ = o.db.UpdateHeadSHA(remote, branch, newSHA)
 	}
 
-	// Check if PR exists, create if not
-	pr, err := github.GetPRForBranch(remote, branch)
+	// Check if PR exists, create if not. Fork PR tracks are looked up by number, their
+	// branch doesn't exist on origin.
+	pr, err := o.prForTrack(trk)
 	if err != nil {
 		// Non-fatal, just skip PR creation
 		return result, nil
 	}
 
 	if pr == nil {
-		// Create PR
-		title := branch // Use branch name as default title
-		prNum, err := github.CreatePR(remote, branch, defaultBranch, title)
+		// Create PR with title and body derived from config and commits
+		opts, err := o.buildPROptions(trk, workDir, defaultBranch)
+		if err != nil {
+			result.PRError = err
+			return result, nil
+		}
+		prNum, err := o.forge.CreatePR(remote, branch, defaultBranch, opts)
 		if err != nil {
-			// Non-fatal, just skip PR creation
+			// The push went through, so the sync itself succeeded
+			result.PRError = err
 			return result, nil
 		}
 		result.PRCreated = true
@@ -331,8 +508,10 @@ func (o *Ops) SyncTrack(branch string) (*SyncResult, error) {
 	return result, nil
 }
 
-// JumpToTrack switches to the tmux window for the given track, creating it if needed.
-func (o *Ops) JumpToTrack(branch string) error {
+// JumpToTrack switches to the window for the given track, creating it if needed.
+func (o *Ops) JumpToTrack(branch string) (err error) {
+	defer func() { o.recordError(branch, "jump", err) }()
+
 	remote := o.config.Repo.Remote
 
 	// Get the track from database
@@ -346,65 +525,14 @@ func (o *Ops) JumpToTrack(branch string) error {
 
 	// Update last accessed time
 	_ = o.db.UpdateLastAccessed(remote, branch)
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventJump})
 
-	// Determine tmux session name (use repo name or a default)
-	sessionName := "trak"
-
-	// Sanitize branch name for tmux
-	windowName := track.SanitizeForTmux(branch)
-
-	// Ensure session exists
-	sessionExists, err := tmux.SessionExists(sessionName)
+	w, err := o.ensureTrackWindow(trk)
 	if err != nil {
-		return fmt.Errorf("failed to check session: %w", err)
+		return err
 	}
 
-	if !sessionExists {
-		if err := tmux.CreateSession(sessionName); err != nil {
-			return fmt.Errorf("failed to create session: %w", err)
-		}
-	}
-
-	// Check if window exists
-	windowExists, err := tmux.WindowExists(sessionName, windowName)
-	if err != nil {
-		return fmt.Errorf("failed to check window: %w", err)
-	}
-
-	if !windowExists {
-		// Create window with appropriate start directory
-		var startDir string
-		switch trk.Type {
-		case db.TrackTypeWorktree:
-			if trk.Path != nil {
-				startDir = *trk.Path
-			}
-		case db.TrackTypeDevbox:
-			// For devbox, we'll create window and then run SSH command
-			startDir = ""
-		}
-
-		if err := tmux.CreateWindow(sessionName, windowName, startDir); err != nil {
-			return fmt.Errorf("failed to create window: %w", err)
-		}
-
-		// For devbox, send SSH command to the window
-		if trk.Type == db.TrackTypeDevbox && trk.DevboxName != nil {
-			sshCmd, err := devbox.GetSSHCommand(*trk.DevboxName)
-			if err == nil && sshCmd != "" {
-				_ = tmux.RunInWindow(sessionName, windowName, sshCmd)
-			}
-		}
-	}
-
-	// Switch to the window
-	if tmux.IsInsideTmux() {
-		return tmux.SwitchToWindow(sessionName, windowName)
-	} else {
-		// Select the window first, then attach
-		_ = tmux.SelectWindow(sessionName, windowName)
-		return tmux.AttachSession(sessionName)
-	}
+	return o.mux.Focus(w)
 }
 
 // RunAI runs the AI assistant (toad) in the context of the given track.
@@ -435,43 +563,24 @@ func (o *Ops) RunAI(branch string) error {
 	// Update last accessed time
 	_ = o.db.UpdateLastAccessed(remote, branch)
 
-	// Run tc (toad claude) command
-	cmd := fmt.Sprintf("tc %s", toadPath)
+	// Run the configured agent command (tc, toad claude, by default)
+	cmd := fmt.Sprintf("%s %s", o.config.AI.AgentCommand(), toadPath)
 
-	// Get tmux context
-	sessionName := "trak"
-	windowName := track.SanitizeForTmux(branch)
-
-	// Check if we need to create window
-	sessionExists, _ := tmux.SessionExists(sessionName)
-	if !sessionExists {
-		if err := tmux.CreateSession(sessionName); err != nil {
-			return fmt.Errorf("failed to create session: %w", err)
-		}
-	}
-
-	windowExists, _ := tmux.WindowExists(sessionName, windowName)
-	if !windowExists {
-		if err := tmux.CreateWindow(sessionName, windowName, toadPath); err != nil {
-			return fmt.Errorf("failed to create window: %w", err)
-		}
+	w, err := o.ensureTrackWindow(trk)
+	if err != nil {
+		return err
 	}
 
 	// Send the toad command to the window
-	if err := tmux.RunInWindow(sessionName, windowName, cmd); err != nil {
+	if err := o.mux.Run(w, cmd); err != nil {
 		return fmt.Errorf("failed to run AI command: %w", err)
 	}
 
-	// Switch to the window
-	if tmux.IsInsideTmux() {
-		return tmux.SwitchToWindow(sessionName, windowName)
-	} else {
-		_ = tmux.SelectWindow(sessionName, windowName)
-		return tmux.AttachSession(sessionName)
-	}
+	return o.mux.Focus(w)
 }
 
 // RefreshTrackStatus fetches the current status of a track from git and GitHub.
+// If the PR lookup fails, the git status is still returned along with the error.
 func (o *Ops) RefreshTrackStatus(trk db.Track) (track.TrackStatus, error) {
 	status := track.TrackStatus{}
 	repoPath := o.config.Repo.Path
@@ -523,11 +632,14 @@ func (o *Ops) RefreshTrackStatus(trk db.Track) (track.TrackStatus, error) {
 	}
 
 	// Get PR status from GitHub
-	pr, err := github.GetPRForBranch(remote, trk.Branch)
-	if err == nil && pr != nil {
+	pr, prErr := o.prForTrack(&trk)
+	if prErr != nil {
+		prErr = fmt.Errorf("failed to get PR status for %s: %w", trk.Branch, prErr)
+	}
+	if prErr == nil && pr != nil {
 		status.PR = &track.PRStatus{
 			Number: pr.Number,
-			URL:    fmt.Sprintf("https://github.com/%s/pull/%d", remote, pr.Number),
+			URL:    o.forge.PRURL(remote, pr.Number),
 			State:  pr.State,
 			Draft:  false, // gh CLI doesn't expose draft status in our current implementation
 		}
@@ -555,7 +667,12 @@ func (o *Ops) RefreshTrackStatus(trk db.Track) (track.TrackStatus, error) {
 		}
 	}
 
-	return status, nil
+	// A partial status would hide the PR from prompts until the next refresh
+	if prErr == nil {
+		o.saveTrackStatus(trk, status)
+	}
+
+	return status, prErr
 }
 
 // ListTracksWithStatus returns all tracks with their current status.
@@ -567,14 +684,12 @@ func (o *Ops) ListTracksWithStatus() ([]TrackWithStatus, error) {
 
 	result := make([]TrackWithStatus, 0, len(tracks))
 	for _, trk := range tracks {
+		// Keep the partial status on error, don't fail the whole list
 		status, err := o.RefreshTrackStatus(trk)
-		if err != nil {
-			// Use empty status on error, don't fail the whole list
-			status = track.TrackStatus{}
-		}
 		result = append(result, TrackWithStatus{
 			Track:  trk,
 			Status: status,
+			Err:    err,
 		})
 	}
 
@@ -586,25 +701,25 @@ func (o *Ops) ListRemoteBranches() ([]RemoteBranch, error) {
 	remote := o.config.Repo.Remote
 
 	// Get branches with open PRs
-	ghBranches, err := github.ListMyBranches(remote)
+	forgeBranches, err := o.forge.ListMyBranches(remote)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list remote branches: %w", err)
 	}
 
 	// Get all PRs for enrichment
-	prs, err := github.ListMyPRs(remote)
+	prs, err := o.forge.ListMyPRs(remote)
 	if err != nil {
-		prs = []github.PR{} // Non-fatal, continue without PR info
+		prs = []forge.PR{} // Non-fatal, continue without PR info
 	}
 
 	// Build a map of branch -> PR
-	prMap := make(map[string]github.PR)
+	prMap := make(map[string]forge.PR)
 	for _, pr := range prs {
 		prMap[pr.Branch] = pr
 	}
 
-	result := make([]RemoteBranch, 0, len(ghBranches))
-	for _, b := range ghBranches {
+	result := make([]RemoteBranch, 0, len(forgeBranches))
+	for _, b := range forgeBranches {
 		rb := RemoteBranch{
 			Name:       b.Name,
 			LastCommit: b.LastCommit,
@@ -619,5 +734,6 @@ func (o *Ops) ListRemoteBranches() ([]RemoteBranch, error) {
 		result = append(result, rb)
 	}
 
+	o.saveRemoteBranchCache(result)
 	return result, nil
 }
@@ -1,11 +1,31 @@
 package ops
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/laurent/trak/internal/config"
 	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/forge"
+	"github.com/laurent/trak/internal/git"
+	"github.com/laurent/trak/internal/github"
+	"github.com/laurent/trak/internal/issue"
+	"github.com/laurent/trak/internal/mux"
+	"github.com/laurent/trak/internal/tmux"
+	"github.com/laurent/trak/internal/track"
+	"github.com/laurent/trak/internal/zellij"
 )
 
 // testDB creates an in-memory database for testing.
@@ -28,6 +48,8 @@ func testConfig() *config.Config {
 			Path:   "/tmp/test-repo",
 			Remote: "testowner/testrepo",
 		},
+		// Tests fake tmux, whatever is installed
+		Mux: config.MuxConfig{Backend: mux.KindTmux},
 	}
 }
 
@@ -139,7 +161,7 @@ func TestDeleteTrackNotFound(t *testing.T) {
 	cfg := testConfig()
 	ops := New(database, cfg)
 
-	err := ops.DeleteTrack("nonexistent-branch", false)
+	err := ops.DeleteTrack("nonexistent-branch", DeleteOptions{})
 	if err == nil {
 		t.Error("expected error for non-existent track")
 	}
@@ -357,6 +379,7 @@ func TestRefreshTrackStatusDevbox(t *testing.T) {
 
 	cfg := testConfig()
 	ops := New(database, cfg)
+	ops.forge = &fakeForge{}
 
 	// Create a devbox track
 	devboxName := "test-devbox"
@@ -382,6 +405,75 @@ func TestRefreshTrackStatusDevbox(t *testing.T) {
 	}
 }
 
+func TestSyncTrackForkPR(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	worktreePath := filepath.Join(dir, "wt")
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=t@t", "-c", "user.name=t"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	run(dir, "init", "-q", "--bare", "-b", "main", "origin.git")
+	run(dir, "init", "-q", "--bare", "fork.git")
+	run(dir, "init", "-q", "-b", "main", repoPath)
+	run(repoPath, "commit", "-q", "--allow-empty", "-m", "initial")
+	run(repoPath, "remote", "add", "origin", filepath.Join(dir, "origin.git"))
+	run(repoPath, "remote", "add", "alice", filepath.Join(dir, "fork.git"))
+	run(repoPath, "push", "-q", "origin", "main")
+	run(repoPath, "fetch", "-q", "origin")
+	run(repoPath, "checkout", "-q", "-b", "alice/fix-typo")
+	run(repoPath, "commit", "-q", "--allow-empty", "-m", "fix typo")
+	run(repoPath, "push", "-q", "-u", "alice", "alice/fix-typo:fix-typo")
+	run(repoPath, "checkout", "-q", "main")
+	run(repoPath, "worktree", "add", "-q", worktreePath, "alice/fix-typo")
+
+	cfg := testConfig()
+	cfg.Repo.Path = repoPath
+	ops := New(database, cfg)
+	f := &fakeForge{prs: []forge.PR{{Number: 7, Branch: "fix-typo", State: "open"}}}
+	ops.forge = f
+
+	prNumber := 7
+	if err := database.InsertTrack(db.Track{
+		Branch:    "alice/fix-typo",
+		RemoteURL: cfg.Repo.Remote,
+		Type:      db.TrackTypeWorktree,
+		Path:      &worktreePath,
+		PRNumber:  &prNumber,
+	}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	result, err := ops.SyncTrack("alice/fix-typo")
+	if err != nil {
+		t.Fatalf("SyncTrack failed: %v", err)
+	}
+	if !result.Pushed || result.PRCreated || result.PRError != nil {
+		t.Errorf("expected a push to the fork and no new PR, got %+v", result)
+	}
+	if len(f.created) != 0 {
+		t.Errorf("expected no PR to be created, got %v", f.created)
+	}
+
+	// A PR that fails to be created is reported, the sync still succeeds
+	f.prs = nil
+	f.createErr = fmt.Errorf("unknown reviewer")
+	result, err = ops.SyncTrack("alice/fix-typo")
+	if err != nil {
+		t.Fatalf("SyncTrack failed: %v", err)
+	}
+	if result.PRCreated || result.PRError != f.createErr {
+		t.Errorf("expected the PR error to be reported, got %+v", result)
+	}
+}
+
 func TestSyncTrackWorktreeNoPath(t *testing.T) {
 	database := testDB(t)
 	defer database.Close()
@@ -443,3 +535,1784 @@ func TestRunAIWorktreeNoPath(t *testing.T) {
 		t.Errorf("unexpected error message: %v", err)
 	}
 }
+
+func TestSendCIExcerptToAINotFound(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	ops := New(database, testConfig())
+
+	if err := ops.SendCIExcerptToAI("nonexistent-branch", "/tmp/excerpt.log"); err == nil {
+		t.Error("expected error for non-existent track")
+	}
+}
+
+func TestIsShell(t *testing.T) {
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"zsh", true},
+		{"-bash", true},
+		{"/bin/fish", true},
+		{"node", false},
+		{"nvim", false},
+	}
+
+	for _, tt := range tests {
+		if got := isShell(tt.command); got != tt.want {
+			t.Errorf("isShell(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote("/tmp/it's here"); got != `'/tmp/it'\''s here'` {
+		t.Errorf("shellQuote() = %q", got)
+	}
+}
+
+func TestRenderPRTitle(t *testing.T) {
+	data := PRTemplateData{Branch: "feature/x", FirstCommit: "Add x", Commits: []string{"Add x"}}
+
+	tests := []struct {
+		name string
+		tmpl string
+		data PRTemplateData
+		want string
+	}{
+		{"first commit by default", "", data, "Add x"},
+		{"branch without commits", "", PRTemplateData{Branch: "feature/x"}, "feature/x"},
+		{"template", "[{{.Branch}}] {{.FirstCommit}}", data, "[feature/x] Add x"},
+		{"empty render falls back", "{{.FirstCommit}}", PRTemplateData{Branch: "feature/x"}, "feature/x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderPRTitle(tt.tmpl, tt.data)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("renderPRTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderPRBody(t *testing.T) {
+	data := PRTemplateData{Branch: "feature/x", Commits: []string{"One", "Two"}}
+	workDir := t.TempDir()
+
+	// Without any template, the commits are listed
+	body, err := renderPRBody("", "", workDir, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != "## Commits\n\n- One\n- Two\n" {
+		t.Errorf("unexpected default body: %q", body)
+	}
+
+	// The repo template is used verbatim when present
+	if err := os.MkdirAll(filepath.Join(workDir, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	repoTemplate := "## Summary\n\n{{ not a go template }}\n"
+	if err := os.WriteFile(filepath.Join(workDir, ".github", "pull_request_template.md"), []byte(repoTemplate), 0644); err != nil {
+		t.Fatal(err)
+	}
+	body, err = renderPRBody("", "", workDir, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != repoTemplate {
+		t.Errorf("expected repo template, got %q", body)
+	}
+
+	// A configured template takes precedence and is rendered
+	body, err = renderPRBody("{{.Branch}}:{{range .Commits}} {{.}}{{end}}", "", workDir, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != "feature/x: One Two" {
+		t.Errorf("unexpected rendered body: %q", body)
+	}
+
+	// Template files are rendered too
+	file := filepath.Join(t.TempDir(), "body.md")
+	if err := os.WriteFile(file, []byte("Branch {{.Branch}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	body, err = renderPRBody("", file, workDir, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body != "Branch feature/x" {
+		t.Errorf("unexpected body from file: %q", body)
+	}
+
+	if _, err := renderPRBody("{{.Nope", "", workDir, data); err == nil {
+		t.Error("expected error for invalid template")
+	}
+}
+
+func TestCreatePRForTrackNotFound(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	ops := New(database, testConfig())
+
+	if _, err := ops.CreatePRForTrack("nonexistent-branch", PRCreateOptions{}); err == nil {
+		t.Error("expected error for non-existent track")
+	}
+}
+
+func TestOpenThreadInEditorNotFound(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	ops := New(database, testConfig())
+
+	err := ops.OpenThreadInEditor("nonexistent-branch", github.ReviewThread{Path: "main.go", Line: 1})
+	if err == nil {
+		t.Error("expected error for non-existent track")
+	}
+}
+
+func TestMergeTrackNotFound(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	ops := New(database, testConfig())
+
+	if _, err := ops.MergeTrack("nonexistent-branch", MergeOptions{}); err == nil {
+		t.Error("expected error for non-existent track")
+	}
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("EDITOR", "")
+	if got := editorCommand("it's.go", 12); got != `vi +12 'it'\''s.go'` {
+		t.Errorf("editorCommand() = %q", got)
+	}
+	t.Setenv("EDITOR", "code -w")
+	if got := editorCommand("main.go", 0); got != "code -w 'main.go'" {
+		t.Errorf("editorCommand() = %q", got)
+	}
+}
+
+func TestGitHubOnlyFeatures(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	ops := New(database, testConfig())
+	ops.forge = forge.NewGitLab("https://gitlab.example.com", "")
+
+	checks := map[string]error{}
+	_, checks["GetCILogs"] = ops.GetCILogs("feature")
+	_, checks["RerunFailedCI"] = ops.RerunFailedCI("feature")
+	_, checks["MergeTrack"] = ops.MergeTrack("feature", MergeOptions{})
+	_, checks["ListReviewThreads"] = ops.ListReviewThreads("feature")
+	checks["ResolveReviewThread"] = ops.ResolveReviewThread("T_1")
+	_, checks["ListReviewRequests"] = ops.ListReviewRequests()
+	_, checks["NewReviewTrack"] = ops.NewReviewTrack(42)
+	_, checks["NewTrackFromPR"] = ops.NewTrackFromPR("42")
+	for name, err := range checks {
+		if err == nil || !strings.Contains(err.Error(), "not supported on gitlab") {
+			t.Errorf("%s: expected a not supported error, got %v", name, err)
+		}
+	}
+}
+
+func TestReviewBranch(t *testing.T) {
+	if got := ReviewBranch(42); got != "review/pr-42" {
+		t.Errorf("ReviewBranch(42) = %q, want %q", got, "review/pr-42")
+	}
+}
+
+func TestReviewTrackReadOnly(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	path := "/tmp/review"
+	prNumber := 42
+	now := time.Now()
+	err := database.InsertTrack(db.Track{
+		Branch:       "review/pr-42",
+		RemoteURL:    cfg.Repo.Remote,
+		HeadSHA:      "abc123",
+		Type:         db.TrackTypeWorktree,
+		Path:         &path,
+		CreatedAt:    now,
+		LastAccessed: &now,
+		PRNumber:     &prNumber,
+		Review:       true,
+	})
+	if err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	want := "review/pr-42 is a read-only review track"
+
+	if _, err := ops.SyncTrack("review/pr-42"); err == nil || err.Error() != want {
+		t.Errorf("SyncTrack: expected %q, got %v", want, err)
+	}
+	if _, err := ops.CreatePRForTrack("review/pr-42", PRCreateOptions{}); err == nil || err.Error() != want {
+		t.Errorf("CreatePRForTrack: expected %q, got %v", want, err)
+	}
+	if _, err := ops.MergeTrack("review/pr-42", MergeOptions{}); err == nil || err.Error() != want {
+		t.Errorf("MergeTrack: expected %q, got %v", want, err)
+	}
+}
+
+func TestCleanupReviewTracks(t *testing.T) {
+	fake := &fakeTmux{}
+	tmux.SetRunner(fake)
+	defer tmux.ResetRunner()
+
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	now := time.Now()
+	for _, n := range []int{1, 2, 3} {
+		prNumber := n
+		err := database.InsertTrack(db.Track{
+			Branch:    ReviewBranch(n),
+			RemoteURL: cfg.Repo.Remote,
+			HeadSHA:   "abc123",
+			Type:      db.TrackTypeWorktree,
+			CreatedAt: now,
+			PRNumber:  &prNumber,
+			Review:    true,
+		})
+		if err != nil {
+			t.Fatalf("failed to insert test track: %v", err)
+		}
+	}
+
+	tracks, err := database.ListTracks()
+	if err != nil {
+		t.Fatalf("failed to list tracks: %v", err)
+	}
+
+	// PR #3 was reviewed too, but its window has an editor open
+	pr3, _ := database.GetTrack(cfg.Repo.Remote, "review/pr-3")
+	fake.windows = map[string]*fakeWindow{"@1": {session: "testrepo", name: "review/pr-3", tag: trackTag(pr3), panes: []tmux.PaneInfo{
+		{ID: "%1", Command: "nvim"},
+	}}}
+
+	// Review of PR #1 is still requested, PR #2 was reviewed
+	deleted := ops.cleanupReviewTracks(tracks, []github.ReviewRequest{{Number: 1}})
+	if len(deleted) != 1 || deleted[0] != "review/pr-2" {
+		t.Errorf("expected only review/pr-2 to be deleted, got %v", deleted)
+	}
+
+	remaining, err := database.ListTracks()
+	if err != nil {
+		t.Fatalf("failed to list tracks: %v", err)
+	}
+	var branches []string
+	for _, trk := range remaining {
+		branches = append(branches, trk.Branch)
+	}
+	sort.Strings(branches)
+	if !reflect.DeepEqual(branches, []string{"review/pr-1", "review/pr-3"}) {
+		t.Errorf("expected review/pr-1 and review/pr-3 to remain, got %v", branches)
+	}
+	if _, ok := fake.windows["@1"]; !ok {
+		t.Error("expected the window with an editor to be left open")
+	}
+}
+
+func TestResolveHost(t *testing.T) {
+	// An explicit host wins over inference
+	if got := resolveHost(config.RepoConfig{Host: "ghe.example.com", Path: "/nonexistent"}); got != "ghe.example.com" {
+		t.Errorf("expected configured host, got %q", got)
+	}
+
+	// No git repo to infer from falls back to the default host
+	if got := resolveHost(config.RepoConfig{Path: t.TempDir()}); got != "" {
+		t.Errorf("expected empty host, got %q", got)
+	}
+
+	ghDir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", ghDir)
+	if err := os.WriteFile(filepath.Join(ghDir, "hosts.yml"), []byte("ghe.example.com:\n    user: alice\n"), 0o600); err != nil {
+		t.Fatalf("failed to write hosts.yml: %v", err)
+	}
+
+	tests := []struct {
+		origin string
+		host   string
+		gh     string
+	}{
+		{"git@ghe.example.com:owner/repo.git", "ghe.example.com", "ghe.example.com"},
+		// Other forges are detected from the host, but gh isn't pointed at them
+		{"git@gitlab.example.com:group/repo.git", "gitlab.example.com", ""},
+		// An SSH config alias isn't a host gh knows, keep gh's default
+		{"git@github-work:owner/repo.git", "github-work", ""},
+	}
+	for _, tt := range tests {
+		repo := t.TempDir()
+		for _, args := range [][]string{{"init", "-q"}, {"remote", "add", "origin", tt.origin}} {
+			if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+		cfg := config.RepoConfig{Path: repo}
+		host := resolveHost(cfg)
+		if host != tt.host {
+			t.Errorf("resolveHost() with origin %s = %q, want %q", tt.origin, host, tt.host)
+		}
+		if got := githubHost(cfg, host); got != tt.gh {
+			t.Errorf("githubHost() with origin %s = %q, want %q", tt.origin, got, tt.gh)
+		}
+	}
+
+	// A configured host is used as is
+	if got := githubHost(config.RepoConfig{Host: "github-work"}, "github-work"); got != "github-work" {
+		t.Errorf("expected configured host for gh, got %q", got)
+	}
+}
+
+// fakeForge is an in-memory Forge for testing.
+type fakeForge struct {
+	forge.GitHub
+	prs       []forge.PR
+	branches  []forge.RemoteBranch
+	err       error // Returned by PR lookups when set
+	created   []string
+	createErr error // Returned by CreatePR when set
+}
+
+func (f *fakeForge) CreatePR(remote, branch, baseBranch string, opts forge.PROptions) (int, error) {
+	if f.createErr != nil {
+		return 0, f.createErr
+	}
+	f.created = append(f.created, branch)
+	return 100 + len(f.created), nil
+}
+
+func (f *fakeForge) ListMyPRs(remote string) ([]forge.PR, error) {
+	return f.prs, nil
+}
+
+func (f *fakeForge) ListMyBranches(remote string) ([]forge.RemoteBranch, error) {
+	return f.branches, nil
+}
+
+func (f *fakeForge) GetPRForBranch(remote, branch string) (*forge.PR, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, pr := range f.prs {
+		if pr.Branch == branch {
+			return &pr, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeForge) GetPR(remote string, number int) (*forge.PR, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, pr := range f.prs {
+		if pr.Number == number {
+			return &pr, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestListRemoteBranchesUsesForge(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	database := testDB(t)
+	defer database.Close()
+
+	ops := New(database, testConfig())
+	ops.forge = &fakeForge{
+		prs: []forge.PR{{Number: 3, Branch: "feature-a"}},
+		branches: []forge.RemoteBranch{
+			{Name: "feature-a", LastCommit: "abc"},
+			{Name: "feature-b", LastCommit: "def"},
+		},
+	}
+
+	branches, err := ops.ListRemoteBranches()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(branches) != 2 {
+		t.Fatalf("expected 2 branches, got %d", len(branches))
+	}
+	if !branches[0].HasPR || branches[0].PRNumber != 3 {
+		t.Errorf("expected feature-a to have PR #3, got %+v", branches[0])
+	}
+	if branches[1].HasPR {
+		t.Errorf("expected feature-b to have no PR, got %+v", branches[1])
+	}
+}
+
+func TestRefreshTrackStatusReviewTrackUsesPRNumber(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	ops := New(database, testConfig())
+	ops.forge = &fakeForge{
+		prs: []forge.PR{{Number: 42, Branch: "contributor-branch", State: "open", CIStatus: "success"}},
+	}
+
+	prNumber := 42
+	status, err := ops.RefreshTrackStatus(db.Track{
+		Branch:   "review/pr-42",
+		Type:     db.TrackTypeDevbox,
+		PRNumber: &prNumber,
+		Review:   true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.PR == nil || status.PR.Number != 42 {
+		t.Fatalf("expected PR #42, got %+v", status.PR)
+	}
+	if status.CI == nil || !status.CI.Passing {
+		t.Errorf("expected passing CI, got %+v", status.CI)
+	}
+}
+
+func TestListTracksWithStatusKeepsPartialStatusOnError(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+	ops.forge = &fakeForge{err: github.ErrRateLimited}
+
+	now := time.Now().Add(-10 * 24 * time.Hour)
+	if err := database.InsertTrack(db.Track{
+		Branch:       "feature/limited",
+		RemoteURL:    cfg.Repo.Remote,
+		HeadSHA:      "abc123",
+		Type:         db.TrackTypeDevbox,
+		CreatedAt:    now,
+		LastAccessed: &now,
+	}); err != nil {
+		t.Fatalf("failed to insert track: %v", err)
+	}
+
+	tracks, err := ops.ListTracksWithStatus()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tracks) != 1 {
+		t.Fatalf("expected 1 track, got %d", len(tracks))
+	}
+	if tracks[0].Err == nil {
+		t.Error("expected the PR status error to be recorded")
+	}
+	if !tracks[0].Status.IsStale {
+		t.Error("expected the rest of the status to be kept")
+	}
+}
+
+func TestStatusWarning(t *testing.T) {
+	reset := time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)
+	limited := fmt.Errorf("failed to get PR status: %w", github.ErrRateLimited)
+
+	tests := []struct {
+		name   string
+		tracks []TrackWithStatus
+		want   string
+	}{
+		{"no errors", []TrackWithStatus{{}}, ""},
+		{"rate limited", []TrackWithStatus{{Err: limited}, {}}, "GitHub API rate limit exceeded, PR status unavailable until 15:04"},
+		{"single error", []TrackWithStatus{{Err: errors.New("boom")}}, "boom"},
+		{"several errors", []TrackWithStatus{{Err: errors.New("boom")}, {Err: errors.New("bang")}}, "PR status unavailable for 2 tracks: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StatusWarning(tt.tracks, github.RateLimit{Reset: reset})
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// fakeTracker is an in-memory issue tracker for testing.
+type fakeTracker struct {
+	issues map[string]issue.Issue
+}
+
+func (f *fakeTracker) Name() string { return "fake" }
+
+func (f *fakeTracker) GetIssue(ref string) (*issue.Issue, error) {
+	iss, ok := f.issues[ref]
+	if !ok {
+		return nil, fmt.Errorf("issue %s not found", ref)
+	}
+	return &iss, nil
+}
+
+func (f *fakeTracker) PRReference(ref string, close bool) string {
+	if close {
+		return "Fixes " + ref
+	}
+	return "See " + ref
+}
+
+func TestIssueBranch(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	cfg.Issue.BranchTemplate = "feature/{{key}}-{{slug}}"
+	ops := New(database, cfg)
+	ops.issues = &fakeTracker{issues: map[string]issue.Issue{
+		"PROJ-7": {Ref: "PROJ-7", Key: "PROJ-7", Title: "Add dark mode"},
+	}}
+
+	branch, iss, err := ops.IssueBranch("PROJ-7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if branch != "feature/PROJ-7-add-dark-mode" {
+		t.Errorf("unexpected branch: %s", branch)
+	}
+	if iss.Ref != "PROJ-7" {
+		t.Errorf("unexpected issue: %+v", iss)
+	}
+
+	if _, _, err := ops.IssueBranch("PROJ-8"); err == nil {
+		t.Error("expected error for unknown issue")
+	}
+}
+
+func TestLinkIssue(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+	if err := database.InsertTrack(db.Track{Branch: "feature/x", RemoteURL: cfg.Repo.Remote, HeadSHA: "abc", Type: db.TrackTypeWorktree}); err != nil {
+		t.Fatalf("failed to insert track: %v", err)
+	}
+
+	if err := ops.LinkIssue("feature/x", "#42"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	trk, _ := database.GetTrack(cfg.Repo.Remote, "feature/x")
+	if trk.IssueRef == nil || *trk.IssueRef != "#42" {
+		t.Errorf("expected issue ref #42, got %v", trk.IssueRef)
+	}
+}
+
+func TestIssueLine(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+	ops.issues = &fakeTracker{}
+
+	ref := "#42"
+	if got := ops.issueLine(&ref); got != "Fixes #42" {
+		t.Errorf("expected closing line, got %q", got)
+	}
+	cfg.Issue.MentionOnly = true
+	if got := ops.issueLine(&ref); got != "See #42" {
+		t.Errorf("expected mention, got %q", got)
+	}
+	if got := ops.issueLine(nil); got != "" {
+		t.Errorf("expected no line without an issue, got %q", got)
+	}
+}
+
+func TestAppendIssueLine(t *testing.T) {
+	data := PRTemplateData{Issue: "#42", IssueLine: "Closes #42"}
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty body", "", "Closes #42\n"},
+		{"appended", "## Commits\n\n- a\n", "## Commits\n\n- a\n\nCloses #42\n"},
+		{"already referenced", "Fixes #42 by doing things", "Fixes #42 by doing things"},
+		{"referenced at the end", "Fixes #42", "Fixes #42"},
+		{"longer number", "Follows up on #421", "Follows up on #421\n\nCloses #42\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appendIssueLine(tt.body, data); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	jira := PRTemplateData{Issue: "PROJ-1", IssueLine: "PROJ-1"}
+	if got := appendIssueLine("See XPROJ-1", jira); got != "See XPROJ-1\n\nPROJ-1\n" {
+		t.Errorf("expected the issue line after another key, got %q", got)
+	}
+
+	if got := appendIssueLine("body", PRTemplateData{}); got != "body" {
+		t.Errorf("expected body unchanged without an issue, got %q", got)
+	}
+}
+
+func TestPRTrackBranch(t *testing.T) {
+	if got := prTrackBranch(&github.PRHead{Branch: "feature", Owner: "owner"}); got != "feature" {
+		t.Errorf("expected 'feature', got %q", got)
+	}
+	if got := prTrackBranch(&github.PRHead{Branch: "main", Owner: "alice", IsFork: true}); got != "alice/main" {
+		t.Errorf("expected 'alice/main', got %q", got)
+	}
+}
+
+func TestParsePRRefForRemote(t *testing.T) {
+	if n, err := parsePRRefForRemote("#12", "owner/repo"); err != nil || n != 12 {
+		t.Errorf("expected 12, got %d, %v", n, err)
+	}
+	if n, err := parsePRRefForRemote("https://github.com/Owner/Repo/pull/34", "owner/repo"); err != nil || n != 34 {
+		t.Errorf("expected 34, got %d, %v", n, err)
+	}
+	if _, err := parsePRRefForRemote("https://github.com/other/repo/pull/34", "owner/repo"); err == nil {
+		t.Error("expected error for a PR in another repository")
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	open := Snapshot{PRNumber: 5, PRState: "open", CI: "pending", Review: "pending", Comments: 1}
+
+	tests := []struct {
+		name string
+		prev Snapshot
+		cur  Snapshot
+		want []string
+	}{
+		{"unchanged", open, open, nil},
+		{"no PR", Snapshot{}, Snapshot{Comments: -1}, nil},
+		{"PR opened", Snapshot{Comments: -1}, open, []string{TransitionPROpened}},
+		{"CI passed and approved", open, Snapshot{PRNumber: 5, PRState: "open", CI: "success", Review: "approved", Comments: 1}, []string{TransitionCIPassed, TransitionApproved}},
+		{"CI failed", open, Snapshot{PRNumber: 5, PRState: "open", CI: "failure", Review: "pending", Comments: 1}, []string{TransitionCIFailed}},
+		{"changes requested with comments", open, Snapshot{PRNumber: 5, PRState: "open", CI: "pending", Review: "changes_requested", Comments: 4}, []string{TransitionChangesRequested, TransitionNewComments}},
+		{"merged", open, Snapshot{PRNumber: 5, PRState: "merged", CI: "pending", Review: "pending", Comments: -1}, []string{TransitionMerged}},
+		{"comments unknown before", Snapshot{PRNumber: 5, PRState: "open", Comments: -1}, Snapshot{PRNumber: 5, PRState: "open", Comments: 3}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffSnapshots("feature", "https://example.com/pull/5", tt.prev, tt.cur)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %+v", tt.want, got)
+			}
+			for i, kind := range tt.want {
+				if got[i].Kind != kind {
+					t.Errorf("transition %d: expected %s, got %s", i, kind, got[i].Kind)
+				}
+				if got[i].Branch != "feature" || got[i].URL == "" {
+					t.Errorf("transition %d missing branch or URL: %+v", i, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPollTransitions(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+	f := &fakeForge{prs: []forge.PR{{Number: 9, Branch: "feature/watch", State: "open", CIStatus: "pending", ReviewStatus: "pending"}}}
+	ops.forge = f
+
+	now := time.Now()
+	if err := database.InsertTrack(db.Track{
+		Branch:       "feature/watch",
+		RemoteURL:    cfg.Repo.Remote,
+		HeadSHA:      "abc",
+		Type:         db.TrackTypeDevbox,
+		CreatedAt:    now,
+		LastAccessed: &now,
+	}); err != nil {
+		t.Fatalf("failed to insert track: %v", err)
+	}
+
+	// The first poll only records the snapshot
+	transitions, err := ops.PollTransitions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transitions) != 0 {
+		t.Errorf("expected no transitions on first poll, got %+v", transitions)
+	}
+
+	f.prs[0].CIStatus = "success"
+	transitions, err = ops.PollTransitions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transitions) != 1 || transitions[0].Kind != TransitionCIPassed {
+		t.Fatalf("expected CI passed, got %+v", transitions)
+	}
+
+	// Nothing changed since
+	transitions, _ = ops.PollTransitions()
+	if len(transitions) != 0 {
+		t.Errorf("expected no transitions, got %+v", transitions)
+	}
+}
+
+func TestSyncTrackRecordsHistory(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	devboxName := "test-devbox"
+	if err := database.InsertTrack(db.Track{
+		Branch:     "feature/devbox",
+		RemoteURL:  cfg.Repo.Remote,
+		HeadSHA:    "abc123",
+		Type:       db.TrackTypeDevbox,
+		DevboxName: &devboxName,
+	}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	if _, err := ops.SyncTrack("feature/devbox"); err == nil {
+		t.Fatal("expected error for devbox track sync")
+	}
+
+	events, err := ops.ListEvents("feature/devbox", 0)
+	if err != nil {
+		t.Fatalf("failed to list events: %v", err)
+	}
+	if len(events) != 1 || events[0].Kind != db.EventError {
+		t.Fatalf("expected one error event, got %+v", events)
+	}
+	if !strings.HasPrefix(events[0].Detail, "sync: sync is not supported") {
+		t.Errorf("unexpected detail: %q", events[0].Detail)
+	}
+}
+
+func TestRecordSync(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+	if err := database.InsertTrack(db.Track{
+		Branch:    "feature",
+		RemoteURL: cfg.Repo.Remote,
+		HeadSHA:   "new",
+		Type:      db.TrackTypeDevbox,
+	}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	ops.recordSync("feature", "old", &SyncResult{Rebased: true, Pushed: true, PRCreated: true, PRNumber: 7}, nil)
+	ops.recordSync("feature", "new", &SyncResult{HasConflicts: true, ConflictsPath: "/tmp/wt"}, nil)
+
+	events, _ := ops.ListEvents("feature", 0)
+	var kinds []db.EventKind
+	for _, e := range events {
+		kinds = append(kinds, e.Kind)
+	}
+	want := []db.EventKind{db.EventConflict, db.EventPRCreate, db.EventPush, db.EventSync}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("expected events %v, got %v", want, kinds)
+	}
+
+	sync := events[3]
+	if sync.BeforeSHA != "old" || sync.AfterSHA != "new" || sync.Detail != "rebased" {
+		t.Errorf("unexpected sync event: %+v", sync)
+	}
+	if events[1].Detail != "created PR #7" {
+		t.Errorf("unexpected PR event detail: %q", events[1].Detail)
+	}
+	if !strings.Contains(events[0].Detail, "/tmp/wt") {
+		t.Errorf("expected conflict event to name the worktree, got %q", events[0].Detail)
+	}
+}
+
+func TestDeleteDetail(t *testing.T) {
+	path := "/tmp/wt"
+	name := "box"
+	tests := []struct {
+		trk    db.Track
+		remote bool
+		want   string
+	}{
+		{db.Track{Path: &path}, false, "removed worktree /tmp/wt"},
+		{db.Track{Path: &path}, true, "removed worktree /tmp/wt and remote branch"},
+		{db.Track{DevboxName: &name}, false, "deleted devbox box"},
+	}
+	for _, tt := range tests {
+		if got := deleteDetail(&tt.trk, tt.remote); got != tt.want {
+			t.Errorf("deleteDetail() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestEventSHA(t *testing.T) {
+	tests := []struct {
+		event db.Event
+		want  string
+	}{
+		{db.Event{BeforeSHA: "aaaaaaaaaa", AfterSHA: "bbbbbbbbbb"}, "aaaaaaa→bbbbbbb"},
+		{db.Event{BeforeSHA: "aaaaaaaaaa", AfterSHA: "aaaaaaaaaa"}, "aaaaaaa"},
+		{db.Event{BeforeSHA: "abc"}, "abc"},
+		{db.Event{}, "—"},
+	}
+	for _, tt := range tests {
+		if got := EventSHA(tt.event); got != tt.want {
+			t.Errorf("EventSHA(%+v) = %q, want %q", tt.event, got, tt.want)
+		}
+	}
+}
+
+// gitRepoWithWorktree creates a repository with a "feature" branch checked out in a
+// worktree, and a config pointing at the repository.
+func gitRepoWithWorktree(t *testing.T) (cfg *config.Config, worktreePath string) {
+	t.Helper()
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	worktreePath = filepath.Join(dir, "wt")
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	run(dir, "init", "-q", "-b", "main", repoPath)
+	run(repoPath, "-c", "user.email=t@t", "-c", "user.name=t", "commit", "-q", "--allow-empty", "-m", "initial")
+	run(repoPath, "worktree", "add", "-q", "-b", "feature", worktreePath)
+
+	cfg = testConfig()
+	cfg.Repo.Path = repoPath
+	return cfg, worktreePath
+}
+
+func TestUndoDelete(t *testing.T) {
+	tmux.SetRunner(&fakeTmux{})
+	defer tmux.ResetRunner()
+
+	database := testDB(t)
+	defer database.Close()
+
+	cfg, worktreePath := gitRepoWithWorktree(t)
+	ops := New(database, cfg)
+	sha, _ := git.GetHeadSHA(worktreePath)
+	if err := database.InsertTrack(db.Track{
+		Branch:    "feature",
+		RemoteURL: cfg.Repo.Remote,
+		HeadSHA:   sha,
+		Type:      db.TrackTypeWorktree,
+		Path:      &worktreePath,
+	}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	if err := ops.DeleteTrack("feature", DeleteOptions{}); err != nil {
+		t.Fatalf("DeleteTrack failed: %v", err)
+	}
+	if _, err := os.Stat(worktreePath); !os.IsNotExist(err) {
+		t.Fatal("expected worktree to be removed")
+	}
+
+	entries, err := ops.ListUndoable("")
+	if err != nil {
+		t.Fatalf("ListUndoable failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Operation != db.UndoDelete || entries[0].LocalSHA != sha {
+		t.Fatalf("expected one delete entry at %s, got %+v", sha, entries)
+	}
+	if entries[0].RemoteBranch != "" {
+		t.Errorf("expected no remote branch to restore, got %q", entries[0].RemoteBranch)
+	}
+
+	if err := ops.Undo(entries[0]); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if _, err := os.Stat(worktreePath); err != nil {
+		t.Errorf("expected worktree to be recreated: %v", err)
+	}
+	trk, err := database.GetTrack(cfg.Repo.Remote, "feature")
+	if err != nil || trk.HeadSHA != sha {
+		t.Errorf("expected track to be restored at %s, got %+v, %v", sha, trk, err)
+	}
+
+	entries, _ = ops.ListUndoable("")
+	if len(entries) != 0 {
+		t.Errorf("expected nothing left to undo, got %+v", entries)
+	}
+	events, _ := ops.ListEvents("feature", 1)
+	if len(events) != 1 || events[0].Kind != db.EventUndo {
+		t.Errorf("expected an undo event, got %+v", events)
+	}
+}
+
+func TestUndoSync(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg, worktreePath := gitRepoWithWorktree(t)
+	ops := New(database, cfg)
+	before, _ := git.GetHeadSHA(worktreePath)
+	trk := db.Track{
+		Branch:    "feature",
+		RemoteURL: cfg.Repo.Remote,
+		HeadSHA:   before,
+		Type:      db.TrackTypeWorktree,
+		Path:      &worktreePath,
+	}
+	if err := database.InsertTrack(trk); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	// Stand in for the rebase of a sync
+	if _, err := ops.journalSync(&trk, worktreePath, before); err != nil {
+		t.Fatalf("journalSync failed: %v", err)
+	}
+	cmd := exec.Command("git", "-c", "user.email=t@t", "-c", "user.name=t", "commit", "-q", "--allow-empty", "-m", "rebased")
+	cmd.Dir = worktreePath
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	entries, _ := ops.ListUndoable("feature")
+	if len(entries) != 1 || entries[0].Operation != db.UndoSync {
+		t.Fatalf("expected one sync entry, got %+v", entries)
+	}
+	if err := ops.Undo(entries[0]); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+
+	head, _ := git.GetHeadSHA(worktreePath)
+	if head != before {
+		t.Errorf("expected worktree to be reset to %s, got %s", before, head)
+	}
+}
+
+func TestJournalPinsRemoteTip(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg, worktreePath := gitRepoWithWorktree(t)
+	ops := New(database, cfg)
+	local, _ := git.GetHeadSHA(worktreePath)
+	cmd := exec.Command("git", "-c", "user.email=t@t", "-c", "user.name=t", "commit", "-q", "--allow-empty", "-m", "pushed")
+	cmd.Dir = worktreePath
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	remote, _ := git.GetHeadSHA(worktreePath)
+
+	id, err := ops.journal(db.UndoEntry{Branch: "feature", Operation: db.UndoDelete, LocalSHA: local, RemoteName: "origin", RemoteBranch: "feature", RemoteSHA: remote})
+	if err != nil {
+		t.Fatalf("journal failed: %v", err)
+	}
+	for side, want := range map[string]string{"local": local, "remote": remote} {
+		if got, err := git.GetBranchSHA(cfg.Repo.Path, undoRef(id, side)); err != nil || got != want {
+			t.Errorf("%s pin = %q, %v, want %s", side, got, err, want)
+		}
+	}
+
+	ops.discardJournal(id)
+	for _, side := range []string{"local", "remote"} {
+		if _, err := git.GetBranchSHA(cfg.Repo.Path, undoRef(id, side)); err == nil {
+			t.Errorf("expected the %s pin to be deleted", side)
+		}
+	}
+}
+
+// fakeTmux records tmux commands and keeps track of windows, their sessions and tags.
+// New windows get increasing IDs starting at @1, new panes at %1.
+type fakeTmux struct {
+	calls   [][]string
+	created int
+	panes   int
+	windows map[string]*fakeWindow // by window ID
+}
+
+type fakeWindow struct {
+	session, name, tag string
+	panes              []tmux.PaneInfo
+	output             map[string]string // pane ID -> captured text
+}
+
+// fakeStubborn is a command that ignores C-c in fakeTmux panes.
+const fakeStubborn = "nvim"
+
+// find returns the ID of the window a target ("@ID" or "=session:=name") refers to.
+func (f *fakeTmux) find(target string) (string, bool) {
+	if _, ok := f.windows[target]; ok {
+		return target, true
+	}
+	for id, w := range f.windows {
+		if target == tmux.WindowTarget(w.session, id) || target == tmux.WindowTarget(w.session, w.name) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+func (f *fakeTmux) Run(name string, args ...string) (string, error) {
+	f.calls = append(f.calls, args)
+	if f.windows == nil {
+		f.windows = make(map[string]*fakeWindow)
+	}
+	switch args[0] {
+	case "has-session":
+		for _, w := range f.windows {
+			if tmux.SessionTarget(w.session) == args[2] {
+				return "", nil
+			}
+		}
+		return "", fmt.Errorf("can't find session: %s", args[2])
+	case "new-window", "new-session":
+		f.created++
+		id := fmt.Sprintf("@%d", f.created)
+		session := strings.TrimSuffix(strings.TrimPrefix(args[2], "="), ":")
+		if args[0] == "new-session" {
+			session = args[3]
+		}
+		f.windows[id] = &fakeWindow{session: session, name: args[slices.Index(args, "-n")+1]}
+		return id, nil
+	case "split-window":
+		f.panes++
+		return fmt.Sprintf("%%%d", f.panes), nil
+	case "display-message":
+		id, ok := f.find(args[3])
+		if !ok {
+			return "", fmt.Errorf("can't find window: %s", args[3])
+		}
+		w := f.windows[id]
+		switch args[4] {
+		case "#{pane_id}":
+			return "%0", nil
+		case "#{window_id}":
+			return id, nil
+		}
+		return fmt.Sprintf("%s\t%s\t%s\t%s", id, w.session, w.name, w.tag), nil
+	case "set-option":
+		if args[2] == "-u" {
+			if id, ok := f.find(args[4]); ok {
+				f.windows[id].tag = ""
+			}
+		} else if id, ok := f.find(args[3]); ok {
+			f.windows[id].tag = args[5]
+		}
+	case "list-panes":
+		id, ok := f.find(args[2])
+		if !ok {
+			return "", fmt.Errorf("can't find window: %s", args[2])
+		}
+		var lines []string
+		for _, p := range f.windows[id].panes {
+			lines = append(lines, fmt.Sprintf("%s\t%d\t%s", p.ID, p.Index, p.Command))
+		}
+		return strings.Join(lines, "\n"), nil
+	case "capture-pane":
+		for _, w := range f.windows {
+			if out, ok := w.output[args[4]]; ok {
+				return out, nil
+			}
+		}
+		return "", fmt.Errorf("can't find pane: %s", args[4])
+	case "send-keys":
+		if args[3] != "C-c" {
+			break
+		}
+		for _, w := range f.windows {
+			for i, p := range w.panes {
+				if p.ID == args[2] && p.Command != fakeStubborn {
+					w.panes[i].Command = "zsh"
+				}
+			}
+		}
+	case "kill-window":
+		if id, ok := f.find(args[2]); ok {
+			delete(f.windows, id)
+		}
+	case "list-windows":
+		var lines []string
+		for id, w := range f.windows {
+			if args[1] == "-a" {
+				lines = append(lines, fmt.Sprintf("%s\t%s\t%s\t%s", id, w.session, w.name, w.tag))
+			} else if tmux.SessionTarget(w.session) == args[2] {
+				lines = append(lines, w.name)
+			}
+		}
+		sort.Strings(lines)
+		return strings.Join(lines, "\n"), nil
+	}
+	return "", nil
+}
+
+func (f *fakeTmux) Exec(name string, args ...string) error {
+	f.calls = append(f.calls, args)
+	return nil
+}
+
+func TestCreateTrackWindowAppliesLayout(t *testing.T) {
+	fake := &fakeTmux{}
+	tmux.SetRunner(fake)
+	defer tmux.ResetRunner()
+
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	cfg.Tmux = config.TmuxConfig{
+		Layout: "dev",
+		Layouts: map[string]config.Layout{
+			"dev": {
+				Select: "main-vertical",
+				Panes: []config.Pane{
+					{Name: "editor", Dir: "src", Command: "nvim"},
+					{Name: "shell", Split: config.SplitRight, Size: "40%", Focus: true},
+					{Name: "tests", From: "editor", Dir: "/abs", Command: "make watch"},
+				},
+			},
+		},
+		Tracks: map[string]string{"review/*": config.NoLayout},
+	}
+	ops := New(database, cfg)
+
+	path := "/wt"
+	trk := &db.Track{ID: 7, Branch: "feature", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}
+	w, err := ops.ensureTrackWindow(trk)
+	if err != nil {
+		t.Fatalf("ensureTrackWindow failed: %v", err)
+	}
+	if w.ID != "@1" {
+		t.Errorf("expected window @1, got %q", w.ID)
+	}
+
+	want := [][]string{
+		{"list-windows", "-a", "-F", "#{window_id}\t#{session_name}\t#{window_name}\t#{@trak_track_id}"},
+		{"has-session", "-t", "=testrepo"},
+		{"new-session", "-d", "-s", "testrepo", "-n", "feature", "-P", "-F", "#{window_id}", "-c", "/wt/src"},
+		{"set-option", "-w", "-t", "@1", "@trak_track_id", "testowner/testrepo:feature"},
+		{"display-message", "-p", "-t", "=testrepo:@1", "#{pane_id}"},
+		{"split-window", "-t", "%0", "-P", "-F", "#{pane_id}", "-h", "-l", "40%", "-c", "/wt"},
+		{"split-window", "-t", "%0", "-P", "-F", "#{pane_id}", "-v", "-c", "/abs"},
+		{"select-layout", "-t", "=testrepo:@1", "main-vertical"},
+		{"send-keys", "-t", "%0", "nvim", "Enter"},
+		{"send-keys", "-t", "%2", "make watch", "Enter"},
+		{"select-pane", "-t", "%1"},
+	}
+	if !reflect.DeepEqual(fake.calls, want) {
+		t.Errorf("unexpected tmux calls:\ngot  %v\nwant %v", fake.calls, want)
+	}
+
+	// Overridden tracks get a plain window
+	fake.calls = nil
+	review := &db.Track{ID: 8, Branch: "review/pr-1", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}
+	if _, err := ops.ensureTrackWindow(review); err != nil {
+		t.Fatalf("ensureTrackWindow failed: %v", err)
+	}
+	if len(fake.calls) != 4 || fake.calls[2][0] != "new-window" || fake.calls[3][0] != "set-option" {
+		t.Errorf("expected a tagged plain window, got %v", fake.calls)
+	}
+}
+
+func TestEnsureTrackWindowFindsWindowByTag(t *testing.T) {
+	fake := &fakeTmux{}
+	tmux.SetRunner(fake)
+	defer tmux.ResetRunner()
+
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	path := "/wt"
+	if err := database.InsertTrack(db.Track{Branch: "feature", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+	trk, _ := database.GetTrack(cfg.Repo.Remote, "feature")
+
+	w, err := ops.ensureTrackWindow(trk)
+	if err != nil {
+		t.Fatalf("ensureTrackWindow failed: %v", err)
+	}
+	if w.Session != "testrepo" {
+		t.Errorf("expected the window in the repo's session, got %q", w.Session)
+	}
+	windowID := w.ID
+	stored, _ := database.GetTrack(cfg.Repo.Remote, "feature")
+	if stored.TmuxWindow == nil || *stored.TmuxWindow != windowID {
+		t.Fatalf("expected window %s to be recorded, got %v", windowID, stored.TmuxWindow)
+	}
+
+	// The recorded window is reused, whatever its name
+	fake.windows[windowID].name = "zsh"
+	again, _ := ops.ensureTrackWindow(stored)
+	if again.ID != windowID || fake.created != 1 {
+		t.Errorf("expected window %s to be reused, got %s after %d windows", windowID, again.ID, fake.created)
+	}
+
+	// After a tmux restart the recorded ID may belong to another window, and the
+	// track's window may be in a session named by an earlier template
+	fake.windows = map[string]*fakeWindow{
+		windowID: {session: "testrepo", name: "zsh"},
+		"@9":     {session: "trak", name: "feature", tag: trackTag(stored)},
+	}
+	found, _ := ops.ensureTrackWindow(stored)
+	if found.Session != "trak" || found.ID != "@9" {
+		t.Errorf("expected the tagged window trak:@9, got %s:%s", found.Session, found.ID)
+	}
+	stored, _ = database.GetTrack(cfg.Repo.Remote, "feature")
+	if stored.TmuxWindow == nil || *stored.TmuxWindow != "@9" {
+		t.Errorf("expected recorded window to be updated to @9, got %v", stored.TmuxWindow)
+	}
+}
+
+func TestDoctor(t *testing.T) {
+	fake := &fakeTmux{}
+	tmux.SetRunner(fake)
+	defer tmux.ResetRunner()
+
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	dir := t.TempDir()
+	for _, branch := range []string{"linked", "stale", "legacy", "gone"} {
+		path := filepath.Join(dir, branch)
+		if branch != "gone" {
+			if err := os.Mkdir(path, 0o755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := database.InsertTrack(db.Track{Branch: branch, RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+			t.Fatalf("failed to insert test track: %v", err)
+		}
+	}
+	linked, _ := database.GetTrack(cfg.Repo.Remote, "linked")
+	stale, _ := database.GetTrack(cfg.Repo.Remote, "stale")
+
+	_ = database.SetTmuxWindow(cfg.Repo.Remote, "linked", "@1")
+	_ = database.SetTmuxWindow(cfg.Repo.Remote, "stale", "@5")
+	fake.windows = map[string]*fakeWindow{
+		"@1": {session: "testrepo", name: "linked", tag: trackTag(linked)},
+		"@2": {session: "testrepo", name: "stale", tag: trackTag(stale)},
+		"@3": {session: "trak", name: "legacy"},
+		"@4": {session: "trak", name: "old", tag: "999"},
+	}
+
+	issues, err := ops.Doctor(false)
+	if err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	var problems []string
+	for _, issue := range issues {
+		problems = append(problems, issue.Branch+": "+issue.Problem)
+		if issue.Fixed {
+			t.Errorf("expected nothing fixed without fix, got %+v", issue)
+		}
+	}
+	want := []string{
+		"gone: worktree " + filepath.Join(dir, "gone") + " does not exist",
+		"legacy: window trak:legacy is not tagged with the track",
+		"stale: recorded window @5 is not the track's window @2",
+		": window @4 (trak:old) is tagged with unknown track 999",
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("unexpected issues:\ngot  %q\nwant %q", problems, want)
+	}
+
+	if _, err := ops.Doctor(true); err != nil {
+		t.Fatalf("Doctor failed: %v", err)
+	}
+	stale, _ = database.GetTrack(cfg.Repo.Remote, "stale")
+	if stale.TmuxWindow == nil || *stale.TmuxWindow != "@2" {
+		t.Errorf("expected stale window to be relinked to @2, got %v", stale.TmuxWindow)
+	}
+	legacy, _ := database.GetTrack(cfg.Repo.Remote, "legacy")
+	if legacy.TmuxWindow == nil || *legacy.TmuxWindow != "@3" || fake.windows["@3"].tag != trackTag(legacy) {
+		t.Errorf("expected legacy window @3 to be adopted, got %v tagged %q", legacy.TmuxWindow, fake.windows["@3"].tag)
+	}
+	if _, ok := fake.windows["@4"]; !ok {
+		t.Error("expected orphan window to be left alone")
+	}
+}
+
+func TestCloseTrackWindow(t *testing.T) {
+	fake := &fakeTmux{}
+	tmux.SetRunner(fake)
+	defer tmux.ResetRunner()
+	t.Setenv("TMUX_PANE", "")
+
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	cfg.Tmux.OnDelete.Grace = "50ms"
+	ops := New(database, cfg)
+
+	path := "/wt"
+	if err := database.InsertTrack(db.Track{Branch: "feature", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+	trk, _ := database.GetTrack(cfg.Repo.Remote, "feature")
+	newWindow := func() {
+		fake.windows = map[string]*fakeWindow{"@1": {session: "testrepo", name: "feature", tag: trackTag(trk), panes: []tmux.PaneInfo{
+			{ID: "%1", Command: "nvim"},
+			{ID: "%2", Command: "make"},
+			{ID: "%3", Command: "zsh"},
+		}}}
+	}
+
+	newWindow()
+	tests := []struct {
+		warn string
+		want int
+	}{
+		{config.WarnEditors, 1},
+		{config.WarnRunning, 2},
+		{config.WarnNever, 0},
+	}
+	for _, tt := range tests {
+		ops.config.Tmux.OnDelete.Warn = tt.warn
+		warnings, err := ops.WindowWarnings("feature")
+		if err != nil || len(warnings) != tt.want {
+			t.Errorf("WindowWarnings() with warn %q = %v, %v, want %d warnings", tt.warn, warnings, err, tt.want)
+		}
+	}
+
+	// trak doesn't kill the window it runs in
+	t.Setenv("TMUX_PANE", "%3")
+	if err := ops.closeTrackWindow(trk); err == nil {
+		t.Error("expected an error when trak runs in the window")
+	}
+	if w, ok := fake.windows["@1"]; !ok || w.panes[1].Command != "make" {
+		t.Error("expected the window trak runs in to be left alone")
+	}
+	t.Setenv("TMUX_PANE", "")
+
+	fake.calls = nil
+	if err := ops.closeTrackWindow(trk); err != nil {
+		t.Fatalf("closeTrackWindow failed: %v", err)
+	}
+	if _, ok := fake.windows["@1"]; ok {
+		t.Error("expected the window to be killed")
+	}
+	var interrupted []string
+	for _, call := range fake.calls {
+		if call[0] == "send-keys" {
+			interrupted = append(interrupted, call[2])
+		}
+	}
+	if !reflect.DeepEqual(interrupted, []string{"%1", "%2"}) {
+		t.Errorf("expected C-c to be sent to busy panes only, got %v", interrupted)
+	}
+
+	// A track without a window has nothing to close
+	if err := ops.closeTrackWindow(trk); err != nil {
+		t.Errorf("closeTrackWindow without window failed: %v", err)
+	}
+}
+
+func TestReleaseTrackWindow(t *testing.T) {
+	fake := &fakeTmux{}
+	tmux.SetRunner(fake)
+	defer tmux.ResetRunner()
+
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	path := "/wt"
+	if err := database.InsertTrack(db.Track{Branch: "feature", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+	trk, _ := database.GetTrack(cfg.Repo.Remote, "feature")
+	fake.windows = map[string]*fakeWindow{"@1": {session: "work", name: "feature", tag: trackTag(trk)}}
+
+	if err := ops.releaseTrackWindow(trk); err != nil {
+		t.Fatalf("releaseTrackWindow failed: %v", err)
+	}
+	w, ok := fake.windows["@1"]
+	if !ok || w.tag != "" {
+		t.Fatalf("expected the window to be kept untagged, got %+v", w)
+	}
+
+	// A track recreated on the same branch doesn't take over the kept window
+	_ = database.DeleteTrack(cfg.Repo.Remote, "feature")
+	if err := database.InsertTrack(db.Track{Branch: "feature", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+	trk, _ = database.GetTrack(cfg.Repo.Remote, "feature")
+	if w, err := ops.findTrackWindow(trk); err != nil || w != nil {
+		t.Errorf("findTrackWindow() = %+v, %v, want no window", w, err)
+	}
+}
+
+func TestCaptureAndGrep(t *testing.T) {
+	fake := &fakeTmux{}
+	tmux.SetRunner(fake)
+	defer tmux.ResetRunner()
+	t.Setenv("HOME", t.TempDir())
+
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	path := "/wt"
+	if err := database.InsertTrack(db.Track{Branch: "feature/login", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+	trk, _ := database.GetTrack(cfg.Repo.Remote, "feature/login")
+
+	// No window yet
+	if lines, err := ops.TailPane("feature/login", 2); err != nil || lines != nil {
+		t.Errorf("TailPane() without window = %v, %v, want nil", lines, err)
+	}
+	if _, err := ops.SaveCapture("feature/login", CaptureOptions{Pane: MainPane}); err == nil {
+		t.Error("expected SaveCapture to fail without a window")
+	}
+
+	fake.windows = map[string]*fakeWindow{"@1": {
+		session: "testrepo",
+		name:    "feature/login",
+		tag:     trackTag(trk),
+		panes:   []tmux.PaneInfo{{ID: "%1", Index: 1, Command: "nvim"}, {ID: "%2", Index: 2, Command: "zsh"}},
+		output: map[string]string{
+			"%1": "editing",
+			"%2": "=== RUN   TestLogin\n--- FAIL: TestLogin (0.01s)\nFAIL",
+		},
+	}}
+
+	if lines, err := ops.TailPane("feature/login", 2); err != nil || !reflect.DeepEqual(lines, []string{"editing"}) {
+		t.Errorf("TailPane() = %v, %v, want the main pane", lines, err)
+	}
+
+	saved, err := ops.SaveCapture("feature/login", CaptureOptions{Pane: 2})
+	if err != nil {
+		t.Fatalf("SaveCapture failed: %v", err)
+	}
+	wantDir := config.GetTrackLogDir(track.Slugify(cfg.Repo.Remote), "feature-login")
+	if !strings.HasSuffix(saved, "-pane2.log") || filepath.Dir(saved) != wantDir {
+		t.Errorf("unexpected capture path %s", saved)
+	}
+	// Captures taken in the same second don't overwrite each other
+	if again, err := ops.SaveCapture("feature/login", CaptureOptions{Pane: 1}); err != nil || again == saved {
+		t.Errorf("SaveCapture() = %s, %v, want a new file", again, err)
+	}
+	if _, err := ops.SaveCapture("feature/login", CaptureOptions{Pane: 5}); err == nil {
+		t.Error("expected error for a missing pane")
+	}
+
+	// Captures of deleted tracks are found too
+	oldDir := config.GetTrackLogDir(track.Slugify(cfg.Repo.Remote), "old-branch")
+	if err := os.MkdirAll(oldDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(oldDir, "capture-20260101-120000-pane0.log"), []byte("fail: timeout\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(oldDir, "capture-29991231-120000-pane0.log"), []byte("fail: later\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// CI logs aren't captures
+	if err := os.WriteFile(filepath.Join(oldDir, "ci-1.log"), []byte("FAIL\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Nor are other repos' captures
+	otherDir := config.GetTrackLogDir(track.Slugify("git@github.com:other/repo.git"), "feature-login")
+	if err := os.MkdirAll(otherDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(otherDir, "capture-20260101-120000-pane0.log"), []byte("FAIL\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	matches, err := ops.GrepCaptures(regexp.MustCompile("(?i)fail"))
+	if err != nil {
+		t.Fatalf("GrepCaptures failed: %v", err)
+	}
+	var got []string
+	for _, m := range matches {
+		got = append(got, fmt.Sprintf("%s:%d: %s", m.Branch, m.Line, m.Text))
+	}
+	// Oldest capture first, whatever the track
+	want := []string{
+		"old-branch:1: fail: timeout",
+		"feature/login:2: --- FAIL: TestLogin (0.01s)",
+		"feature/login:3: FAIL",
+		"old-branch:1: fail: later",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected matches:\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestTrackWindowWithoutTmux(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	cfg.Mux.Backend = mux.KindPrint
+	ops := New(database, cfg)
+	var out bytes.Buffer
+	ops.mux = mux.Print{Out: &out}
+
+	path := "/wt"
+	if err := database.InsertTrack(db.Track{Branch: "feature/login", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	// Without a multiplexer, jumping prints where to go
+	if err := ops.JumpToTrack("feature/login"); err != nil {
+		t.Fatalf("JumpToTrack failed: %v", err)
+	}
+	if out.String() != "cd '/wt'\n" {
+		t.Errorf("expected a cd command, got %q", out.String())
+	}
+	if lines, err := ops.TailPane("feature/login", 5); err != nil || lines != nil {
+		t.Errorf("expected no pane output, got %v, %v", lines, err)
+	}
+
+	// zellij tabs can't be tagged and are found by name in the track's session
+	fake := &fakeZellij{tabs: map[string][]string{"testrepo": {"main", "feature/login"}, "other": {"feature/login"}}}
+	zellij.SetRunner(fake)
+	defer zellij.ResetRunner()
+	ops.mux = mux.Zellij{}
+
+	trk, _ := database.GetTrack(cfg.Repo.Remote, "feature/login")
+	w, err := ops.findTrackWindow(trk)
+	if err != nil || w == nil || w.Session != "testrepo" || w.Name != "feature/login" {
+		t.Fatalf("expected tab testrepo:feature/login, got %+v, %v", w, err)
+	}
+	if err := ops.closeTrackWindow(trk); err != nil {
+		t.Fatalf("closeTrackWindow failed: %v", err)
+	}
+	if tabs := fake.tabs["testrepo"]; !reflect.DeepEqual(tabs, []string{"main"}) {
+		t.Errorf("expected the track's tab to be closed, got %v", tabs)
+	}
+}
+
+// fakeZellij keeps track of the tabs of zellij sessions.
+type fakeZellij struct {
+	tabs    map[string][]string // session -> tab names
+	focused map[string]string   // session -> focused tab
+}
+
+func (f *fakeZellij) Run(name string, args ...string) (string, error) {
+	if args[0] == "list-sessions" {
+		sessions := slices.Sorted(maps.Keys(f.tabs))
+		return strings.Join(sessions, "\n"), nil
+	}
+	if f.focused == nil {
+		f.focused = make(map[string]string)
+	}
+	session := args[1]
+	switch args[3] {
+	case "query-tab-names":
+		return strings.Join(f.tabs[session], "\n"), nil
+	case "go-to-tab-name":
+		f.focused[session] = args[4]
+	case "close-tab":
+		f.tabs[session] = slices.DeleteFunc(f.tabs[session], func(tab string) bool { return tab == f.focused[session] })
+	}
+	return "", nil
+}
+
+func (f *fakeZellij) Exec(name string, args ...string) error {
+	return nil
+}
+
+func TestMatchTrack(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	path := "/wt"
+	for _, branch := range []string{"feature/login", "feature/logout", "fix/Login-bug", "main"} {
+		if err := database.InsertTrack(db.Track{Branch: branch, RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+			t.Fatalf("failed to insert test track: %v", err)
+		}
+	}
+	// Tracks of other repos never match
+	if err := database.InsertTrack(db.Track{Branch: "elsewhere", RemoteURL: "other/repo", Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	tests := []struct {
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{"main", "main", false},
+		{"logout", "feature/logout", false},
+		{"BUG", "fix/Login-bug", false},
+		{"fxlb", "fix/Login-bug", false},
+		{"feature/log", "", true}, // login and logout
+		{"login", "", true},       // feature/login and fix/Login-bug
+		{"elsewhere", "", true},
+		{"zzz", "", true},
+	}
+	for _, tt := range tests {
+		trk, err := ops.MatchTrack(tt.query)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("MatchTrack(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if err == nil && trk.Branch != tt.want {
+			t.Errorf("MatchTrack(%q) = %s, want %s", tt.query, trk.Branch, tt.want)
+		}
+	}
+}
+
+func TestEnterTrack(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+
+	dir := t.TempDir()
+	for _, branch := range []string{"one", "two"} {
+		path := filepath.Join(dir, branch)
+		if err := database.InsertTrack(db.Track{Branch: branch, RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+			t.Fatalf("failed to insert test track: %v", err)
+		}
+	}
+	box := "box"
+	if err := database.InsertTrack(db.Track{Branch: "remote", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeDevbox, DevboxName: &box}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	if _, err := ops.EnterTrack("remote", dir); err == nil {
+		t.Error("expected an error for a devbox track")
+	}
+
+	steps := []struct {
+		query, cwd, want string
+	}{
+		{"one", dir, filepath.Join(dir, "one")},
+		{"two", filepath.Join(dir, "one", "sub"), filepath.Join(dir, "two")},
+		{PreviousTrack, filepath.Join(dir, "two"), filepath.Join(dir, "one")},
+		{PreviousTrack, filepath.Join(dir, "one"), filepath.Join(dir, "two")},
+	}
+	for _, step := range steps {
+		got, err := ops.EnterTrack(step.query, step.cwd)
+		if err != nil || got != step.want {
+			t.Errorf("EnterTrack(%q) from %s = %q, %v, want %q", step.query, step.cwd, got, err, step.want)
+		}
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := NewCached(database, cfg)
+
+	dir := t.TempDir()
+	outer, inner := filepath.Join(dir, "main"), filepath.Join(dir, "main", "nested")
+	for branch, path := range map[string]string{"main": outer, "fix/#12": inner} {
+		if err := database.InsertTrack(db.Track{Branch: branch, RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+			t.Fatalf("failed to insert test track: %v", err)
+		}
+	}
+
+	// Outside tracks, and before any refresh
+	if got, err := ops.Prompt(dir, "", false); err != nil || got != "" {
+		t.Errorf("Prompt() outside tracks = %q, %v", got, err)
+	}
+	if got, _ := ops.Prompt(filepath.Join(inner, "src"), "", false); got != "fix/#12" {
+		t.Errorf("Prompt() before a refresh = %q", got)
+	}
+
+	trk, _ := database.GetTrack(cfg.Repo.Remote, "fix/#12")
+	ops.saveTrackStatus(*trk, track.TrackStatus{
+		GitStatus: track.GitStatus{Clean: true, Ahead: true, AheadCount: 2},
+		PR:        &track.PRStatus{Number: 42},
+		CI:        &track.CIStatus{Passing: true},
+		Review:    &track.ReviewStatus{Pending: true},
+	})
+
+	tests := []struct {
+		format string
+		tmux   bool
+		want   string
+	}{
+		{"", false, "fix/#12 ↑2 #42 ✓○"},
+		{"{{.PR}}", false, "#42"},
+		{"{{.Branch}} {{.PR}} {{.CI}}", true, "fix/##12 ##42 #[fg=green]✓#[fg=default]"},
+	}
+	for _, tt := range tests {
+		got, err := ops.Prompt(inner, tt.format, tt.tmux)
+		if err != nil || got != tt.want {
+			t.Errorf("Prompt(%q, tmux=%v) = %q, %v, want %q", tt.format, tt.tmux, got, err, tt.want)
+		}
+	}
+
+	if _, err := ops.Prompt(inner, "{{.Missing}}", false); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestUntrackedRemoteBranches(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	database := testDB(t)
+	defer database.Close()
+
+	cfg := testConfig()
+	ops := New(database, cfg)
+	fake := &fakeForge{branches: []forge.RemoteBranch{{Name: "a"}, {Name: "b"}, {Name: "c"}}}
+	ops.forge = fake
+
+	path := "/wt"
+	if err := database.InsertTrack(db.Track{Branch: "b", RemoteURL: cfg.Repo.Remote, Type: db.TrackTypeWorktree, Path: &path}); err != nil {
+		t.Fatalf("failed to insert test track: %v", err)
+	}
+
+	branches, err := ops.UntrackedRemoteBranches()
+	if err != nil || !reflect.DeepEqual(branches, []string{"a", "c"}) {
+		t.Fatalf("UntrackedRemoteBranches() = %v, %v, want [a c]", branches, err)
+	}
+
+	// The cache is used while it's fresh
+	fake.branches = []forge.RemoteBranch{{Name: "d"}}
+	if branches, _ := ops.UntrackedRemoteBranches(); !reflect.DeepEqual(branches, []string{"a", "c"}) {
+		t.Errorf("expected the cached branches, got %v", branches)
+	}
+
+	old := time.Now().Add(-2 * remoteBranchCacheTTL)
+	if err := os.Chtimes(ops.remoteBranchCachePath(), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if branches, _ := ops.UntrackedRemoteBranches(); !reflect.DeepEqual(branches, []string{"d"}) {
+		t.Errorf("expected the branches to be refreshed, got %v", branches)
+	}
+}
@@ -0,0 +1,140 @@
+package ops
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/track"
+)
+
+// PreviousTrack is the query EnterTrack resolves to the track entered before the current one.
+const PreviousTrack = "-"
+
+// MatchTrack returns the track of the repo whose branch matches query: the branch itself,
+// else the only branch containing query (ignoring case), else the only branch containing
+// the characters of query in order, e.g. "flog" for "feature/login".
+func (o *Ops) MatchTrack(query string) (*db.Track, error) {
+	matches, err := o.MatchTracks(query)
+	if err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no track matches %q", query)
+	case 1:
+		return &matches[0], nil
+	default:
+		branches := make([]string, len(matches))
+		for i, trk := range matches {
+			branches[i] = trk.Branch
+		}
+		return nil, fmt.Errorf("%q matches several tracks: %s", query, strings.Join(branches, ", "))
+	}
+}
+
+// MatchTracks returns the tracks of the repo that match query best (see track.MatchBranch),
+// most recently accessed first.
+func (o *Ops) MatchTracks(query string) ([]db.Track, error) {
+	tracks, err := o.RepoTracks()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []db.Track
+	best := -1
+	for _, trk := range tracks {
+		rank, ok := track.MatchBranch(query, trk.Branch)
+		if !ok || (best >= 0 && rank > best) {
+			continue
+		}
+		if best < 0 || rank < best {
+			matches = nil
+			best = rank
+		}
+		matches = append(matches, trk)
+	}
+	return matches, nil
+}
+
+// RepoTracks returns the tracks of the repo, most recently accessed first.
+func (o *Ops) RepoTracks() ([]db.Track, error) {
+	all, err := o.db.ListTracks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracks: %w", err)
+	}
+	tracks := make([]db.Track, 0, len(all))
+	for _, trk := range all {
+		if trk.RemoteURL == o.config.Repo.Remote {
+			tracks = append(tracks, trk)
+		}
+	}
+	return tracks, nil
+}
+
+// TrackPath returns the worktree of the track matching query (see MatchTrack).
+func (o *Ops) TrackPath(query string) (string, error) {
+	trk, err := o.MatchTrack(query)
+	if err != nil {
+		return "", err
+	}
+	return worktreePath(trk)
+}
+
+// EnterTrack returns the worktree of the track matching query for the shell to change to,
+// and marks the track as accessed. PreviousTrack selects the most recently accessed track
+// other than the one cwd is in, so entering it repeatedly switches between two tracks.
+func (o *Ops) EnterTrack(query, cwd string) (string, error) {
+	var trk *db.Track
+	var err error
+	if query == PreviousTrack {
+		trk, err = o.previousTrack(cwd)
+	} else {
+		trk, err = o.MatchTrack(query)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	path, err := worktreePath(trk)
+	if err != nil {
+		return "", err
+	}
+	_ = o.db.UpdateLastAccessed(trk.RemoteURL, trk.Branch)
+	o.recordEvent(db.Event{Branch: trk.Branch, Kind: db.EventJump})
+	return path, nil
+}
+
+// previousTrack returns the most recently accessed worktree track that cwd isn't in.
+func (o *Ops) previousTrack(cwd string) (*db.Track, error) {
+	tracks, err := o.RepoTracks()
+	if err != nil {
+		return nil, err
+	}
+	for i := range tracks {
+		trk := &tracks[i]
+		if trk.Type != db.TrackTypeWorktree || trk.Path == nil || isWithin(cwd, *trk.Path) {
+			continue
+		}
+		return trk, nil
+	}
+	return nil, fmt.Errorf("no previous track")
+}
+
+// isWithin reports whether path is dir or inside it.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// worktreePath returns the local path of a track, which only worktree tracks have.
+func worktreePath(trk *db.Track) (string, error) {
+	if trk.Type != db.TrackTypeWorktree {
+		return "", fmt.Errorf("%s is a devbox track and has no local path", trk.Branch)
+	}
+	if trk.Path == nil {
+		return "", fmt.Errorf("worktree track has no path")
+	}
+	return *trk.Path, nil
+}
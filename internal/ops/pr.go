@@ -0,0 +1,268 @@
+package ops
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/forge"
+	"github.com/laurent/trak/internal/git"
+)
+
+// repoPRTemplatePaths are the locations GitHub looks for a pull request template.
+var repoPRTemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// PRTemplateData is the data available to PR title and body templates.
+type PRTemplateData struct {
+	Branch      string
+	BaseBranch  string
+	Remote      string
+	TrackType   string
+	Path        string
+	Commits     []string // Commit subjects, oldest first
+	FirstCommit string   // Subject of the first commit, empty if none
+	Issue       string   // Linked issue reference, empty if none
+	IssueLine   string   // Line linking the issue, e.g. "Closes #42"; appended to the body if unused
+}
+
+// PRCreateOptions overrides the configured PR defaults for a single PR.
+type PRCreateOptions struct {
+	Title string
+	Draft *bool // nil uses the configured default
+}
+
+// CreatePRForTrack pushes a track's branch and opens a PR for it without rebasing.
+// Returns the number of the created PR.
+func (o *Ops) CreatePRForTrack(branch string, overrides PRCreateOptions) (prNumber int, err error) {
+	defer func() { o.recordError(branch, "create PR", err) }()
+
+	remote := o.config.Repo.Remote
+
+	trk, err := o.db.GetTrack(remote, branch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get track: %w", err)
+	}
+	if trk == nil {
+		return 0, fmt.Errorf("track not found for branch: %s", branch)
+	}
+	if err := checkWritable(trk); err != nil {
+		return 0, err
+	}
+	if trk.Type != db.TrackTypeWorktree {
+		return 0, fmt.Errorf("PR creation is not supported for devbox tracks")
+	}
+	if trk.Path == nil {
+		return 0, fmt.Errorf("worktree track has no path")
+	}
+	workDir := *trk.Path
+
+	existing, err := o.prForTrack(trk)
+	if err != nil {
+		return 0, err
+	}
+	if existing != nil {
+		return 0, fmt.Errorf("PR #%d already exists for %s", existing.Number, branch)
+	}
+
+	if err := git.Fetch(workDir); err != nil {
+		return 0, fmt.Errorf("failed to fetch: %w", err)
+	}
+
+	defaultBranch, err := git.GetDefaultBranch(workDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	if err := git.Push(workDir, branch); err != nil {
+		return 0, fmt.Errorf("failed to push: %w", err)
+	}
+	headSHA, _ := git.GetHeadSHA(workDir)
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventPush, Detail: "pushed for PR", AfterSHA: headSHA})
+
+	opts, err := o.buildPROptions(trk, workDir, defaultBranch)
+	if err != nil {
+		return 0, err
+	}
+	if overrides.Title != "" {
+		opts.Title = overrides.Title
+	}
+	if overrides.Draft != nil {
+		opts.Draft = *overrides.Draft
+	}
+
+	prNumber, err = o.forge.CreatePR(remote, branch, defaultBranch, opts)
+	if err != nil {
+		return 0, err
+	}
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventPRCreate, Detail: fmt.Sprintf("created PR #%d", prNumber)})
+	return prNumber, nil
+}
+
+// buildPROptions derives the PR title, body and metadata from config and the track's commits.
+func (o *Ops) buildPROptions(trk *db.Track, workDir, defaultBranch string) (forge.PROptions, error) {
+	cfg := o.config.PR
+
+	commits, err := git.CommitSubjects(workDir, "origin/"+defaultBranch, trk.Branch)
+	if err != nil {
+		// Non-fatal, templates just get no commits
+		commits = []string{}
+	}
+
+	data := PRTemplateData{
+		Branch:     trk.Branch,
+		BaseBranch: defaultBranch,
+		Remote:     trk.RemoteURL,
+		TrackType:  string(trk.Type),
+		Path:       workDir,
+		Commits:    commits,
+	}
+	if len(commits) > 0 {
+		data.FirstCommit = commits[0]
+	}
+	if trk.IssueRef != nil {
+		data.Issue = *trk.IssueRef
+		data.IssueLine = o.issueLine(trk.IssueRef)
+	}
+
+	title, err := renderPRTitle(cfg.TitleTemplate, data)
+	if err != nil {
+		return forge.PROptions{}, err
+	}
+
+	body, err := renderPRBody(cfg.BodyTemplate, cfg.BodyTemplateFile, workDir, data)
+	if err != nil {
+		return forge.PROptions{}, err
+	}
+	body = appendIssueLine(body, data)
+
+	return forge.PROptions{
+		Title:     title,
+		Body:      body,
+		Reviewers: cfg.Reviewers,
+		Labels:    cfg.Labels,
+		Assignees: cfg.Assignees,
+		Draft:     cfg.Draft,
+	}, nil
+}
+
+// renderPRTitle renders the title template, defaulting to the first commit subject
+// and falling back to the branch name.
+func renderPRTitle(tmpl string, data PRTemplateData) (string, error) {
+	if tmpl != "" {
+		title, err := renderTemplate("title", tmpl, data)
+		if err != nil {
+			return "", err
+		}
+		if title = strings.TrimSpace(title); title != "" {
+			return title, nil
+		}
+	}
+	if data.FirstCommit != "" {
+		return data.FirstCommit, nil
+	}
+	return data.Branch, nil
+}
+
+// renderPRBody builds the PR body. In order of preference it uses the configured
+// inline template, the configured template file, the repo's pull request template
+// (verbatim), and finally a generated list of commits.
+func renderPRBody(inline, file, workDir string, data PRTemplateData) (string, error) {
+	if inline != "" {
+		return renderTemplate("body", inline, data)
+	}
+
+	if file != "" {
+		content, err := os.ReadFile(expandHome(file))
+		if err != nil {
+			return "", fmt.Errorf("failed to read PR body template: %w", err)
+		}
+		return renderTemplate("body", string(content), data)
+	}
+
+	if content := readRepoPRTemplate(workDir); content != "" {
+		return content, nil
+	}
+
+	return defaultPRBody(data), nil
+}
+
+// appendIssueLine adds the issue line to a PR body unless the body already references the issue.
+func appendIssueLine(body string, data PRTemplateData) string {
+	if data.IssueLine == "" || referencesIssue(body, data.Issue) {
+		return body
+	}
+	if body = strings.TrimRight(body, "\n"); body == "" {
+		return data.IssueLine + "\n"
+	}
+	return body + "\n\n" + data.IssueLine + "\n"
+}
+
+// referencesIssue reports whether text mentions an issue reference as a whole, so that
+// #4 isn't found in #42 or PROJ-1 in XPROJ-12.
+func referencesIssue(text, issue string) bool {
+	re := regexp.MustCompile(`(?:^|\W)` + regexp.QuoteMeta(issue) + `(?:\D|$)`)
+	return re.MatchString(text)
+}
+
+// readRepoPRTemplate returns the repository's pull request template, or "" if none exists.
+func readRepoPRTemplate(workDir string) string {
+	for _, rel := range repoPRTemplatePaths {
+		content, err := os.ReadFile(filepath.Join(workDir, rel))
+		if err == nil {
+			return string(content)
+		}
+	}
+	return ""
+}
+
+// defaultPRBody lists the commits in the PR.
+func defaultPRBody(data PRTemplateData) string {
+	if len(data.Commits) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("## Commits\n\n")
+	for _, c := range data.Commits {
+		b.WriteString("- " + c + "\n")
+	}
+	return b.String()
+}
+
+// renderTemplate executes a text/template with the given data.
+func renderTemplate(name, text string, data any) (string, error) {
+	t, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// expandHome replaces a leading "~/" with the user's home directory.
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
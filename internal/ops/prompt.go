@@ -0,0 +1,156 @@
+package ops
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/track"
+)
+
+// PromptData is the data available to prompt formats (see config.PromptConfig). Status
+// fields are empty until the track's status is first refreshed.
+type PromptData struct {
+	Branch string
+	Git    string // Git status, e.g. "clean", "↑2↓3" or "dirty" (see track.GitStatus.String)
+	PR     string // PR number, e.g. "#42", empty without a PR
+	CI     string // CI symbol, e.g. "✓" (see track.CIStatus.Symbol)
+	Review string // Review symbol, e.g. "○" (see track.ReviewStatus.Symbol)
+}
+
+// Prompt renders a prompt format for the track dir is in, or returns "" outside tracks.
+// It only reads the status cached by the last refresh, so it is fast enough to run on
+// every shell prompt. With tmux, the fields carry tmux colors for a status bar. An empty
+// format selects the configured one.
+func (o *Ops) Prompt(dir, format string, tmux bool) (string, error) {
+	if format == "" {
+		format = o.config.Prompt.PromptFormat()
+		if tmux {
+			format = o.config.Prompt.TmuxStatusFormat()
+		}
+	}
+	t, err := template.New("prompt").Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse prompt format: %w", err)
+	}
+
+	trk, err := o.TrackAt(dir)
+	if err != nil || trk == nil {
+		return "", err
+	}
+	status, updated, err := o.CachedTrackStatus(*trk)
+	if err != nil {
+		return "", err
+	}
+
+	data := promptData(trk.Branch, status, !updated.IsZero(), tmux)
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt format: %w", err)
+	}
+	return b.String(), nil
+}
+
+// promptData fills the prompt fields from a track's status, known or not, colored for
+// tmux if asked.
+func promptData(branch string, status track.TrackStatus, known, tmux bool) PromptData {
+	data := PromptData{Branch: branch}
+	if known {
+		data.Git = status.GitStatus.String()
+		data.CI = status.CI.Symbol()
+		data.Review = status.Review.Symbol()
+		if status.PR != nil {
+			data.PR = fmt.Sprintf("#%d", status.PR.Number)
+		}
+	}
+	if !tmux {
+		return data
+	}
+
+	// tmux reads #[...] as a style, so a literal # is doubled
+	escape := func(s string) string { return strings.ReplaceAll(s, "#", "##") }
+	data.Branch = escape(data.Branch)
+	data.PR = escape(data.PR)
+
+	switch data.Git {
+	case "":
+	case "clean":
+		data.Git = tmuxColor(data.Git, "green")
+	case "dirty":
+		data.Git = tmuxColor(data.Git, "yellow")
+	default:
+		data.Git = tmuxColor(data.Git, "cyan")
+	}
+	data.CI = tmuxSymbol(data.CI)
+	data.Review = tmuxSymbol(data.Review)
+	return data
+}
+
+// tmuxSymbol colors a CI or review symbol: green when passing or approved, red when
+// failing or changes were requested, yellow when pending.
+func tmuxSymbol(symbol string) string {
+	switch symbol {
+	case "✓":
+		return tmuxColor(symbol, "green")
+	case "✗":
+		return tmuxColor(symbol, "red")
+	case "○":
+		return tmuxColor(symbol, "yellow")
+	}
+	return symbol
+}
+
+// tmuxColor wraps s in tmux style codes for a foreground color.
+func tmuxColor(s, color string) string {
+	return "#[fg=" + color + "]" + s + "#[fg=default]"
+}
+
+// TrackAt returns the worktree track dir is in, or nil if it isn't in one. Nested
+// worktrees resolve to the innermost.
+func (o *Ops) TrackAt(dir string) (*db.Track, error) {
+	tracks, err := o.db.ListTracks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracks: %w", err)
+	}
+
+	var found *db.Track
+	for i := range tracks {
+		trk := &tracks[i]
+		if trk.Type != db.TrackTypeWorktree || trk.Path == nil || !isWithin(dir, *trk.Path) {
+			continue
+		}
+		if found == nil || len(*trk.Path) > len(*found.Path) {
+			found = trk
+		}
+	}
+	return found, nil
+}
+
+// CachedTrackStatus returns the status saved by the last refresh of a track (see
+// RefreshTrackStatus) and when it was saved, without running git or calling the forge.
+// The time is zero if the status was never saved.
+func (o *Ops) CachedTrackStatus(trk db.Track) (track.TrackStatus, time.Time, error) {
+	data, updated, err := o.db.GetStatus(trk.RemoteURL, trk.Branch)
+	if err != nil || data == "" {
+		return track.TrackStatus{}, time.Time{}, err
+	}
+	var status track.TrackStatus
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		// An unreadable status is replaced on the next refresh
+		return track.TrackStatus{}, time.Time{}, nil
+	}
+	return status, updated, nil
+}
+
+// saveTrackStatus caches a refreshed status for CachedTrackStatus. Failures only leave
+// the cache stale, so they are ignored.
+func (o *Ops) saveTrackStatus(trk db.Track, status track.TrackStatus) {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+	_ = o.db.SaveStatus(trk.RemoteURL, trk.Branch, string(data))
+}
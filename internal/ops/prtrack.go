@@ -0,0 +1,151 @@
+package ops
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/git"
+	"github.com/laurent/trak/internal/github"
+)
+
+// NewTrackFromPR creates a worktree track checked out at a PR's head branch.
+// ref is a PR number or URL. PRs from forks get a remote named after the fork's owner,
+// and their local branch is prefixed with it ("alice/fix-typo") so it can't clash
+// with branches of the same name in the base repository.
+// Returns the branch name of the new track.
+func (o *Ops) NewTrackFromPR(ref string) (string, error) {
+	repoPath := o.config.Repo.Path
+	remote := o.config.Repo.Remote
+
+	if err := o.requireGitHub("creating tracks from PRs"); err != nil {
+		return "", err
+	}
+
+	prNumber, err := parsePRRefForRemote(ref, remote)
+	if err != nil {
+		return "", err
+	}
+
+	head, err := github.GetPRHead(remote, prNumber)
+	if err != nil {
+		return "", err
+	}
+	branch := prTrackBranch(head)
+
+	existing, err := o.db.GetTrack(remote, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get track: %w", err)
+	}
+	if existing != nil {
+		return "", fmt.Errorf("track already exists for %s", branch)
+	}
+
+	createdBranch, err := fetchPRHead(repoPath, head, branch)
+	if err != nil {
+		return "", err
+	}
+
+	worktreePath, sha, err := addWorktree(repoPath, branch)
+	if err != nil {
+		if createdBranch {
+			_ = git.DeleteBranch(repoPath, branch)
+		}
+		return "", err
+	}
+
+	// Record in database
+	now := time.Now()
+	trackRecord := db.Track{
+		Branch:       branch,
+		RemoteURL:    remote,
+		HeadSHA:      sha,
+		Type:         db.TrackTypeWorktree,
+		Path:         &worktreePath,
+		CreatedAt:    now,
+		LastAccessed: &now,
+		PRNumber:     &prNumber,
+	}
+
+	if err := o.db.InsertTrack(trackRecord); err != nil {
+		// If DB insert fails, try to clean up the worktree
+		_ = git.WorktreeRemove(repoPath, worktreePath)
+		return "", fmt.Errorf("failed to record track in database: %w", err)
+	}
+
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventCreate, Detail: fmt.Sprintf("from PR #%d at %s", prNumber, worktreePath), AfterSHA: sha})
+	return branch, nil
+}
+
+// fetchPRHead fetches a PR's head branch and creates a local branch tracking it,
+// adding a remote for the fork if needed. Returns whether the local branch was created.
+func fetchPRHead(repoPath string, head *github.PRHead, branch string) (bool, error) {
+	headRemote := "origin"
+	if head.IsFork {
+		headRemote = head.Owner
+		if !git.HasRemote(repoPath, headRemote) {
+			if err := git.AddRemote(repoPath, headRemote, github.CloneURL(head.Repo)); err != nil {
+				return false, fmt.Errorf("failed to add remote for fork %s: %w", head.Repo, err)
+			}
+		}
+	}
+
+	if err := git.FetchBranch(repoPath, headRemote, head.Branch); err != nil {
+		return false, fmt.Errorf("failed to fetch %s from %s: %w", head.Branch, headRemote, err)
+	}
+
+	if _, err := git.GetBranchSHA(repoPath, branch); err == nil {
+		// Branch already exists locally, reuse it as is
+		return false, nil
+	}
+	if err := git.CreateBranch(repoPath, branch, headRemote+"/"+head.Branch); err != nil {
+		return false, fmt.Errorf("failed to create branch: %w", err)
+	}
+	return true, nil
+}
+
+// pushTrack force-pushes a track's branch to origin, or to its upstream when that is
+// another remote, e.g. the fork of a PR created with NewTrackFromPR.
+func pushTrack(workDir, branch string) error {
+	remote, remoteBranch, err := pushTarget(workDir, branch)
+	if err != nil {
+		return err
+	}
+	if remote != "origin" {
+		return git.PushForceTo(workDir, remote, branch, remoteBranch)
+	}
+	return git.PushForce(workDir, branch)
+}
+
+// pushTarget returns the remote and remote branch a track's branch is pushed to.
+func pushTarget(workDir, branch string) (remote, remoteBranch string, err error) {
+	upstream, upstreamBranch, err := git.BranchUpstream(workDir, branch)
+	if err != nil {
+		return "", "", err
+	}
+	if upstream != "" && upstream != "origin" && upstreamBranch != "" {
+		return upstream, upstreamBranch, nil
+	}
+	return "origin", branch, nil
+}
+
+// prTrackBranch returns the local branch name for a PR's head.
+func prTrackBranch(head *github.PRHead) string {
+	if head.IsFork {
+		return head.Owner + "/" + head.Branch
+	}
+	return head.Branch
+}
+
+// parsePRRefForRemote parses a PR number or URL, checking that a URL points at remote.
+func parsePRRefForRemote(ref, remote string) (int, error) {
+	refRemote, number, err := github.ParsePRRef(ref)
+	if err != nil {
+		return 0, err
+	}
+	if refRemote != "" && !strings.EqualFold(refRemote, remote) {
+		return 0, fmt.Errorf("PR %s belongs to %s, not %s", ref, refRemote, remote)
+	}
+	return number, nil
+}
@@ -0,0 +1,55 @@
+package ops
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/laurent/trak/internal/forge"
+	"github.com/laurent/trak/internal/github"
+)
+
+// RateLimit returns the remaining GitHub API quota. It is the zero value when
+// the repo isn't on GitHub or the quota can't be determined.
+func (o *Ops) RateLimit() github.RateLimit {
+	g, ok := o.forge.(forge.GitHub)
+	if !ok {
+		return github.RateLimit{}
+	}
+	if g.Client != nil {
+		return g.Client.RateLimit()
+	}
+	rate, err := github.FetchRateLimit()
+	if err != nil {
+		return github.LastRateLimit()
+	}
+	return rate
+}
+
+// StatusWarning summarizes the PR status errors of a track list for display,
+// e.g. when the API rate limit was hit. Returns "" if all statuses were fetched.
+func StatusWarning(tracks []TrackWithStatus, rate github.RateLimit) string {
+	var failed []error
+	for _, t := range tracks {
+		if t.Err != nil {
+			failed = append(failed, t.Err)
+		}
+	}
+	if len(failed) == 0 {
+		return ""
+	}
+
+	for _, err := range failed {
+		if errors.Is(err, github.ErrRateLimited) {
+			msg := "GitHub API rate limit exceeded, PR status unavailable"
+			if !rate.Reset.IsZero() {
+				msg += " until " + rate.Reset.Local().Format("15:04")
+			}
+			return msg
+		}
+	}
+
+	if len(failed) == 1 {
+		return failed[0].Error()
+	}
+	return fmt.Sprintf("PR status unavailable for %d tracks: %v", len(failed), failed[0])
+}
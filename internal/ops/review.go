@@ -0,0 +1,171 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/forge"
+	"github.com/laurent/trak/internal/git"
+	"github.com/laurent/trak/internal/github"
+)
+
+// reviewBranchPrefix prefixes the local branch of a review track.
+const reviewBranchPrefix = "review/pr-"
+
+// ReviewBranch returns the local branch name used for reviewing a PR.
+func ReviewBranch(prNumber int) string {
+	return fmt.Sprintf("%s%d", reviewBranchPrefix, prNumber)
+}
+
+// ListReviewRequests returns the open PRs awaiting the current user's review.
+func (o *Ops) ListReviewRequests() ([]github.ReviewRequest, error) {
+	if err := o.requireGitHub("listing review requests"); err != nil {
+		return nil, err
+	}
+	reqs, err := github.ListReviewRequests(o.config.Repo.Remote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list review requests: %w", err)
+	}
+	return reqs, nil
+}
+
+// NewReviewTrack creates a read-only worktree track checked out at a PR's head.
+// The head is fetched from the base repository's pull ref, so PRs from forks work too.
+// Returns the branch name of the new track.
+func (o *Ops) NewReviewTrack(prNumber int) (_ string, err error) {
+	repoPath := o.config.Repo.Path
+	remote := o.config.Repo.Remote
+	branch := ReviewBranch(prNumber)
+	defer func() { o.recordError(branch, "create", err) }()
+
+	if err := o.requireGitHub("reviewing PRs"); err != nil {
+		return "", err
+	}
+
+	existing, err := o.db.GetTrack(remote, branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get track: %w", err)
+	}
+	if existing != nil {
+		return "", fmt.Errorf("review track already exists for PR #%d", prNumber)
+	}
+
+	// Fetch the PR head into a local review branch
+	refspec := fmt.Sprintf("+refs/pull/%d/head:refs/heads/%s", prNumber, branch)
+	if err := git.FetchRefspec(repoPath, refspec); err != nil {
+		return "", fmt.Errorf("failed to fetch PR #%d: %w", prNumber, err)
+	}
+
+	worktreePath, sha, err := addWorktree(repoPath, branch)
+	if err != nil {
+		_ = git.DeleteBranch(repoPath, branch)
+		return "", err
+	}
+
+	// Record in database
+	now := time.Now()
+	trackRecord := db.Track{
+		Branch:       branch,
+		RemoteURL:    remote,
+		HeadSHA:      sha,
+		Type:         db.TrackTypeWorktree,
+		Path:         &worktreePath,
+		CreatedAt:    now,
+		LastAccessed: &now,
+		PRNumber:     &prNumber,
+		Review:       true,
+	}
+
+	if err := o.db.InsertTrack(trackRecord); err != nil {
+		// If DB insert fails, try to clean up the worktree and branch
+		_ = git.WorktreeRemove(repoPath, worktreePath)
+		_ = git.DeleteBranch(repoPath, branch)
+		return "", fmt.Errorf("failed to record track in database: %w", err)
+	}
+
+	o.recordEvent(db.Event{Branch: branch, Kind: db.EventCreate, Detail: fmt.Sprintf("review of PR #%d at %s", prNumber, worktreePath), AfterSHA: sha})
+	return branch, nil
+}
+
+// CleanupReviewTracks deletes review tracks whose PR no longer awaits the current
+// user's review, i.e. the review was submitted or the PR was closed.
+// Tracks that fail to delete (e.g. a dirty worktree) or whose window may lose work
+// (see WindowWarnings) are left in place.
+// Returns the branches of the deleted tracks.
+func (o *Ops) CleanupReviewTracks() ([]string, error) {
+	tracks, err := o.db.ListTracks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracks: %w", err)
+	}
+
+	var reviews []db.Track
+	for _, trk := range tracks {
+		if trk.Review && trk.PRNumber != nil {
+			reviews = append(reviews, trk)
+		}
+	}
+	if len(reviews) == 0 {
+		return nil, nil
+	}
+
+	reqs, err := o.ListReviewRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	return o.cleanupReviewTracks(reviews, reqs), nil
+}
+
+// cleanupReviewTracks deletes the review tracks whose PR is not among the pending requests.
+func (o *Ops) cleanupReviewTracks(reviews []db.Track, reqs []github.ReviewRequest) []string {
+	pending := make(map[int]bool, len(reqs))
+	for _, r := range reqs {
+		pending[r.Number] = true
+	}
+
+	var deleted []string
+	for _, trk := range reviews {
+		if pending[*trk.PRNumber] {
+			continue
+		}
+		// Nobody confirms this delete, so don't kill a window with an editor open
+		if warnings, err := o.WindowWarnings(trk.Branch); err != nil || len(warnings) > 0 {
+			continue
+		}
+		if err := o.DeleteTrack(trk.Branch, DeleteOptions{}); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to clean up review track %s: %v\n", trk.Branch, err)
+			continue
+		}
+		deleted = append(deleted, trk.Branch)
+	}
+	return deleted
+}
+
+// checkWritable returns an error if a track must not be pushed to or merged.
+func checkWritable(trk *db.Track) error {
+	if trk.Review {
+		return fmt.Errorf("%s is a read-only review track", trk.Branch)
+	}
+	return nil
+}
+
+// requireGitHub returns an error if the repo isn't on GitHub, for features built on gh
+// (CI runs, merging, review threads and requests, pull refs).
+func (o *Ops) requireGitHub(feature string) error {
+	if _, ok := o.forge.(forge.GitHub); !ok {
+		return fmt.Errorf("%s is not supported on %s", feature, o.forge.Name())
+	}
+	return nil
+}
+
+// prForTrack returns the PR for a track, looking it up by number when recorded
+// (review branches are named differently from the PR head) and by branch otherwise.
+func (o *Ops) prForTrack(trk *db.Track) (*forge.PR, error) {
+	remote := o.config.Repo.Remote
+	if trk.PRNumber != nil {
+		return o.forge.GetPR(remote, *trk.PRNumber)
+	}
+	return o.forge.GetPRForBranch(remote, trk.Branch)
+}
@@ -0,0 +1,253 @@
+package ops
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/devbox"
+	"github.com/laurent/trak/internal/git"
+	"github.com/laurent/trak/internal/github"
+)
+
+// undoRef returns the ref that keeps the commits of one side ("local" or "remote") of an
+// undo entry from being garbage collected.
+func undoRef(id int64, side string) string {
+	return fmt.Sprintf("refs/trak/undo/%d/%s", id, side)
+}
+
+// ListUndoable returns the deletes and syncs that can still be undone, newest first.
+// An empty branch returns entries for all branches.
+func (o *Ops) ListUndoable(branch string) ([]db.UndoEntry, error) {
+	retention, err := o.config.Undo.RetentionPeriod()
+	if err != nil {
+		return nil, err
+	}
+	return o.db.ListUndoEntries(o.config.Repo.Remote, branch, time.Now().Add(-retention))
+}
+
+// journal records the state of a track before a destructive operation, and pins its
+// local and remote branch tips with refs so the commits survive until the entry expires.
+// Expired entries are pruned along the way. Returns the ID of the new entry.
+func (o *Ops) journal(entry db.UndoEntry) (int64, error) {
+	repoPath := o.config.Repo.Path
+	o.pruneUndoJournal()
+
+	entry.RemoteURL = o.config.Repo.Remote
+	id, err := o.db.InsertUndoEntry(entry)
+	if err != nil {
+		return 0, fmt.Errorf("failed to journal %s for undo: %w", entry.Operation, err)
+	}
+	for side, sha := range map[string]string{"local": entry.LocalSHA, "remote": entry.RemoteSHA} {
+		if sha == "" {
+			continue
+		}
+		if err := git.UpdateRef(repoPath, undoRef(id, side), sha); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to pin %s for undo: %v\n", sha, err)
+		}
+	}
+	return id, nil
+}
+
+// discardJournal removes an entry whose operation failed and left nothing to undo.
+func (o *Ops) discardJournal(id int64) {
+	_ = o.db.DeleteUndoEntry(id)
+	o.unpinUndo(id)
+}
+
+// unpinUndo deletes the refs pinning the commits of an undo entry.
+func (o *Ops) unpinUndo(id int64) {
+	for _, side := range []string{"local", "remote"} {
+		_ = git.DeleteRef(o.config.Repo.Path, undoRef(id, side))
+	}
+}
+
+// pruneUndoJournal removes undone and expired entries and their refs.
+func (o *Ops) pruneUndoJournal() {
+	retention, err := o.config.Undo.RetentionPeriod()
+	if err != nil {
+		return
+	}
+	ids, err := o.db.PruneUndoEntries(time.Now().Add(-retention))
+	if err != nil {
+		return
+	}
+	for _, id := range ids {
+		o.unpinUndo(id)
+	}
+}
+
+// journalDelete records a track before it is deleted. remoteDeleted tells whether
+// the remote branch is deleted along with it.
+func (o *Ops) journalDelete(trk *db.Track, remoteDeleted bool) (int64, error) {
+	repoPath := o.config.Repo.Path
+	entry := db.UndoEntry{
+		Branch:    trk.Branch,
+		Operation: db.UndoDelete,
+		Track:     *trk,
+	}
+
+	if trk.Type == db.TrackTypeWorktree {
+		if sha, err := git.GetBranchSHA(repoPath, trk.Branch); err == nil {
+			entry.LocalSHA = sha
+		}
+	}
+	if remoteDeleted {
+		if sha, err := git.GetBranchSHA(repoPath, "origin/"+trk.Branch); err == nil {
+			entry.RemoteName = "origin"
+			entry.RemoteBranch = trk.Branch
+			entry.RemoteSHA = sha
+		}
+	}
+
+	return o.journal(entry)
+}
+
+// journalSync records a track's branch tips before a sync force-pushes it.
+// localSHA is the tip before the rebase.
+func (o *Ops) journalSync(trk *db.Track, workDir, localSHA string) (int64, error) {
+	entry := db.UndoEntry{
+		Branch:    trk.Branch,
+		Operation: db.UndoSync,
+		Track:     *trk,
+		LocalSHA:  localSHA,
+	}
+
+	remote, remoteBranch, err := pushTarget(workDir, trk.Branch)
+	if err == nil {
+		if sha, err := git.GetBranchSHA(workDir, remote+"/"+remoteBranch); err == nil {
+			entry.RemoteName = remote
+			entry.RemoteBranch = remoteBranch
+			entry.RemoteSHA = sha
+		}
+	}
+
+	return o.journal(entry)
+}
+
+// Undo reverts a journaled operation locally. A deleted track gets its worktree (or devbox)
+// and database row back; a synced track is reset to its branch tip before the sync.
+// The remote branch is left alone, see RestoreRemote.
+func (o *Ops) Undo(entry db.UndoEntry) (err error) {
+	defer func() { o.recordError(entry.Branch, "undo", err) }()
+
+	switch entry.Operation {
+	case db.UndoDelete:
+		err = o.undoDelete(entry)
+	case db.UndoSync:
+		err = o.undoSync(entry)
+	default:
+		return fmt.Errorf("unknown undo operation %q", entry.Operation)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := o.db.MarkUndone(entry.ID); err != nil {
+		return err
+	}
+	o.recordEvent(db.Event{
+		Branch:   entry.Branch,
+		Kind:     db.EventUndo,
+		Detail:   fmt.Sprintf("undid %s from %s", entry.Operation, entry.CreatedAt.Local().Format("2006-01-02 15:04")),
+		AfterSHA: entry.LocalSHA,
+	})
+	return nil
+}
+
+// undoDelete recreates a deleted track from its journal entry.
+func (o *Ops) undoDelete(entry db.UndoEntry) error {
+	repoPath := o.config.Repo.Path
+	remote := o.config.Repo.Remote
+	trk := entry.Track
+
+	existing, err := o.db.GetTrack(remote, entry.Branch)
+	if err != nil {
+		return fmt.Errorf("failed to get track: %w", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("track already exists for %s", entry.Branch)
+	}
+
+	switch trk.Type {
+	case db.TrackTypeWorktree:
+		if trk.Path == nil {
+			return fmt.Errorf("worktree track has no path")
+		}
+		if entry.LocalSHA == "" {
+			return fmt.Errorf("no branch tip was saved for %s", entry.Branch)
+		}
+		if _, err := os.Stat(*trk.Path); err == nil {
+			return fmt.Errorf("worktree path %s already exists", *trk.Path)
+		}
+		// Review branches are deleted with their track
+		if _, err := git.GetBranchSHA(repoPath, entry.Branch); err != nil {
+			if err := git.CreateBranch(repoPath, entry.Branch, entry.LocalSHA); err != nil {
+				return fmt.Errorf("failed to recreate branch: %w", err)
+			}
+		}
+		if err := git.WorktreeAdd(repoPath, *trk.Path, entry.Branch); err != nil {
+			return fmt.Errorf("failed to recreate worktree: %w", err)
+		}
+		trk.HeadSHA = entry.LocalSHA
+
+	case db.TrackTypeDevbox:
+		if trk.DevboxName == nil {
+			return fmt.Errorf("devbox track has no name")
+		}
+		// The devbox's contents are gone, this only brings back a fresh one on the branch
+		if err := devbox.Create(*trk.DevboxName, github.CloneURL(remote), entry.Branch); err != nil {
+			return fmt.Errorf("failed to recreate devbox: %w", err)
+		}
+	}
+
+	if err := o.db.InsertTrack(trk); err != nil {
+		return fmt.Errorf("failed to restore track in database: %w", err)
+	}
+	return nil
+}
+
+// undoSync resets a synced track's branch to its tip before the sync.
+func (o *Ops) undoSync(entry db.UndoEntry) error {
+	trk, err := o.getTrack(entry.Branch)
+	if err != nil {
+		return err
+	}
+	if trk.Path == nil {
+		return fmt.Errorf("worktree track has no path")
+	}
+	workDir := *trk.Path
+
+	dirty, err := git.IsDirty(workDir)
+	if err != nil {
+		return fmt.Errorf("failed to check worktree status: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("worktree %s has uncommitted changes, commit or stash them first", workDir)
+	}
+
+	if err := git.ResetHard(workDir, entry.LocalSHA); err != nil {
+		return fmt.Errorf("failed to reset %s: %w", entry.Branch, err)
+	}
+	_ = o.db.UpdateHeadSHA(o.config.Repo.Remote, entry.Branch, entry.LocalSHA)
+	return nil
+}
+
+// RestoreRemote force-pushes the remote branch of an undone entry back to its tip
+// before the operation. It does nothing if the operation didn't touch the remote.
+func (o *Ops) RestoreRemote(entry db.UndoEntry) error {
+	if entry.RemoteBranch == "" {
+		return nil
+	}
+	if err := git.PushForceTo(o.config.Repo.Path, entry.RemoteName, entry.RemoteSHA, "refs/heads/"+entry.RemoteBranch); err != nil {
+		return fmt.Errorf("failed to restore %s/%s: %w", entry.RemoteName, entry.RemoteBranch, err)
+	}
+	o.recordEvent(db.Event{
+		Branch:   entry.Branch,
+		Kind:     db.EventPush,
+		Detail:   fmt.Sprintf("restored %s/%s on undo", entry.RemoteName, entry.RemoteBranch),
+		AfterSHA: entry.RemoteSHA,
+	})
+	return nil
+}
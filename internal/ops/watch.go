@@ -0,0 +1,209 @@
+package ops
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/laurent/trak/internal/forge"
+	"github.com/laurent/trak/internal/github"
+	"github.com/laurent/trak/internal/track"
+)
+
+// Snapshot is the watched part of a track's status, saved between polls.
+type Snapshot struct {
+	PRNumber int    `json:"pr_number,omitempty"`
+	PRState  string `json:"pr_state,omitempty"` // "open", "closed", "merged"
+	CI       string `json:"ci,omitempty"`       // "success", "failure", "pending"
+	Review   string `json:"review,omitempty"`   // "approved", "changes_requested", "pending"
+	Comments int    `json:"comments"`           // Review comments, -1 if unknown
+}
+
+// Transition kinds.
+const (
+	TransitionPROpened         = "pr_opened"
+	TransitionCIPassed         = "ci_passed"
+	TransitionCIFailed         = "ci_failed"
+	TransitionApproved         = "approved"
+	TransitionChangesRequested = "changes_requested"
+	TransitionNewComments      = "new_comments"
+	TransitionMerged           = "merged"
+	TransitionClosed           = "closed"
+)
+
+// Transition is a change in a track's status between two polls.
+type Transition struct {
+	Branch  string
+	Kind    string
+	Message string
+	URL     string // PR URL, empty if the track has no PR
+}
+
+// PollTransitions refreshes the status of every track, compares it with the snapshot
+// saved by the previous poll and returns what changed. The first poll of a track only
+// saves its snapshot. Tracks whose status can't be fetched are skipped.
+func (o *Ops) PollTransitions() ([]Transition, error) {
+	remote := o.config.Repo.Remote
+
+	tracks, err := o.ListTracksWithStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []Transition
+	for _, t := range tracks {
+		if t.Err != nil {
+			continue
+		}
+		branch := t.Track.Branch
+
+		prev, hadPrev, err := o.loadSnapshot(branch)
+		if err != nil {
+			return nil, err
+		}
+
+		comments := -1
+		if t.Status.PR != nil && t.Status.PR.State == "open" {
+			comments = o.countReviewComments(t.Status.PR.Number)
+		}
+		if comments < 0 && hadPrev {
+			// Keep the last known count so a failed lookup doesn't look like new comments later
+			comments = prev.Comments
+		}
+		cur := snapshotFromStatus(t.Status, comments)
+
+		if hadPrev {
+			url := ""
+			if t.Status.PR != nil {
+				url = t.Status.PR.URL
+			}
+			transitions = append(transitions, diffSnapshots(branch, url, prev, cur)...)
+		}
+
+		data, err := json.Marshal(cur)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+		}
+		if err := o.db.SaveSnapshot(remote, branch, string(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	return transitions, nil
+}
+
+// loadSnapshot returns the saved snapshot of a track, and whether one existed.
+func (o *Ops) loadSnapshot(branch string) (Snapshot, bool, error) {
+	data, err := o.db.GetSnapshot(o.config.Repo.Remote, branch)
+	if err != nil || data == "" {
+		return Snapshot{}, false, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		// An unreadable snapshot is replaced on this poll
+		return Snapshot{}, false, nil
+	}
+	return s, true, nil
+}
+
+// countReviewComments returns the number of review comments on a PR, or -1 if unknown.
+// Review threads are only available on GitHub.
+func (o *Ops) countReviewComments(prNumber int) int {
+	if _, ok := o.forge.(forge.GitHub); !ok {
+		return -1
+	}
+	threads, err := github.ListReviewThreads(o.config.Repo.Remote, prNumber)
+	if err != nil {
+		return -1
+	}
+	count := 0
+	for _, th := range threads {
+		count += 1 + th.Replies
+	}
+	return count
+}
+
+// snapshotFromStatus extracts the watched fields from a track status.
+func snapshotFromStatus(status track.TrackStatus, comments int) Snapshot {
+	s := Snapshot{Comments: comments}
+	if status.PR == nil {
+		return s
+	}
+	s.PRNumber = status.PR.Number
+	s.PRState = status.PR.State
+
+	switch {
+	case status.CI == nil:
+	case status.CI.Failing:
+		s.CI = "failure"
+	case status.CI.Pending:
+		s.CI = "pending"
+	case status.CI.Passing:
+		s.CI = "success"
+	}
+
+	switch {
+	case status.Review == nil:
+	case status.Review.ChangesRequested:
+		s.Review = "changes_requested"
+	case status.Review.Approved:
+		s.Review = "approved"
+	case status.Review.Pending:
+		s.Review = "pending"
+	}
+	return s
+}
+
+// diffSnapshots returns the transitions from prev to cur.
+func diffSnapshots(branch, url string, prev, cur Snapshot) []Transition {
+	var out []Transition
+	add := func(kind, format string, args ...any) {
+		out = append(out, Transition{Branch: branch, Kind: kind, Message: fmt.Sprintf(format, args...), URL: url})
+	}
+
+	if cur.PRNumber == 0 {
+		return nil
+	}
+	if cur.PRNumber != prev.PRNumber {
+		add(TransitionPROpened, "PR #%d opened", cur.PRNumber)
+		// A different PR has nothing to compare against
+		return out
+	}
+
+	if cur.PRState != prev.PRState {
+		switch cur.PRState {
+		case "merged":
+			add(TransitionMerged, "PR #%d merged", cur.PRNumber)
+		case "closed":
+			add(TransitionClosed, "PR #%d closed", cur.PRNumber)
+		}
+	}
+
+	if cur.CI != prev.CI {
+		switch cur.CI {
+		case "success":
+			add(TransitionCIPassed, "CI passed on PR #%d", cur.PRNumber)
+		case "failure":
+			add(TransitionCIFailed, "CI failed on PR #%d", cur.PRNumber)
+		}
+	}
+
+	if cur.Review != prev.Review {
+		switch cur.Review {
+		case "approved":
+			add(TransitionApproved, "PR #%d approved", cur.PRNumber)
+		case "changes_requested":
+			add(TransitionChangesRequested, "Changes requested on PR #%d", cur.PRNumber)
+		}
+	}
+
+	if prev.Comments >= 0 && cur.Comments > prev.Comments {
+		n := cur.Comments - prev.Comments
+		noun := "comments"
+		if n == 1 {
+			noun = "comment"
+		}
+		add(TransitionNewComments, "%d new review %s on PR #%d", n, noun, cur.PRNumber)
+	}
+
+	return out
+}
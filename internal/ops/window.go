@@ -0,0 +1,263 @@
+package ops
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/laurent/trak/internal/config"
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/devbox"
+	"github.com/laurent/trak/internal/mux"
+	"github.com/laurent/trak/internal/tmux"
+	"github.com/laurent/trak/internal/track"
+	"github.com/laurent/trak/internal/zellij"
+)
+
+// trackTag is the tag of a track's window (see mux.WindowSpec.Tag). Row IDs are reused
+// after deletes, so tracks are told apart by repo and branch.
+func trackTag(trk *db.Track) string {
+	return trk.RemoteURL + ":" + trk.Branch
+}
+
+// sessionName returns the session new windows of a track go in, as configured
+// by tmux.session.
+func (o *Ops) sessionName(branch string) (string, error) {
+	name, err := o.config.Tmux.SessionName(o.config.Repo.Remote, branch)
+	if err != nil {
+		return "", err
+	}
+	if o.mux.Name() == mux.KindZellij {
+		return zellij.SanitizeSessionName(name), nil
+	}
+	return tmux.SanitizeSessionName(name), nil
+}
+
+// findTrackWindow returns a track's window, or nil if it has none, and records its ID.
+// A tagged window can be in any session, e.g. one named by an earlier session template;
+// untagged windows of multiplexers that can't tag them are found by name in the track's session.
+func (o *Ops) findTrackWindow(trk *db.Track) (*mux.Window, error) {
+	sessionName, err := o.sessionName(trk.Branch)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := o.mux.List()
+	if err != nil {
+		return nil, err
+	}
+
+	tag := trackTag(trk)
+	windowName := track.SanitizeForTmux(trk.Branch)
+	for _, w := range windows {
+		if w.Tag == tag || (w.Tag == "" && w.Session == sessionName && w.Name == windowName) {
+			o.saveTrackWindow(trk, w.ID)
+			return &w, nil
+		}
+	}
+	o.saveTrackWindow(trk, "")
+	return nil, nil
+}
+
+// saveTrackWindow records the window ID of a track if it changed. An empty ID clears it.
+func (o *Ops) saveTrackWindow(trk *db.Track, windowID string) {
+	current := ""
+	if trk.TmuxWindow != nil {
+		current = *trk.TmuxWindow
+	}
+	if current == windowID {
+		return
+	}
+
+	_ = o.db.SetTmuxWindow(trk.RemoteURL, trk.Branch, windowID)
+	trk.TmuxWindow = nil
+	if windowID != "" {
+		trk.TmuxWindow = &windowID
+	}
+}
+
+// tagTrackWindow tags a tmux window with its track and records the window's ID.
+func (o *Ops) tagTrackWindow(trk *db.Track, windowID string) error {
+	if err := tmux.SetWindowOption(windowID, tmux.TrackOption, trackTag(trk)); err != nil {
+		return fmt.Errorf("failed to tag window: %w", err)
+	}
+	o.saveTrackWindow(trk, windowID)
+	return nil
+}
+
+// ensureTrackWindow returns a track's window, creating it if needed. Worktree windows start
+// in the worktree, split into the layout configured for the branch with tmux; devbox
+// windows SSH into the devbox.
+func (o *Ops) ensureTrackWindow(trk *db.Track) (mux.Window, error) {
+	sessionName, err := o.sessionName(trk.Branch)
+	if err != nil {
+		return mux.Window{}, err
+	}
+
+	var workDir string
+	var layout *config.Layout
+	if trk.Type == db.TrackTypeWorktree && trk.Path != nil {
+		workDir = *trk.Path
+		if o.mux.Name() == mux.KindTmux {
+			if layout, err = o.config.Tmux.LayoutFor(trk.Branch); err != nil {
+				return mux.Window{}, err
+			}
+		}
+	}
+
+	spec := mux.WindowSpec{
+		// The name is only for display when windows can be tagged
+		Name: track.SanitizeForTmux(trk.Branch),
+		Dir:  workDir,
+		Tag:  trackTag(trk),
+	}
+	if trk.TmuxWindow != nil {
+		spec.ID = *trk.TmuxWindow
+	}
+	if layout != nil {
+		spec.Dir = paneDir(workDir, layout.Panes[0].Dir)
+	}
+
+	w, created, err := o.mux.EnsureWindow(sessionName, spec)
+	if err != nil {
+		return mux.Window{}, err
+	}
+	o.saveTrackWindow(trk, w.ID)
+	if !created {
+		return w, nil
+	}
+
+	if trk.Type == db.TrackTypeDevbox && trk.DevboxName != nil {
+		sshCmd, err := devbox.GetSSHCommand(*trk.DevboxName)
+		if err == nil && sshCmd != "" {
+			_ = o.mux.Run(w, sshCmd)
+		}
+	}
+	if layout != nil {
+		if err := applyLayout(w.Session, w.ID, workDir, layout); err != nil {
+			return mux.Window{}, fmt.Errorf("failed to apply tmux layout: %w", err)
+		}
+	}
+	return w, nil
+}
+
+// windowPollInterval is how often a closing window is checked for processes that are still running.
+var windowPollInterval = 100 * time.Millisecond
+
+// WindowWarnings returns why closing a track's window may lose work, e.g. an editor that may
+// have unsaved buffers, as configured by tmux.on_delete.warn. It returns nothing if the track
+// has no window, its window is kept on delete, or the multiplexer isn't tmux, the only one
+// that tells what runs in a window.
+func (o *Ops) WindowWarnings(branch string) ([]string, error) {
+	cleanup := o.config.Tmux.OnDelete
+	if cleanup.Keep || cleanup.WarnLevel() == config.WarnNever || o.mux.Name() != mux.KindTmux {
+		return nil, nil
+	}
+
+	trk, err := o.getTrack(branch)
+	if err != nil {
+		return nil, err
+	}
+	w, err := o.findTrackWindow(trk)
+	if err != nil || w == nil {
+		return nil, err
+	}
+	panes, err := tmux.ListPanes(w.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list panes: %w", err)
+	}
+
+	editors := cleanup.EditorCommands()
+	var warnings []string
+	for _, p := range panes {
+		switch {
+		case slices.Contains(editors, filepath.Base(p.Command)):
+			warnings = append(warnings, fmt.Sprintf("%s is running in pane %s and may have unsaved buffers", p.Command, p.ID))
+		case cleanup.WarnLevel() == config.WarnRunning && !isShell(p.Command):
+			warnings = append(warnings, fmt.Sprintf("%s is running in pane %s", p.Command, p.ID))
+		}
+	}
+	return warnings, nil
+}
+
+// releaseTrackWindow untags the window of a track that is deleted while its window is kept,
+// so that the window isn't taken for the window of a later track with the same branch.
+func (o *Ops) releaseTrackWindow(trk *db.Track) error {
+	if o.mux.Name() != mux.KindTmux {
+		return nil
+	}
+	w, err := o.findTrackWindow(trk)
+	if err != nil || w == nil {
+		return err
+	}
+	if err := tmux.UnsetWindowOption(w.ID, tmux.TrackOption); err != nil {
+		return fmt.Errorf("failed to untag window %s: %w", w.ID, err)
+	}
+	return nil
+}
+
+// closeTrackWindow stops what runs in a track's window and kills it. With tmux, panes running
+// something other than a shell get C-c, and the window is killed once they exit or the grace
+// period configured by tmux.on_delete.grace is over.
+func (o *Ops) closeTrackWindow(trk *db.Track) error {
+	w, err := o.findTrackWindow(trk)
+	if err != nil || w == nil {
+		return err
+	}
+	if o.mux.Name() == mux.KindTmux {
+		closed, err := o.stopTmuxWindow(w.ID)
+		if err != nil || closed {
+			return err
+		}
+	}
+
+	if err := o.mux.Kill(*w); err != nil {
+		return fmt.Errorf("failed to kill window %s: %w", w.ID, err)
+	}
+	return nil
+}
+
+// stopTmuxWindow sends C-c to the busy panes of a tmux window and waits for them to exit,
+// at most for the grace period. It refuses to stop the window trak runs in.
+// Returns whether the window closed by itself once its processes exited.
+func (o *Ops) stopTmuxWindow(windowID string) (bool, error) {
+	grace, err := o.config.Tmux.OnDelete.GracePeriod()
+	if err != nil {
+		return false, err
+	}
+	panes, err := tmux.ListPanes(windowID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list panes: %w", err)
+	}
+
+	// Killing the window trak runs in would kill trak before it's done
+	current := tmux.CurrentPane()
+	if slices.ContainsFunc(panes, func(p tmux.PaneInfo) bool { return p.ID == current }) {
+		return false, fmt.Errorf("trak is running in window %s, close it yourself", windowID)
+	}
+
+	busy := 0
+	for _, p := range panes {
+		if !isShell(p.Command) {
+			_ = tmux.Interrupt(p.ID)
+			busy++
+		}
+	}
+
+	deadline := time.Now().Add(grace)
+	for busy > 0 && time.Now().Before(deadline) {
+		time.Sleep(windowPollInterval)
+		panes, err := tmux.ListPanes(windowID)
+		if err != nil {
+			// The window closed by itself once its processes exited
+			return true, nil
+		}
+		busy = 0
+		for _, p := range panes {
+			if !isShell(p.Command) {
+				busy++
+			}
+		}
+	}
+	return false, nil
+}
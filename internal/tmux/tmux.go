@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
@@ -56,9 +57,31 @@ func ResetRunner() {
 	runner = &DefaultRunner{}
 }
 
+// SessionTarget quotes a session name for use as a target. The "=" prefix makes tmux
+// match the name exactly, so "api" doesn't match a session named "api-v2" and names
+// containing pattern characters work.
+func SessionTarget(session string) string {
+	return "=" + session
+}
+
+// WindowTarget quotes a window of a session for use as a target. Window IDs such as "@4"
+// are unique and used as is; window names are matched exactly.
+func WindowTarget(session, window string) string {
+	if !strings.HasPrefix(window, "@") {
+		window = "=" + window
+	}
+	return SessionTarget(session) + ":" + window
+}
+
+// SanitizeSessionName returns the name tmux gives a session created with the given name:
+// tmux replaces "." and ":" since they separate the parts of a target.
+func SanitizeSessionName(name string) string {
+	return strings.NewReplacer(".", "_", ":", "_").Replace(name)
+}
+
 // SessionExists checks if a tmux session with the given name exists.
 func SessionExists(name string) (bool, error) {
-	_, err := runner.Run("tmux", "has-session", "-t", name)
+	_, err := runner.Run("tmux", "has-session", "-t", SessionTarget(name))
 	if err != nil {
 		// tmux has-session returns exit code 1 if session doesn't exist
 		if strings.Contains(err.Error(), "exit status 1") || strings.Contains(err.Error(), "can't find session") {
@@ -77,10 +100,20 @@ func CreateSession(name string) error {
 	return err
 }
 
+// NewSession creates a detached session whose first window has the given name and
+// optional start directory. Returns the window's ID.
+func NewSession(name, windowName, startDir string) (string, error) {
+	args := []string{"new-session", "-d", "-s", name, "-n", windowName, "-P", "-F", "#{window_id}"}
+	if startDir != "" {
+		args = append(args, "-c", startDir)
+	}
+	return runner.Run("tmux", args...)
+}
+
 // WindowExists checks if a window exists in the given session.
 func WindowExists(session, windowName string) (bool, error) {
 	// List windows in the session and check if our window name is there
-	output, err := runner.Run("tmux", "list-windows", "-t", session, "-F", "#{window_name}")
+	output, err := runner.Run("tmux", "list-windows", "-t", SessionTarget(session), "-F", "#{window_name}")
 	if err != nil {
 		// Session might not exist
 		if strings.Contains(err.Error(), "can't find session") {
@@ -99,19 +132,19 @@ func WindowExists(session, windowName string) (bool, error) {
 }
 
 // CreateWindow creates a new window in a session with optional start directory.
-func CreateWindow(session, windowName, startDir string) error {
-	args := []string{"new-window", "-t", session, "-n", windowName}
+// Returns the window's ID, e.g. "@4", which unlike its name never changes.
+func CreateWindow(session, windowName, startDir string) (string, error) {
+	args := []string{"new-window", "-t", SessionTarget(session) + ":", "-n", windowName, "-P", "-F", "#{window_id}"}
 	if startDir != "" {
 		args = append(args, "-c", startDir)
 	}
-	_, err := runner.Run("tmux", args...)
-	return err
+	return runner.Run("tmux", args...)
 }
 
 // SwitchToWindow switches to a window in the given session.
 // This only works when already inside tmux.
 func SwitchToWindow(session, windowName string) error {
-	target := fmt.Sprintf("%s:%s", session, windowName)
+	target := WindowTarget(session, windowName)
 	_, err := runner.Run("tmux", "switch-client", "-t", target)
 	return err
 }
@@ -119,7 +152,7 @@ func SwitchToWindow(session, windowName string) error {
 // AttachSession attaches to a session.
 // This should be used when not inside tmux.
 func AttachSession(session string) error {
-	return runner.Exec("tmux", "attach-session", "-t", session)
+	return runner.Exec("tmux", "attach-session", "-t", SessionTarget(session))
 }
 
 // IsInsideTmux returns true if currently running inside tmux.
@@ -130,29 +163,218 @@ func IsInsideTmux() bool {
 // RunInWindow sends a command to run in a specific window.
 // Uses send-keys to type the command and execute it.
 func RunInWindow(session, windowName, command string) error {
-	target := fmt.Sprintf("%s:%s", session, windowName)
+	target := WindowTarget(session, windowName)
 	// send-keys with Enter to execute the command
 	_, err := runner.Run("tmux", "send-keys", "-t", target, command, "Enter")
 	return err
 }
 
+// TrackOption is the window user option trak tags track windows with.
+// Its value identifies the track, so windows are found even after they are renamed.
+const TrackOption = "@trak_track_id"
+
+// TaggedWindow is a window carrying a user option.
+type TaggedWindow struct {
+	ID      string // Window ID, e.g. "@4"
+	Session string
+	Name    string
+	Value   string // Value of the option
+}
+
+// SetWindowOption sets a window option, e.g. a user option like TrackOption.
+func SetWindowOption(target, option, value string) error {
+	_, err := runner.Run("tmux", "set-option", "-w", "-t", target, option, value)
+	return err
+}
+
+// UnsetWindowOption removes a window option, e.g. a user option like TrackOption.
+func UnsetWindowOption(target, option string) error {
+	_, err := runner.Run("tmux", "set-option", "-w", "-u", "-t", target, option)
+	return err
+}
+
+// DescribeWindow returns a window with the value of a user option, "" if it isn't set.
+// It fails if the window doesn't exist.
+func DescribeWindow(target, option string) (TaggedWindow, error) {
+	output, err := runner.Run("tmux", "display-message", "-p", "-t", target, taggedWindowFormat(option))
+	if err != nil {
+		return TaggedWindow{}, err
+	}
+	w, ok := parseTaggedWindow(output)
+	if !ok {
+		return TaggedWindow{}, fmt.Errorf("unexpected tmux output: %q", output)
+	}
+	return w, nil
+}
+
+// WindowID returns the ID of a window, e.g. "@4", given any target (see WindowTarget).
+func WindowID(target string) (string, error) {
+	return runner.Run("tmux", "display-message", "-p", "-t", target, "#{window_id}")
+}
+
+// TaggedWindows lists the windows of all sessions that have the given user option set.
+func TaggedWindows(option string) ([]TaggedWindow, error) {
+	output, err := runner.Run("tmux", "list-windows", "-a", "-F", taggedWindowFormat(option))
+	if err != nil {
+		// No server running means no windows
+		if strings.Contains(err.Error(), "no server running") || strings.Contains(err.Error(), "error connecting to") {
+			return []TaggedWindow{}, nil
+		}
+		return nil, err
+	}
+
+	windows := make([]TaggedWindow, 0)
+	for _, line := range strings.Split(output, "\n") {
+		if w, ok := parseTaggedWindow(line); ok && w.Value != "" {
+			windows = append(windows, w)
+		}
+	}
+	return windows, nil
+}
+
+// taggedWindowFormat is the format of a window line parsed by parseTaggedWindow.
+func taggedWindowFormat(option string) string {
+	return "#{window_id}\t#{session_name}\t#{window_name}\t#{" + option + "}"
+}
+
+func parseTaggedWindow(line string) (TaggedWindow, bool) {
+	fields := strings.SplitN(line, "\t", 4)
+	if len(fields) != 4 {
+		return TaggedWindow{}, false
+	}
+	return TaggedWindow{ID: fields[0], Session: fields[1], Name: fields[2], Value: fields[3]}, true
+}
+
+// SplitOptions configures a new pane created by SplitPane.
+type SplitOptions struct {
+	Right    bool   // Place the new pane to the right instead of below
+	Size     string // Lines/columns or a percentage, e.g. "20" or "30%"; "" splits in half
+	StartDir string // Start directory of the new pane
+}
+
+// SplitPane splits a pane (or the active pane of a window) and returns the new pane's ID, e.g. "%5".
+func SplitPane(target string, opts SplitOptions) (string, error) {
+	args := []string{"split-window", "-t", target, "-P", "-F", "#{pane_id}"}
+	if opts.Right {
+		args = append(args, "-h")
+	} else {
+		args = append(args, "-v")
+	}
+	if opts.Size != "" {
+		args = append(args, "-l", opts.Size)
+	}
+	if opts.StartDir != "" {
+		args = append(args, "-c", opts.StartDir)
+	}
+	return runner.Run("tmux", args...)
+}
+
+// ActivePane returns the ID of the active pane of a window, e.g. "%3".
+func ActivePane(session, windowName string) (string, error) {
+	target := WindowTarget(session, windowName)
+	return runner.Run("tmux", "display-message", "-p", "-t", target, "#{pane_id}")
+}
+
+// SelectLayout arranges the panes of a window with a tmux layout, e.g. "main-vertical".
+func SelectLayout(session, windowName, layout string) error {
+	target := WindowTarget(session, windowName)
+	_, err := runner.Run("tmux", "select-layout", "-t", target, layout)
+	return err
+}
+
+// SelectPane makes a pane the active pane of its window.
+func SelectPane(paneID string) error {
+	_, err := runner.Run("tmux", "select-pane", "-t", paneID)
+	return err
+}
+
+// RunInPane sends a command to run in a specific pane.
+func RunInPane(paneID, command string) error {
+	_, err := runner.Run("tmux", "send-keys", "-t", paneID, command, "Enter")
+	return err
+}
+
+// PaneInfo describes a pane of a window.
+type PaneInfo struct {
+	ID      string // Pane ID, e.g. "%3"
+	Index   int    // Position of the pane in its window, starting at pane-base-index
+	Command string // Command running in the pane, the shell when idle
+}
+
+// ListPanes returns the panes of a window, in order.
+func ListPanes(target string) ([]PaneInfo, error) {
+	output, err := runner.Run("tmux", "list-panes", "-t", target, "-F", "#{pane_id}\t#{pane_index}\t#{pane_current_command}")
+	if err != nil {
+		return nil, err
+	}
+
+	panes := make([]PaneInfo, 0)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		panes = append(panes, PaneInfo{ID: fields[0], Index: index, Command: fields[2]})
+	}
+	return panes, nil
+}
+
+// CapturePane returns the text of a pane, without colors and with wrapped lines joined.
+// lines is how many lines of scrollback above the visible pane to include; 0 includes
+// the whole scrollback.
+func CapturePane(target string, lines int) (string, error) {
+	start := "-"
+	if lines > 0 {
+		start = strconv.Itoa(-lines)
+	}
+	return runner.Run("tmux", "capture-pane", "-p", "-J", "-t", target, "-S", start)
+}
+
+// CurrentPane returns the ID of the pane trak runs in, or "" outside tmux.
+func CurrentPane() string {
+	return os.Getenv("TMUX_PANE")
+}
+
+// Interrupt sends C-c to a pane.
+func Interrupt(paneID string) error {
+	_, err := runner.Run("tmux", "send-keys", "-t", paneID, "C-c")
+	return err
+}
+
+// CurrentCommand returns the command running in the active pane of a window.
+// For an idle pane this is the shell (e.g. "zsh").
+func CurrentCommand(session, windowName string) (string, error) {
+	target := WindowTarget(session, windowName)
+	return runner.Run("tmux", "display-message", "-p", "-t", target, "#{pane_current_command}")
+}
+
 // SelectWindow selects a window (makes it the current window in the session).
 func SelectWindow(session, windowName string) error {
-	target := fmt.Sprintf("%s:%s", session, windowName)
+	target := WindowTarget(session, windowName)
 	_, err := runner.Run("tmux", "select-window", "-t", target)
 	return err
 }
 
 // KillWindow kills a window in a session.
 func KillWindow(session, windowName string) error {
-	target := fmt.Sprintf("%s:%s", session, windowName)
+	target := WindowTarget(session, windowName)
 	_, err := runner.Run("tmux", "kill-window", "-t", target)
 	return err
 }
 
+// DisplayMessage shows a message in the status line of the attached tmux clients.
+func DisplayMessage(message string) error {
+	_, err := runner.Run("tmux", "display-message", message)
+	return err
+}
+
 // KillSession kills an entire session.
 func KillSession(session string) error {
-	_, err := runner.Run("tmux", "kill-session", "-t", session)
+	_, err := runner.Run("tmux", "kill-session", "-t", SessionTarget(session))
 	return err
 }
 
@@ -176,7 +398,7 @@ func ListSessions() ([]string, error) {
 
 // ListWindows returns a list of all window names in a session.
 func ListWindows(session string) ([]string, error) {
-	output, err := runner.Run("tmux", "list-windows", "-t", session, "-F", "#{window_name}")
+	output, err := runner.Run("tmux", "list-windows", "-t", SessionTarget(session), "-F", "#{window_name}")
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +101,7 @@ func TestSessionExists(t *testing.T) {
 			if call.Name != "tmux" {
 				t.Errorf("Expected tmux command, got %s", call.Name)
 			}
-			expectedArgs := []string{"has-session", "-t", tt.session}
+			expectedArgs := []string{"has-session", "-t", "=" + tt.session}
 			if !slicesEqual(call.Args, expectedArgs) {
 				t.Errorf("Expected args %v, got %v", expectedArgs, call.Args)
 			}
@@ -201,14 +201,14 @@ func TestCreateWindow(t *testing.T) {
 			session:      "mysession",
 			windowName:   "mywindow",
 			startDir:     "",
-			expectedArgs: []string{"new-window", "-t", "mysession", "-n", "mywindow"},
+			expectedArgs: []string{"new-window", "-t", "=mysession:", "-n", "mywindow", "-P", "-F", "#{window_id}"},
 		},
 		{
 			name:         "with start dir",
 			session:      "mysession",
 			windowName:   "mywindow",
 			startDir:     "/home/user/project",
-			expectedArgs: []string{"new-window", "-t", "mysession", "-n", "mywindow", "-c", "/home/user/project"},
+			expectedArgs: []string{"new-window", "-t", "=mysession:", "-n", "mywindow", "-P", "-F", "#{window_id}", "-c", "/home/user/project"},
 		},
 	}
 
@@ -218,7 +218,7 @@ func TestCreateWindow(t *testing.T) {
 			SetRunner(mock)
 			defer ResetRunner()
 
-			err := CreateWindow(tt.session, tt.windowName, tt.startDir)
+			_, err := CreateWindow(tt.session, tt.windowName, tt.startDir)
 			if err != nil {
 				t.Errorf("CreateWindow() error = %v", err)
 			}
@@ -248,7 +248,7 @@ func TestSwitchToWindow(t *testing.T) {
 		t.Fatalf("Expected 1 call, got %d", len(mock.Calls))
 	}
 	call := mock.Calls[0]
-	expectedArgs := []string{"switch-client", "-t", "mysession:mywindow"}
+	expectedArgs := []string{"switch-client", "-t", "=mysession:=mywindow"}
 	if !slicesEqual(call.Args, expectedArgs) {
 		t.Errorf("Expected args %v, got %v", expectedArgs, call.Args)
 	}
@@ -271,7 +271,7 @@ func TestAttachSession(t *testing.T) {
 	if call.Method != "Exec" {
 		t.Errorf("Expected Exec method, got %s", call.Method)
 	}
-	expectedArgs := []string{"attach-session", "-t", "mysession"}
+	expectedArgs := []string{"attach-session", "-t", "=mysession"}
 	if !slicesEqual(call.Args, expectedArgs) {
 		t.Errorf("Expected args %v, got %v", expectedArgs, call.Args)
 	}
@@ -309,7 +309,7 @@ func TestRunInWindow(t *testing.T) {
 		t.Fatalf("Expected 1 call, got %d", len(mock.Calls))
 	}
 	call := mock.Calls[0]
-	expectedArgs := []string{"send-keys", "-t", "mysession:mywindow", "ls -la", "Enter"}
+	expectedArgs := []string{"send-keys", "-t", "=mysession:=mywindow", "ls -la", "Enter"}
 	if !slicesEqual(call.Args, expectedArgs) {
 		t.Errorf("Expected args %v, got %v", expectedArgs, call.Args)
 	}
@@ -400,7 +400,7 @@ func TestKillWindow(t *testing.T) {
 		t.Fatalf("Expected 1 call, got %d", len(mock.Calls))
 	}
 	call := mock.Calls[0]
-	expectedArgs := []string{"kill-window", "-t", "mysession:mywindow"}
+	expectedArgs := []string{"kill-window", "-t", "=mysession:=mywindow"}
 	if !slicesEqual(call.Args, expectedArgs) {
 		t.Errorf("Expected args %v, got %v", expectedArgs, call.Args)
 	}
@@ -420,7 +420,7 @@ func TestKillSession(t *testing.T) {
 		t.Fatalf("Expected 1 call, got %d", len(mock.Calls))
 	}
 	call := mock.Calls[0]
-	expectedArgs := []string{"kill-session", "-t", "mysession"}
+	expectedArgs := []string{"kill-session", "-t", "=mysession"}
 	if !slicesEqual(call.Args, expectedArgs) {
 		t.Errorf("Expected args %v, got %v", expectedArgs, call.Args)
 	}
@@ -440,7 +440,7 @@ func TestSelectWindow(t *testing.T) {
 		t.Fatalf("Expected 1 call, got %d", len(mock.Calls))
 	}
 	call := mock.Calls[0]
-	expectedArgs := []string{"select-window", "-t", "mysession:mywindow"}
+	expectedArgs := []string{"select-window", "-t", "=mysession:=mywindow"}
 	if !slicesEqual(call.Args, expectedArgs) {
 		t.Errorf("Expected args %v, got %v", expectedArgs, call.Args)
 	}
@@ -471,7 +471,7 @@ func TestCommandConstruction(t *testing.T) {
 			name:         "has-session",
 			callFunc:     func() { SessionExists("test") },
 			expectedCmd:  "tmux",
-			expectedArgs: []string{"has-session", "-t", "test"},
+			expectedArgs: []string{"has-session", "-t", "=test"},
 		},
 		{
 			name:         "new-session detached",
@@ -483,25 +483,25 @@ func TestCommandConstruction(t *testing.T) {
 			name:         "list-windows format",
 			callFunc:     func() { WindowExists("sess", "win") },
 			expectedCmd:  "tmux",
-			expectedArgs: []string{"list-windows", "-t", "sess", "-F", "#{window_name}"},
+			expectedArgs: []string{"list-windows", "-t", "=sess", "-F", "#{window_name}"},
 		},
 		{
 			name:         "new-window with dir",
 			callFunc:     func() { CreateWindow("sess", "win", "/tmp") },
 			expectedCmd:  "tmux",
-			expectedArgs: []string{"new-window", "-t", "sess", "-n", "win", "-c", "/tmp"},
+			expectedArgs: []string{"new-window", "-t", "=sess:", "-n", "win", "-P", "-F", "#{window_id}", "-c", "/tmp"},
 		},
 		{
 			name:         "switch-client target format",
 			callFunc:     func() { SwitchToWindow("sess", "win") },
 			expectedCmd:  "tmux",
-			expectedArgs: []string{"switch-client", "-t", "sess:win"},
+			expectedArgs: []string{"switch-client", "-t", "=sess:=win"},
 		},
 		{
 			name:         "send-keys with enter",
 			callFunc:     func() { RunInWindow("sess", "win", "echo hello") },
 			expectedCmd:  "tmux",
-			expectedArgs: []string{"send-keys", "-t", "sess:win", "echo hello", "Enter"},
+			expectedArgs: []string{"send-keys", "-t", "=sess:=win", "echo hello", "Enter"},
 		},
 	}
 
@@ -575,3 +575,303 @@ func TestErrorPropagation(t *testing.T) {
 		t.Errorf("Expected error message to contain 'connection refused', got: %v", err)
 	}
 }
+
+func TestCurrentCommand(t *testing.T) {
+	mock := &MockRunner{
+		RunFunc: func(name string, args ...string) (string, error) {
+			return "zsh", nil
+		},
+	}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	cmd, err := CurrentCommand("mysession", "mywindow")
+	if err != nil {
+		t.Fatalf("CurrentCommand() error = %v", err)
+	}
+	if cmd != "zsh" {
+		t.Errorf("CurrentCommand() = %q, want %q", cmd, "zsh")
+	}
+
+	expectedArgs := []string{"display-message", "-p", "-t", "=mysession:=mywindow", "#{pane_current_command}"}
+	if !slicesEqual(mock.Calls[0].Args, expectedArgs) {
+		t.Errorf("Expected args %v, got %v", expectedArgs, mock.Calls[0].Args)
+	}
+}
+
+func TestDisplayMessage(t *testing.T) {
+	mock := &MockRunner{}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if err := DisplayMessage("CI passed on feature"); err != nil {
+		t.Errorf("DisplayMessage() error = %v", err)
+	}
+
+	if len(mock.Calls) != 1 {
+		t.Fatalf("Expected 1 call, got %d", len(mock.Calls))
+	}
+	expectedArgs := []string{"display-message", "CI passed on feature"}
+	if !slicesEqual(mock.Calls[0].Args, expectedArgs) {
+		t.Errorf("Expected args %v, got %v", expectedArgs, mock.Calls[0].Args)
+	}
+}
+
+func TestSplitPane(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     SplitOptions
+		wantArgs []string
+	}{
+		{
+			name:     "below, half",
+			opts:     SplitOptions{},
+			wantArgs: []string{"split-window", "-t", "%1", "-P", "-F", "#{pane_id}", "-v"},
+		},
+		{
+			name:     "right with size and dir",
+			opts:     SplitOptions{Right: true, Size: "30%", StartDir: "/tmp/wt"},
+			wantArgs: []string{"split-window", "-t", "%1", "-P", "-F", "#{pane_id}", "-h", "-l", "30%", "-c", "/tmp/wt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockRunner{
+				RunFunc: func(name string, args ...string) (string, error) {
+					return "%2", nil
+				},
+			}
+			SetRunner(mock)
+			defer ResetRunner()
+
+			pane, err := SplitPane("%1", tt.opts)
+			if err != nil {
+				t.Fatalf("SplitPane() error = %v", err)
+			}
+			if pane != "%2" {
+				t.Errorf("SplitPane() = %q, want %%2", pane)
+			}
+			if !slicesEqual(mock.Calls[0].Args, tt.wantArgs) {
+				t.Errorf("Expected args %v, got %v", tt.wantArgs, mock.Calls[0].Args)
+			}
+		})
+	}
+}
+
+func TestPaneCommands(t *testing.T) {
+	mock := &MockRunner{}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	ActivePane("mysession", "mywindow")
+	SelectLayout("mysession", "mywindow", "main-vertical")
+	SelectPane("%3")
+	RunInPane("%3", "nvim")
+
+	expected := [][]string{
+		{"display-message", "-p", "-t", "=mysession:=mywindow", "#{pane_id}"},
+		{"select-layout", "-t", "=mysession:=mywindow", "main-vertical"},
+		{"select-pane", "-t", "%3"},
+		{"send-keys", "-t", "%3", "nvim", "Enter"},
+	}
+	if len(mock.Calls) != len(expected) {
+		t.Fatalf("Expected %d calls, got %d", len(expected), len(mock.Calls))
+	}
+	for i, want := range expected {
+		if !slicesEqual(mock.Calls[i].Args, want) {
+			t.Errorf("call %d: expected args %v, got %v", i, want, mock.Calls[i].Args)
+		}
+	}
+}
+
+func TestWindowOptions(t *testing.T) {
+	mock := &MockRunner{
+		RunFunc: func(name string, args ...string) (string, error) {
+			return "@4\tmy.repo\tfeature\t12", nil
+		},
+	}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if err := SetWindowOption("@4", TrackOption, "12"); err != nil {
+		t.Fatalf("SetWindowOption() error = %v", err)
+	}
+	w, err := DescribeWindow("@4", TrackOption)
+	want := TaggedWindow{ID: "@4", Session: "my.repo", Name: "feature", Value: "12"}
+	if err != nil || w != want {
+		t.Errorf("DescribeWindow() = %+v, %v, want %+v", w, err, want)
+	}
+	if _, err := WindowID(WindowTarget("trak", "feature")); err != nil {
+		t.Fatalf("WindowID() error = %v", err)
+	}
+	if err := UnsetWindowOption("@4", TrackOption); err != nil {
+		t.Fatalf("UnsetWindowOption() error = %v", err)
+	}
+
+	expected := [][]string{
+		{"set-option", "-w", "-t", "@4", "@trak_track_id", "12"},
+		{"display-message", "-p", "-t", "@4", "#{window_id}\t#{session_name}\t#{window_name}\t#{@trak_track_id}"},
+		{"display-message", "-p", "-t", "=trak:=feature", "#{window_id}"},
+		{"set-option", "-w", "-u", "-t", "@4", "@trak_track_id"},
+	}
+	for i, want := range expected {
+		if !slicesEqual(mock.Calls[i].Args, want) {
+			t.Errorf("call %d: expected args %v, got %v", i, want, mock.Calls[i].Args)
+		}
+	}
+}
+
+func TestTaggedWindows(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		err     error
+		want    []TaggedWindow
+		wantErr bool
+	}{
+		{
+			name:   "skips untagged windows",
+			output: "@1\ttrak\tzsh\t\n@4\ttrak\tfeature\t12\n@7\twork\trenamed\t15",
+			want: []TaggedWindow{
+				{ID: "@4", Session: "trak", Name: "feature", Value: "12"},
+				{ID: "@7", Session: "work", Name: "renamed", Value: "15"},
+			},
+		},
+		{
+			name: "no server",
+			err:  fmt.Errorf("no server running on /tmp/tmux-1000/default"),
+			want: []TaggedWindow{},
+		},
+		{
+			name:    "other error",
+			err:     fmt.Errorf("boom"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockRunner{
+				RunFunc: func(name string, args ...string) (string, error) {
+					return tt.output, tt.err
+				},
+			}
+			SetRunner(mock)
+			defer ResetRunner()
+
+			got, err := TaggedWindows(TrackOption)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TaggedWindows() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("TaggedWindows() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("window %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+			wantArgs := []string{"list-windows", "-a", "-F", "#{window_id}\t#{session_name}\t#{window_name}\t#{@trak_track_id}"}
+			if !slicesEqual(mock.Calls[0].Args, wantArgs) {
+				t.Errorf("Expected args %v, got %v", wantArgs, mock.Calls[0].Args)
+			}
+		})
+	}
+}
+
+func TestTargets(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{SessionTarget("api"), "=api"},
+		{WindowTarget("api", "feature"), "=api:=feature"},
+		{WindowTarget("api", "@4"), "=api:@4"},
+		{SanitizeSessionName("my.repo:v2"), "my_repo_v2"},
+		{SanitizeSessionName("trak-feature/login"), "trak-feature/login"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestNewSession(t *testing.T) {
+	mock := &MockRunner{
+		RunFunc: func(name string, args ...string) (string, error) {
+			return "@7", nil
+		},
+	}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	id, err := NewSession("trak-feature", "feature", "/wt")
+	if err != nil || id != "@7" {
+		t.Fatalf("NewSession() = %q, %v, want @7", id, err)
+	}
+	expectedArgs := []string{"new-session", "-d", "-s", "trak-feature", "-n", "feature", "-P", "-F", "#{window_id}", "-c", "/wt"}
+	if !slicesEqual(mock.Calls[0].Args, expectedArgs) {
+		t.Errorf("Expected args %v, got %v", expectedArgs, mock.Calls[0].Args)
+	}
+}
+
+func TestListPanes(t *testing.T) {
+	mock := &MockRunner{
+		RunFunc: func(name string, args ...string) (string, error) {
+			if args[0] == "send-keys" {
+				return "", nil
+			}
+			return "%1\t0\tnvim\n%2\t1\tzsh", nil
+		},
+	}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	panes, err := ListPanes("@4")
+	if err != nil {
+		t.Fatalf("ListPanes() error = %v", err)
+	}
+	want := []PaneInfo{{ID: "%1", Index: 0, Command: "nvim"}, {ID: "%2", Index: 1, Command: "zsh"}}
+	if len(panes) != 2 || panes[0] != want[0] || panes[1] != want[1] {
+		t.Errorf("ListPanes() = %v, want %v", panes, want)
+	}
+	if err := Interrupt("%1"); err != nil {
+		t.Fatalf("Interrupt() error = %v", err)
+	}
+
+	expected := [][]string{
+		{"list-panes", "-t", "@4", "-F", "#{pane_id}\t#{pane_index}\t#{pane_current_command}"},
+		{"send-keys", "-t", "%1", "C-c"},
+	}
+	for i, want := range expected {
+		if !slicesEqual(mock.Calls[i].Args, want) {
+			t.Errorf("call %d: expected args %v, got %v", i, want, mock.Calls[i].Args)
+		}
+	}
+}
+
+func TestCapturePane(t *testing.T) {
+	mock := &MockRunner{
+		RunFunc: func(name string, args ...string) (string, error) {
+			return "ok\tgithub.com/laurent/trak", nil
+		},
+	}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if out, err := CapturePane("%1", 200); err != nil || out != "ok\tgithub.com/laurent/trak" {
+		t.Fatalf("CapturePane() = %q, %v", out, err)
+	}
+	if _, err := CapturePane("%1", 0); err != nil {
+		t.Fatalf("CapturePane() error = %v", err)
+	}
+
+	expected := [][]string{
+		{"capture-pane", "-p", "-J", "-t", "%1", "-S", "-200"},
+		{"capture-pane", "-p", "-J", "-t", "%1", "-S", "-"},
+	}
+	for i, want := range expected {
+		if !slicesEqual(mock.Calls[i].Args, want) {
+			t.Errorf("call %d: expected args %v, got %v", i, want, mock.Calls[i].Args)
+		}
+	}
+}
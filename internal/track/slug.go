@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // nonAlphanumericRegex matches any character that is not alphanumeric or hyphen.
@@ -89,3 +90,31 @@ func SanitizeForTmux(branch string) string {
 
 	return result
 }
+
+// Branch match ranks returned by MatchBranch, best first.
+const (
+	MatchExact       = iota // The branch itself
+	MatchSubstring          // The branch contains the query, ignoring case
+	MatchSubsequence        // The branch contains the characters of the query in order, ignoring case
+)
+
+// MatchBranch reports whether a branch matches a query typed to find it, and how well.
+// Example: "auth" matches "feature/auth-refactor" as a substring
+// Example: "far" matches "feature/auth-refactor" as a subsequence
+func MatchBranch(query, branch string) (int, bool) {
+	if branch == query {
+		return MatchExact, true
+	}
+	q, b := strings.ToLower(query), strings.ToLower(branch)
+	if strings.Contains(b, q) {
+		return MatchSubstring, true
+	}
+	for _, r := range q {
+		i := strings.IndexRune(b, r)
+		if i < 0 {
+			return 0, false
+		}
+		b = b[i+utf8.RuneLen(r):]
+	}
+	return MatchSubsequence, true
+}
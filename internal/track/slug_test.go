@@ -198,3 +198,53 @@ func TestItoa(t *testing.T) {
 		})
 	}
 }
+
+func TestTrackStatusMergeable(t *testing.T) {
+	openPR := &PRStatus{Number: 1, State: "open"}
+	approved := &ReviewStatus{Approved: true}
+	passing := &CIStatus{Passing: true}
+
+	tests := []struct {
+		name   string
+		status TrackStatus
+		want   bool
+	}{
+		{"approved and passing", TrackStatus{PR: openPR, Review: approved, CI: passing}, true},
+		{"no PR", TrackStatus{Review: approved, CI: passing}, false},
+		{"merged PR", TrackStatus{PR: &PRStatus{Number: 1, State: "merged"}, Review: approved, CI: passing}, false},
+		{"not approved", TrackStatus{PR: openPR, Review: &ReviewStatus{Pending: true}, CI: passing}, false},
+		{"CI pending", TrackStatus{PR: openPR, Review: approved, CI: &CIStatus{Pending: true}}, false},
+		{"no CI", TrackStatus{PR: openPR, Review: approved}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Mergeable(); got != tt.want {
+				t.Errorf("Mergeable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchBranch(t *testing.T) {
+	tests := []struct {
+		query, branch string
+		wantRank      int
+		wantOK        bool
+	}{
+		{"main", "main", MatchExact, true},
+		{"auth", "feature/auth-refactor", MatchSubstring, true},
+		{"AUTH", "feature/Auth-refactor", MatchSubstring, true},
+		{"far", "feature/auth-refactor", MatchSubsequence, true},
+		{"", "main", MatchSubstring, true},
+		{"zz", "feature/auth-refactor", 0, false},
+		{"rf", "feature/ré-f", MatchSubsequence, true},
+	}
+
+	for _, tt := range tests {
+		rank, ok := MatchBranch(tt.query, tt.branch)
+		if rank != tt.wantRank || ok != tt.wantOK {
+			t.Errorf("MatchBranch(%q, %q) = %d, %v, want %d, %v", tt.query, tt.branch, rank, ok, tt.wantRank, tt.wantOK)
+		}
+	}
+}
@@ -49,6 +49,14 @@ type TrackStatus struct {
 	SHAMismatch bool        // True if local SHA doesn't match expected (force-push detected)
 }
 
+// Mergeable reports whether the track's PR is open, approved and passing CI.
+func (s TrackStatus) Mergeable() bool {
+	if s.PR == nil || s.PR.State != "open" {
+		return false
+	}
+	return s.Review != nil && s.Review.Approved && s.CI != nil && s.CI.Passing
+}
+
 // PRStatus represents the state of a pull request.
 type PRStatus struct {
 	Number int
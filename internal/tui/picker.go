@@ -0,0 +1,276 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/ops"
+	"github.com/laurent/trak/internal/track"
+)
+
+// pickerHeight is the number of tracks the picker shows at once.
+const pickerHeight = 10
+
+// pickerStatusMsg carries the status of one track, loaded in the background.
+type pickerStatusMsg struct {
+	branch string
+	status track.TrackStatus
+	err    error
+}
+
+// pickerModel is an inline fuzzy finder over the tracks of the repo, for commands run
+// without a branch. It takes a few lines below the prompt instead of the whole screen.
+type pickerModel struct {
+	ops       *ops.Ops
+	multi     bool
+	input     textinput.Model
+	tracks    []db.Track
+	statuses  map[string]*pickerStatusMsg // branch -> status, missing while loading
+	matches   []int                       // indexes into tracks, best match first
+	cursor    int
+	offset    int             // first match shown
+	selected  map[string]bool // branch -> selected, in multi mode
+	done      bool
+	cancelled bool
+}
+
+func newPicker(o *ops.Ops, tracks []db.Track, query string, multi bool) pickerModel {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "type to filter"
+	ti.PromptStyle = inputStyle
+	ti.TextStyle = normalStyle
+	ti.SetValue(query)
+	ti.Focus()
+
+	m := pickerModel{
+		ops:      o,
+		multi:    multi,
+		input:    ti,
+		tracks:   tracks,
+		statuses: make(map[string]*pickerStatusMsg),
+		selected: make(map[string]bool),
+	}
+	m.filter()
+	return m
+}
+
+// filter matches the tracks against the query, keeping the most recent first within a rank.
+func (m *pickerModel) filter() {
+	query := m.input.Value()
+	ranks := make(map[int]int)
+	m.matches = m.matches[:0]
+	for i, trk := range m.tracks {
+		if rank, ok := track.MatchBranch(query, trk.Branch); ok {
+			ranks[i] = rank
+			m.matches = append(m.matches, i)
+		}
+	}
+	sort.SliceStable(m.matches, func(a, b int) bool {
+		return ranks[m.matches[a]] < ranks[m.matches[b]]
+	})
+	m.cursor = 0
+	m.offset = 0
+}
+
+func (m pickerModel) loadStatus(trk db.Track) tea.Cmd {
+	return func() tea.Msg {
+		status, err := m.ops.RefreshTrackStatus(trk)
+		return pickerStatusMsg{branch: trk.Branch, status: status, err: err}
+	}
+}
+
+// Init starts loading the status of every track.
+func (m pickerModel) Init() tea.Cmd {
+	cmds := []tea.Cmd{textinput.Blink}
+	if m.ops != nil {
+		for _, trk := range m.tracks {
+			cmds = append(cmds, m.loadStatus(trk))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update handles key presses and loaded statuses.
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case pickerStatusMsg:
+		m.statuses[msg.branch] = &msg
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			m.cancelled = true
+			return m, tea.Quit
+		case "up", "ctrl+p":
+			m.move(-1)
+			return m, nil
+		case "down", "ctrl+n":
+			m.move(1)
+			return m, nil
+		case "tab":
+			if m.multi {
+				if branch, ok := m.current(); ok {
+					m.selected[branch] = !m.selected[branch]
+				}
+				m.move(1)
+			}
+			return m, nil
+		case "enter":
+			if len(m.Selected()) == 0 {
+				branch, ok := m.current()
+				if !ok {
+					return m, nil
+				}
+				m.selected[branch] = true
+			}
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	query := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != query {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// move moves the cursor by delta, scrolling to keep it visible.
+func (m *pickerModel) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.matches)-1))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+pickerHeight {
+		m.offset = m.cursor - pickerHeight + 1
+	}
+}
+
+// current returns the branch under the cursor.
+func (m pickerModel) current() (string, bool) {
+	if len(m.matches) == 0 {
+		return "", false
+	}
+	return m.tracks[m.matches[m.cursor]].Branch, true
+}
+
+// Selected returns the picked branches in list order, none if the picker was cancelled.
+func (m pickerModel) Selected() []string {
+	if m.cancelled {
+		return nil
+	}
+	var branches []string
+	for _, trk := range m.tracks {
+		if m.selected[trk.Branch] {
+			branches = append(branches, trk.Branch)
+		}
+	}
+	return branches
+}
+
+// View renders the prompt and the matching tracks.
+func (m pickerModel) View() string {
+	if m.done || m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(m.input.View())
+	b.WriteString("\n")
+	mark := ""
+	if m.multi {
+		mark = "    "
+	}
+	b.WriteString(headerStyle.Render("  " + formatPickerRow(mark, "BRANCH", "TYPE", "GIT", "PR", "CI", "REVIEW", "AGE")))
+	b.WriteString("\n")
+
+	end := min(m.offset+pickerHeight, len(m.matches))
+	for i := m.offset; i < end; i++ {
+		row := m.renderTrack(m.tracks[m.matches[i]])
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render("> " + row))
+		} else {
+			b.WriteString(normalStyle.Render("  " + row))
+		}
+		b.WriteString("\n")
+	}
+
+	hints := "enter to pick, esc to cancel"
+	if m.multi {
+		hints = "tab to select, enter to pick, esc to cancel"
+	}
+	b.WriteString(helpStyle.Render(fmt.Sprintf("%d/%d · %s", len(m.matches), len(m.tracks), hints)))
+	return b.String()
+}
+
+// renderTrack formats a track like the main table, with "…" for a status still loading.
+func (m pickerModel) renderTrack(trk db.Track) string {
+	mark := ""
+	if m.multi {
+		mark = "[ ] "
+		if m.selected[trk.Branch] {
+			mark = "[x] "
+		}
+	}
+
+	trackType := string(trk.Type)
+	if trk.Review {
+		trackType = "review"
+	}
+
+	gitStr, prStr, ciStr, reviewStr := "…", "…", "…", "…"
+	if s := m.statuses[trk.Branch]; s != nil {
+		gitStr = s.status.GitStatus.String()
+		prStr, ciStr, reviewStr = "—", "—", "—"
+		if s.status.PR != nil {
+			prStr = fmt.Sprintf("#%d", s.status.PR.Number)
+		} else if s.err != nil {
+			prStr = "!"
+		}
+		if s.status.CI != nil {
+			ciStr = s.status.CI.Symbol()
+		}
+		if s.status.Review != nil {
+			reviewStr = s.status.Review.Symbol()
+		}
+	}
+
+	return formatPickerRow(mark, truncate(trk.Branch, 30), trackType, gitStr, prStr, ciStr, reviewStr, formatAge(trk.CreatedAt))
+}
+
+func formatPickerRow(mark, branch, trackType, gitStr, prStr, ciStr, reviewStr, age string) string {
+	return mark + pad(branch, 31) + pad(trackType, 9) + pad(gitStr, 9) + pad(prStr, 7) + pad(ciStr, 5) + pad(reviewStr, 7) + age
+}
+
+// pad pads s with spaces to width columns; fmt pads by bytes, which is off for symbols.
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-lipgloss.Width(s)))
+}
+
+// PickTracks lets the user pick tracks of the repo with a fuzzy finder prefilled with
+// query, drawn on stderr so that stdout stays free for the command's output. Several
+// tracks can be picked in multi mode. It returns no branches if the user cancelled.
+func PickTracks(o *ops.Ops, query string, multi bool) ([]string, error) {
+	tracks, err := o.RepoTracks()
+	if err != nil {
+		return nil, err
+	}
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("no tracks")
+	}
+
+	final, err := tea.NewProgram(newPicker(o, tracks, query, multi), tea.WithOutput(os.Stderr)).Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run track picker: %w", err)
+	}
+	return final.(pickerModel).Selected(), nil
+}
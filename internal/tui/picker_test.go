@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/track"
+)
+
+func pickerTracks() []db.Track {
+	return []db.Track{
+		{Branch: "fix/typo", Type: db.TrackTypeWorktree},
+		{Branch: "feature/auth-refactor", Type: db.TrackTypeWorktree},
+		{Branch: "auth", Type: db.TrackTypeDevbox},
+	}
+}
+
+func typeQuery(m pickerModel, query string) pickerModel {
+	for _, r := range query {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(pickerModel)
+	}
+	return m
+}
+
+func pressKey(m pickerModel, k tea.KeyType) (pickerModel, tea.Cmd) {
+	updated, cmd := m.Update(tea.KeyMsg{Type: k})
+	return updated.(pickerModel), cmd
+}
+
+func TestPickerFilter(t *testing.T) {
+	m := newPicker(nil, pickerTracks(), "", false)
+	if len(m.matches) != 3 {
+		t.Fatalf("expected all tracks without a query, got %v", m.matches)
+	}
+
+	// The exact match comes first, then substrings, most recent first
+	m = typeQuery(m, "auth")
+	if want := []int{2, 1}; !reflect.DeepEqual(m.matches, want) {
+		t.Errorf("matches = %v, want %v", m.matches, want)
+	}
+
+	m = newPicker(nil, pickerTracks(), "ftyp", false)
+	if want := []int{0}; !reflect.DeepEqual(m.matches, want) {
+		t.Errorf("matches = %v, want %v", m.matches, want)
+	}
+}
+
+func TestPickerPick(t *testing.T) {
+	m := newPicker(nil, pickerTracks(), "", false)
+	m, _ = pressKey(m, tea.KeyDown)
+	m, cmd := pressKey(m, tea.KeyEnter)
+	if cmd == nil || !m.done {
+		t.Fatal("expected enter to finish the picker")
+	}
+	if got := m.Selected(); !reflect.DeepEqual(got, []string{"feature/auth-refactor"}) {
+		t.Errorf("Selected() = %v", got)
+	}
+	if m.View() != "" {
+		t.Error("expected the picker to clear itself once done")
+	}
+}
+
+func TestPickerMultiSelect(t *testing.T) {
+	m := newPicker(nil, pickerTracks(), "", true)
+	m, _ = pressKey(m, tea.KeyTab)
+	m, _ = pressKey(m, tea.KeyDown)
+	m, _ = pressKey(m, tea.KeyTab)
+	if !strings.Contains(m.View(), "[x] fix/typo") {
+		t.Errorf("expected the selection to show, got:\n%s", m.View())
+	}
+	m, _ = pressKey(m, tea.KeyEnter)
+
+	want := []string{"fix/typo", "auth"}
+	if got := m.Selected(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Selected() = %v, want %v", got, want)
+	}
+}
+
+func TestPickerCancel(t *testing.T) {
+	m := newPicker(nil, pickerTracks(), "", true)
+	m, _ = pressKey(m, tea.KeyTab)
+	m, _ = pressKey(m, tea.KeyEsc)
+	if got := m.Selected(); got != nil {
+		t.Errorf("expected nothing picked after cancelling, got %v", got)
+	}
+
+	// Enter does nothing when no track matches
+	m = newPicker(nil, pickerTracks(), "zzz", false)
+	if m, _ = pressKey(m, tea.KeyEnter); m.done {
+		t.Error("expected enter to do nothing without matches")
+	}
+}
+
+func TestPickerStatus(t *testing.T) {
+	m := newPicker(nil, pickerTracks(), "typo", false)
+	if !strings.Contains(m.View(), "…") {
+		t.Errorf("expected a loading placeholder, got:\n%s", m.View())
+	}
+
+	updated, _ := m.Update(pickerStatusMsg{
+		branch: "fix/typo",
+		status: track.TrackStatus{PR: &track.PRStatus{Number: 42}},
+	})
+	if view := updated.View(); !strings.Contains(view, "#42") || strings.Contains(view, "…") {
+		t.Errorf("expected the loaded status, got:\n%s", view)
+	}
+}
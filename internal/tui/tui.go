@@ -13,7 +13,10 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/github"
 	"github.com/laurent/trak/internal/ops"
+	"github.com/laurent/trak/internal/track"
 )
 
 // View represents the current view in the TUI.
@@ -24,6 +27,10 @@ const (
 	ViewRemoteBrowser
 	ViewNewTrack
 	ViewDeleteConfirm
+	ViewComments
+	ViewReviewQueue
+	ViewHistory
+	ViewOutput
 )
 
 // Model is the main bubbletea model for trak TUI.
@@ -38,6 +45,18 @@ type Model struct {
 	keys           KeyMap
 	tracks         []ops.TrackWithStatus
 	remoteBranches []ops.RemoteBranch
+	commentsTable  table.Model
+	threads        []github.ReviewThread
+	commentsBranch string
+	reviewTable    table.Model
+	reviewRequests []github.ReviewRequest
+	historyTable   table.Model
+	events         []db.Event
+	historyBranch  string
+	output         []string // Tail of the main pane of outputBranch's window
+	outputBranch   string
+	rateLimit      github.RateLimit
+	statusWarning  string // Why PR status is missing for some tracks, shown as a banner
 	loading        bool
 	notification   string
 	notifyTime     time.Time
@@ -48,7 +67,8 @@ type Model struct {
 	// New track input
 	textInput textinput.Model
 	// Delete confirmation
-	pendingDeleteBranch string
+	pendingDeleteBranch   string
+	pendingDeleteWarnings []string // Why closing the track's window may lose work
 }
 
 // KeyMap defines the keybindings for the TUI.
@@ -58,11 +78,18 @@ type KeyMap struct {
 	Enter       key.Binding
 	Refresh     key.Binding
 	Browse      key.Binding
+	Reviews     key.Binding
 	New         key.Binding
 	Delete      key.Binding
 	ForceDelete key.Binding
 	Sync        key.Binding
 	AI          key.Binding
+	RerunCI     key.Binding
+	Merge       key.Binding
+	Comments    key.Binding
+	Resolve     key.Binding
+	History     key.Binding
+	Output      key.Binding
 	Back        key.Binding
 	Quit        key.Binding
 	Help        key.Binding
@@ -93,6 +120,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("b"),
 			key.WithHelp("b", "browse remote"),
 		),
+		Reviews: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "review queue"),
+		),
 		New: key.NewBinding(
 			key.WithKeys("n"),
 			key.WithHelp("n", "new track"),
@@ -113,6 +144,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "run AI"),
 		),
+		RerunCI: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "re-run failed CI"),
+		),
+		Merge: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "merge PR"),
+		),
+		Comments: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "review comments"),
+		),
+		Resolve: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "resolve comment"),
+		),
+		History: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "history"),
+		),
+		Output: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "pane output"),
+		),
 		Back: key.NewBinding(
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "back"),
@@ -145,8 +200,9 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Enter},
-		{k.Refresh, k.Browse, k.New},
-		{k.Sync, k.AI, k.Delete, k.ForceDelete},
+		{k.Refresh, k.Browse, k.Reviews, k.New},
+		{k.Sync, k.AI, k.RerunCI, k.Merge, k.Delete, k.ForceDelete},
+		{k.Comments, k.Resolve, k.History, k.Output},
 		{k.Back, k.Quit, k.Help},
 	}
 }
@@ -223,13 +279,16 @@ func New(o *ops.Ops, repoName string) Model {
 	ti.TextStyle = normalStyle
 	ti.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("117"))
 
+	keys := DefaultKeyMap()
+	keys.Merge.SetEnabled(false)
+
 	return Model{
 		ops:       o,
 		repoName:  repoName,
 		view:      ViewMain,
 		spinner:   s,
 		help:      h,
-		keys:      DefaultKeyMap(),
+		keys:      keys,
 		loading:   true,
 		width:     80,
 		height:    24,
@@ -240,18 +299,53 @@ func New(o *ops.Ops, repoName string) Model {
 // Messages for async operations.
 
 type tracksLoadedMsg struct {
-	tracks []ops.TrackWithStatus
+	tracks    []ops.TrackWithStatus
+	rateLimit github.RateLimit
 }
 
 type remoteBranchesLoadedMsg struct {
 	branches []ops.RemoteBranch
 }
 
+type reviewRequestsLoadedMsg struct {
+	requests []github.ReviewRequest
+}
+
 type operationCompleteMsg struct {
 	message string
 	isError bool
 }
 
+type threadsLoadedMsg struct {
+	branch  string
+	threads []github.ReviewThread
+}
+
+type eventsLoadedMsg struct {
+	branch string
+	events []db.Event
+}
+
+type paneOutputMsg struct {
+	branch string
+	lines  []string
+}
+
+type deleteWarningsMsg struct {
+	branch   string
+	warnings []string
+}
+
+type threadResolvedMsg struct {
+	id string
+}
+
+type ciRerunMsg struct {
+	branch    string
+	workflows []string
+	err       error
+}
+
 type errMsg struct {
 	err error
 }
@@ -259,11 +353,14 @@ type errMsg struct {
 // Commands
 
 func (m Model) loadTracks() tea.Msg {
+	// Drop review tracks whose review has been submitted; failures just keep them around
+	_, _ = m.ops.CleanupReviewTracks()
+
 	tracks, err := m.ops.ListTracksWithStatus()
 	if err != nil {
 		return errMsg{err}
 	}
-	return tracksLoadedMsg{tracks}
+	return tracksLoadedMsg{tracks: tracks, rateLimit: m.ops.RateLimit()}
 }
 
 func (m Model) loadRemoteBranches() tea.Msg {
@@ -274,6 +371,77 @@ func (m Model) loadRemoteBranches() tea.Msg {
 	return remoteBranchesLoadedMsg{branches}
 }
 
+func (m Model) loadReviewRequests() tea.Msg {
+	requests, err := m.ops.ListReviewRequests()
+	if err != nil {
+		return errMsg{err}
+	}
+	return reviewRequestsLoadedMsg{requests}
+}
+
+func (m Model) loadThreads(branch string) tea.Cmd {
+	return func() tea.Msg {
+		threads, err := m.ops.ListReviewThreads(branch)
+		if err != nil {
+			return errMsg{err}
+		}
+		return threadsLoadedMsg{branch: branch, threads: threads}
+	}
+}
+
+// historyLimit is how many events the history pane shows.
+const historyLimit = 100
+
+func (m Model) loadEvents(branch string) tea.Cmd {
+	return func() tea.Msg {
+		events, err := m.ops.ListEvents(branch, historyLimit)
+		if err != nil {
+			return errMsg{err}
+		}
+		return eventsLoadedMsg{branch: branch, events: events}
+	}
+}
+
+// outputLines is how many lines of a track's main pane the output view shows.
+const outputLines = 30
+
+func (m Model) loadPaneOutput(branch string) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := m.ops.TailPane(branch, outputLines)
+		if err != nil {
+			return errMsg{err}
+		}
+		return paneOutputMsg{branch: branch, lines: lines}
+	}
+}
+
+func (m Model) loadDeleteWarnings(branch string) tea.Cmd {
+	return func() tea.Msg {
+		// Warnings are best-effort, the confirmation works without them
+		warnings, _ := m.ops.WindowWarnings(branch)
+		return deleteWarningsMsg{branch: branch, warnings: warnings}
+	}
+}
+
+func (m Model) openThread(branch string, thread github.ReviewThread) tea.Cmd {
+	return func() tea.Msg {
+		err := m.ops.OpenThreadInEditor(branch, thread)
+		if err != nil {
+			return operationCompleteMsg{message: err.Error(), isError: true}
+		}
+		return operationCompleteMsg{message: fmt.Sprintf("Opened %s", thread.Path), isError: false}
+	}
+}
+
+func (m Model) resolveThread(id string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.ops.ResolveReviewThread(id); err != nil {
+			return errMsg{err}
+		}
+		return threadResolvedMsg{id: id}
+	}
+}
+
 func (m Model) jumpToTrack(branch string) tea.Cmd {
 	return func() tea.Msg {
 		err := m.ops.JumpToTrack(branch)
@@ -300,17 +468,20 @@ func (m Model) syncTrack(branch string) tea.Cmd {
 		if result.PRCreated {
 			msg = fmt.Sprintf("Synced, PR #%d created", result.PRNumber)
 		}
+		if result.PRError != nil {
+			return operationCompleteMsg{message: "Synced, PR not created: " + result.PRError.Error(), isError: true}
+		}
 		return operationCompleteMsg{message: msg, isError: false}
 	}
 }
 
 func (m Model) deleteTrack(branch string) tea.Cmd {
 	return func() tea.Msg {
-		err := m.ops.DeleteTrack(branch, false)
+		err := m.ops.DeleteTrack(branch, ops.DeleteOptions{})
 		if err != nil {
 			return operationCompleteMsg{message: err.Error(), isError: true}
 		}
-		return operationCompleteMsg{message: fmt.Sprintf("Deleted %s", branch), isError: false}
+		return operationCompleteMsg{message: fmt.Sprintf("Deleted %s (run trak undo to restore it)", branch), isError: false}
 	}
 }
 
@@ -324,6 +495,27 @@ func (m Model) runAI(branch string) tea.Cmd {
 	}
 }
 
+func (m Model) rerunFailedCI(branch string) tea.Cmd {
+	return func() tea.Msg {
+		workflows, err := m.ops.RerunFailedCI(branch)
+		return ciRerunMsg{branch: branch, workflows: workflows, err: err}
+	}
+}
+
+func (m Model) mergeTrack(branch string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.ops.MergeTrack(branch, ops.MergeOptions{})
+		if err != nil {
+			return operationCompleteMsg{message: err.Error(), isError: true}
+		}
+		msg := fmt.Sprintf("Merged %s (%s)", branch, result.Method)
+		if result.CleanedUp {
+			msg += ", track deleted"
+		}
+		return operationCompleteMsg{message: msg, isError: false}
+	}
+}
+
 func (m Model) createTrackFromRemote(branch string) tea.Cmd {
 	return func() tea.Msg {
 		err := m.ops.NewTrackWorktree(branch)
@@ -334,6 +526,32 @@ func (m Model) createTrackFromRemote(branch string) tea.Cmd {
 	}
 }
 
+func (m Model) createTrackFromPR(ref string) tea.Cmd {
+	return func() tea.Msg {
+		branch, err := m.ops.NewTrackFromPR(ref)
+		if err != nil {
+			return operationCompleteMsg{message: err.Error(), isError: true}
+		}
+		return operationCompleteMsg{message: fmt.Sprintf("Created track for %s", branch), isError: false}
+	}
+}
+
+// isPRRef reports whether new track input refers to a PR ("#1234" or a PR URL)
+// rather than naming a branch.
+func isPRRef(input string) bool {
+	return strings.HasPrefix(input, "#") || strings.Contains(input, "://")
+}
+
+func (m Model) createReviewTrack(prNumber int) tea.Cmd {
+	return func() tea.Msg {
+		branch, err := m.ops.NewReviewTrack(prNumber)
+		if err != nil {
+			return operationCompleteMsg{message: err.Error(), isError: true}
+		}
+		return operationCompleteMsg{message: fmt.Sprintf("Created review track %s", branch), isError: false}
+	}
+}
+
 // Init initializes the model.
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
@@ -364,7 +582,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case key.Matches(msg, m.keys.Back):
-			if m.view == ViewRemoteBrowser || m.view == ViewNewTrack {
+			if m.view == ViewRemoteBrowser || m.view == ViewReviewQueue || m.view == ViewNewTrack {
 				m.view = ViewMain
 				m.textInput.Blur()
 				return m, nil
@@ -374,12 +592,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.pendingDeleteBranch = ""
 				return m, nil
 			}
+			if m.view == ViewComments {
+				m.view = ViewMain
+				m.threads = nil
+				m.commentsBranch = ""
+				return m, nil
+			}
+			if m.view == ViewHistory {
+				m.view = ViewMain
+				m.events = nil
+				m.historyBranch = ""
+				return m, nil
+			}
+			if m.view == ViewOutput {
+				m.view = ViewMain
+				m.output = nil
+				m.outputBranch = ""
+				return m, nil
+			}
 
 		case key.Matches(msg, m.keys.Refresh):
 			m.loading = true
 			if m.view == ViewRemoteBrowser {
 				return m, m.loadRemoteBranches
 			}
+			if m.view == ViewReviewQueue {
+				return m, m.loadReviewRequests
+			}
+			if m.view == ViewComments {
+				return m, m.loadThreads(m.commentsBranch)
+			}
+			if m.view == ViewHistory {
+				return m, m.loadEvents(m.historyBranch)
+			}
+			if m.view == ViewOutput {
+				return m, m.loadPaneOutput(m.outputBranch)
+			}
 			return m, m.loadTracks
 
 		case key.Matches(msg, m.keys.Browse):
@@ -389,6 +637,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.loadRemoteBranches
 			}
 
+		case key.Matches(msg, m.keys.Reviews):
+			if m.view == ViewMain {
+				m.view = ViewReviewQueue
+				m.loading = true
+				return m, m.loadReviewRequests
+			}
+
 		case key.Matches(msg, m.keys.New):
 			if m.view == ViewMain {
 				m.view = ViewNewTrack
@@ -404,6 +659,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.view = ViewMain
 					m.textInput.Blur()
 					m.loading = true
+					if isPRRef(branch) {
+						return m, m.createTrackFromPR(branch)
+					}
 					return m, m.createTrackFromRemote(branch)
 				}
 				return m, nil
@@ -414,6 +672,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.quitting = true
 					return m, tea.Sequence(m.jumpToTrack(branch), tea.Quit)
 				}
+			} else if m.view == ViewComments && len(m.threads) > 0 {
+				idx := m.commentsTable.Cursor()
+				if idx < len(m.threads) {
+					m.quitting = true
+					return m, tea.Sequence(m.openThread(m.commentsBranch, m.threads[idx]), tea.Quit)
+				}
 			} else if m.view == ViewRemoteBrowser && len(m.remoteBranches) > 0 {
 				idx := m.remoteTable.Cursor()
 				if idx < len(m.remoteBranches) {
@@ -421,6 +685,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.loading = true
 					return m, m.createTrackFromRemote(branch)
 				}
+			} else if m.view == ViewReviewQueue && len(m.reviewRequests) > 0 {
+				idx := m.reviewTable.Cursor()
+				if idx < len(m.reviewRequests) {
+					m.loading = true
+					return m, m.createReviewTrack(m.reviewRequests[idx].Number)
+				}
 			}
 
 		case key.Matches(msg, m.keys.Sync):
@@ -439,8 +709,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if idx < len(m.tracks) {
 					branch := m.tracks[idx].Track.Branch
 					m.pendingDeleteBranch = branch
+					m.pendingDeleteWarnings = nil
 					m.view = ViewDeleteConfirm
-					return m, nil
+					return m, m.loadDeleteWarnings(branch)
 				}
 			}
 
@@ -470,6 +741,74 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case key.Matches(msg, m.keys.Merge):
+			// Only enabled when the selected track is approved and passing CI
+			if m.view == ViewMain && len(m.tracks) > 0 {
+				idx := m.table.Cursor()
+				if idx < len(m.tracks) {
+					branch := m.tracks[idx].Track.Branch
+					m.loading = true
+					return m, m.mergeTrack(branch)
+				}
+			}
+
+		case key.Matches(msg, m.keys.Comments):
+			if m.view == ViewMain && len(m.tracks) > 0 {
+				idx := m.table.Cursor()
+				if idx < len(m.tracks) {
+					m.commentsBranch = m.tracks[idx].Track.Branch
+					m.threads = nil
+					m.commentsTable = m.buildCommentsTable()
+					m.view = ViewComments
+					m.loading = true
+					return m, m.loadThreads(m.commentsBranch)
+				}
+			}
+
+		case key.Matches(msg, m.keys.History):
+			if m.view == ViewMain && len(m.tracks) > 0 {
+				idx := m.table.Cursor()
+				if idx < len(m.tracks) {
+					m.historyBranch = m.tracks[idx].Track.Branch
+					m.events = nil
+					m.historyTable = m.buildHistoryTable()
+					m.view = ViewHistory
+					m.loading = true
+					return m, m.loadEvents(m.historyBranch)
+				}
+			}
+
+		case key.Matches(msg, m.keys.Output):
+			if m.view == ViewMain && len(m.tracks) > 0 {
+				idx := m.table.Cursor()
+				if idx < len(m.tracks) {
+					m.outputBranch = m.tracks[idx].Track.Branch
+					m.output = nil
+					m.view = ViewOutput
+					m.loading = true
+					return m, m.loadPaneOutput(m.outputBranch)
+				}
+			}
+
+		case key.Matches(msg, m.keys.Resolve):
+			if m.view == ViewComments && len(m.threads) > 0 {
+				idx := m.commentsTable.Cursor()
+				if idx < len(m.threads) {
+					m.loading = true
+					return m, m.resolveThread(m.threads[idx].ID)
+				}
+			}
+
+		case key.Matches(msg, m.keys.RerunCI):
+			if m.view == ViewMain && len(m.tracks) > 0 {
+				idx := m.table.Cursor()
+				if idx < len(m.tracks) {
+					branch := m.tracks[idx].Track.Branch
+					m.loading = true
+					return m, m.rerunFailedCI(branch)
+				}
+			}
+
 		case key.Matches(msg, m.keys.AI):
 			if m.view == ViewMain && len(m.tracks) > 0 {
 				idx := m.table.Cursor()
@@ -487,10 +826,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.help.Width = msg.Width
 		m.table = m.buildMainTable()
 		m.remoteTable = m.buildRemoteTable()
+		m.reviewTable = m.buildReviewTable()
+		m.commentsTable = m.buildCommentsTable()
+		m.historyTable = m.buildHistoryTable()
 
 	case tracksLoadedMsg:
 		m.loading = false
 		m.tracks = msg.tracks
+		m.rateLimit = msg.rateLimit
+		m.statusWarning = ops.StatusWarning(msg.tracks, msg.rateLimit)
 		m.table = m.buildMainTable()
 
 	case remoteBranchesLoadedMsg:
@@ -498,6 +842,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.remoteBranches = msg.branches
 		m.remoteTable = m.buildRemoteTable()
 
+	case reviewRequestsLoadedMsg:
+		m.loading = false
+		m.reviewRequests = msg.requests
+		m.reviewTable = m.buildReviewTable()
+
 	case operationCompleteMsg:
 		m.loading = false
 		m.notification = msg.message
@@ -514,6 +863,66 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.loadTracks
 		}
 
+	case threadsLoadedMsg:
+		m.loading = false
+		if msg.branch == m.commentsBranch {
+			m.threads = msg.threads
+			m.commentsTable = m.buildCommentsTable()
+		}
+
+	case paneOutputMsg:
+		m.loading = false
+		if msg.branch == m.outputBranch {
+			m.output = msg.lines
+		}
+
+	case deleteWarningsMsg:
+		if msg.branch == m.pendingDeleteBranch {
+			m.pendingDeleteWarnings = msg.warnings
+		}
+
+	case eventsLoadedMsg:
+		m.loading = false
+		if msg.branch == m.historyBranch {
+			m.events = msg.events
+			m.historyTable = m.buildHistoryTable()
+		}
+
+	case threadResolvedMsg:
+		m.loading = false
+		m.notification = "Comment resolved"
+		m.notifyTime = time.Now()
+		m.err = nil
+		for i, t := range m.threads {
+			if t.ID == msg.id {
+				m.threads = append(m.threads[:i:i], m.threads[i+1:]...)
+				break
+			}
+		}
+		m.commentsTable = m.buildCommentsTable()
+
+	case ciRerunMsg:
+		m.loading = false
+		m.notifyTime = time.Now()
+		if msg.err != nil {
+			m.err = msg.err
+			m.notification = msg.err.Error()
+			break
+		}
+		m.err = nil
+		if len(msg.workflows) == 0 {
+			m.notification = fmt.Sprintf("No failed CI runs for %s", msg.branch)
+			break
+		}
+		m.notification = fmt.Sprintf("Re-running %s for %s", strings.Join(msg.workflows, ", "), msg.branch)
+		// Show CI as pending right away; GitHub can lag behind the re-run request
+		for i := range m.tracks {
+			if m.tracks[i].Track.Branch == msg.branch {
+				m.tracks[i].Status.CI = &track.CIStatus{Pending: true}
+			}
+		}
+		m.table = m.buildMainTable()
+
 	case errMsg:
 		m.loading = false
 		m.err = msg.err
@@ -533,11 +942,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ViewRemoteBrowser:
 		m.remoteTable, cmd = m.remoteTable.Update(msg)
 		cmds = append(cmds, cmd)
+	case ViewReviewQueue:
+		m.reviewTable, cmd = m.reviewTable.Update(msg)
+		cmds = append(cmds, cmd)
 	case ViewNewTrack:
 		m.textInput, cmd = m.textInput.Update(msg)
 		cmds = append(cmds, cmd)
+	case ViewComments:
+		m.commentsTable, cmd = m.commentsTable.Update(msg)
+		cmds = append(cmds, cmd)
+	case ViewHistory:
+		m.historyTable, cmd = m.historyTable.Update(msg)
+		cmds = append(cmds, cmd)
 	}
 
+	m.keys.Merge.SetEnabled(m.selectedMergeable())
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -567,10 +987,18 @@ func (m Model) View() string {
 		b.WriteString(m.renderMainView())
 	case ViewRemoteBrowser:
 		b.WriteString(m.renderRemoteBrowserView())
+	case ViewReviewQueue:
+		b.WriteString(m.renderReviewQueueView())
 	case ViewNewTrack:
 		b.WriteString(m.renderNewTrackView())
 	case ViewDeleteConfirm:
 		b.WriteString(m.renderDeleteConfirmView())
+	case ViewComments:
+		b.WriteString(m.renderCommentsView())
+	case ViewHistory:
+		b.WriteString(m.renderHistoryView())
+	case ViewOutput:
+		b.WriteString(m.renderOutputView())
 	}
 
 	// Notification
@@ -583,6 +1011,12 @@ func (m Model) View() string {
 		b.WriteString(notifStyle.Render("  " + m.notification))
 	}
 
+	// API quota
+	if quota := m.renderQuota(); quota != "" {
+		b.WriteString("\n\n")
+		b.WriteString(quota)
+	}
+
 	// Help
 	b.WriteString("\n")
 	b.WriteString(helpStyle.Render(m.help.View(m.keys)))
@@ -590,13 +1024,45 @@ func (m Model) View() string {
 	return b.String()
 }
 
+// selectedMergeable reports whether the selected track's PR can be merged.
+func (m Model) selectedMergeable() bool {
+	if m.view != ViewMain || len(m.tracks) == 0 {
+		return false
+	}
+	idx := m.table.Cursor()
+	if idx < 0 || idx >= len(m.tracks) {
+		return false
+	}
+	return m.tracks[idx].Status.Mergeable()
+}
+
 func (m Model) renderMainView() string {
 	if len(m.tracks) == 0 {
 		return dimStyle.Render("  No tracks yet. Press 'n' to create one or 'b' to browse remote branches.")
 	}
+	if m.statusWarning != "" {
+		return errorStyle.Render("  ⚠ "+m.statusWarning) + "\n\n" + m.table.View()
+	}
 	return m.table.View()
 }
 
+// renderQuota renders the remaining GitHub API quota, or "" if unknown.
+// It turns red when less than a tenth of the quota is left.
+func (m Model) renderQuota() string {
+	rate := m.rateLimit
+	if rate.Limit == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("  API quota: %d/%d", rate.Remaining, rate.Limit)
+	if !rate.Reset.IsZero() {
+		text += fmt.Sprintf(", resets %s", rate.Reset.Local().Format("15:04"))
+	}
+	if rate.Remaining*10 < rate.Limit {
+		return errorStyle.Render(text)
+	}
+	return dimStyle.Render(text)
+}
+
 func (m Model) renderRemoteBrowserView() string {
 	var b strings.Builder
 	b.WriteString(infoStyle.Render("  Remote Branches (press enter to create track, esc to go back)"))
@@ -611,11 +1077,69 @@ func (m Model) renderRemoteBrowserView() string {
 	return b.String()
 }
 
+func (m Model) renderReviewQueueView() string {
+	var b strings.Builder
+	b.WriteString(infoStyle.Render("  Review Requests (press enter to check out for review, esc to go back)"))
+	b.WriteString("\n\n")
+
+	if len(m.reviewRequests) == 0 {
+		b.WriteString(dimStyle.Render("  No pull requests awaiting your review."))
+		return b.String()
+	}
+
+	b.WriteString(m.reviewTable.View())
+	return b.String()
+}
+
+func (m Model) renderCommentsView() string {
+	var b strings.Builder
+	b.WriteString(infoStyle.Render(fmt.Sprintf("  Review comments on %s (enter to open, x to resolve, esc to go back)", m.commentsBranch)))
+	b.WriteString("\n\n")
+
+	if len(m.threads) == 0 {
+		b.WriteString(dimStyle.Render("  No unresolved review comments."))
+		return b.String()
+	}
+
+	b.WriteString(m.commentsTable.View())
+	return b.String()
+}
+
+func (m Model) renderHistoryView() string {
+	var b strings.Builder
+	b.WriteString(infoStyle.Render(fmt.Sprintf("  History of %s (esc to go back)", m.historyBranch)))
+	b.WriteString("\n\n")
+
+	if len(m.events) == 0 {
+		b.WriteString(dimStyle.Render("  No history recorded yet."))
+		return b.String()
+	}
+
+	b.WriteString(m.historyTable.View())
+	return b.String()
+}
+
+func (m Model) renderOutputView() string {
+	var b strings.Builder
+	b.WriteString(infoStyle.Render(fmt.Sprintf("  Output of %s (r to refresh, esc to go back)", m.outputBranch)))
+	b.WriteString("\n\n")
+
+	if len(m.output) == 0 {
+		b.WriteString(dimStyle.Render("  No tmux window for this track."))
+		return b.String()
+	}
+
+	for _, line := range m.output {
+		b.WriteString("  " + line + "\n")
+	}
+	return b.String()
+}
+
 func (m Model) renderNewTrackView() string {
 	var b strings.Builder
 	b.WriteString(inputStyle.Render("  New Track"))
 	b.WriteString("\n\n")
-	b.WriteString(dimStyle.Render("  Enter a branch name to create a new worktree track."))
+	b.WriteString(dimStyle.Render("  Enter a branch name, or a PR (#1234 or URL), to create a new worktree track."))
 	b.WriteString("\n")
 	b.WriteString(dimStyle.Render("  Press Enter to create, Esc to cancel."))
 	b.WriteString("\n\n")
@@ -630,6 +1154,14 @@ func (m Model) renderDeleteConfirmView() string {
 	b.WriteString("\n\n")
 	b.WriteString(normalStyle.Render(fmt.Sprintf("  Delete track '%s'?", m.pendingDeleteBranch)))
 	b.WriteString("\n\n")
+	for _, w := range m.pendingDeleteWarnings {
+		b.WriteString(errorStyle.Render("  ⚠ " + w))
+		b.WriteString("\n")
+	}
+	if len(m.pendingDeleteWarnings) > 0 {
+		b.WriteString(dimStyle.Render("  The track's tmux window will be closed."))
+		b.WriteString("\n\n")
+	}
 	b.WriteString(dimStyle.Render("  Press "))
 	b.WriteString(inputStyle.Render("y"))
 	b.WriteString(dimStyle.Render(" to confirm, "))
@@ -648,6 +1180,7 @@ func (m Model) buildMainTable() table.Model {
 		{Title: "PR", Width: 6},
 		{Title: "CI", Width: 4},
 		{Title: "REVIEW", Width: 6},
+		{Title: "ISSUE", Width: 10},
 		{Title: "AGE", Width: 8},
 	}
 
@@ -655,12 +1188,17 @@ func (m Model) buildMainTable() table.Model {
 	for _, t := range m.tracks {
 		branch := truncate(t.Track.Branch, 25)
 		trackType := string(t.Track.Type)
+		if t.Track.Review {
+			trackType = "review"
+		}
 
 		gitStatus := t.Status.GitStatus.String()
 
 		prStr := "—"
 		if t.Status.PR != nil {
 			prStr = fmt.Sprintf("#%d", t.Status.PR.Number)
+		} else if t.Err != nil {
+			prStr = "!"
 		}
 
 		ciStr := "—"
@@ -673,9 +1211,14 @@ func (m Model) buildMainTable() table.Model {
 			reviewStr = t.Status.Review.Symbol()
 		}
 
+		issueStr := "—"
+		if t.Track.IssueRef != nil {
+			issueStr = truncate(*t.Track.IssueRef, 10)
+		}
+
 		age := formatAge(t.Track.CreatedAt)
 
-		rows = append(rows, table.Row{branch, trackType, gitStatus, prStr, ciStr, reviewStr, age})
+		rows = append(rows, table.Row{branch, trackType, gitStatus, prStr, ciStr, reviewStr, issueStr, age})
 	}
 
 	t := table.New(
@@ -731,6 +1274,121 @@ func (m Model) buildRemoteTable() table.Model {
 	return t
 }
 
+func (m Model) buildReviewTable() table.Model {
+	columns := []table.Column{
+		{Title: "PR", Width: 6},
+		{Title: "TITLE", Width: 40},
+		{Title: "AUTHOR", Width: 12},
+		{Title: "BRANCH", Width: 25},
+		{Title: "AGE", Width: 6},
+	}
+
+	rows := make([]table.Row, 0, len(m.reviewRequests))
+	for _, r := range m.reviewRequests {
+		title := r.Title
+		if r.Draft {
+			title = "[draft] " + title
+		}
+		branch := r.Branch
+		if r.IsFork {
+			branch = "fork:" + branch
+		}
+
+		age := "—"
+		if !r.UpdatedAt.IsZero() {
+			age = formatAge(r.UpdatedAt)
+		}
+
+		rows = append(rows, table.Row{fmt.Sprintf("#%d", r.Number), truncate(title, 40), truncate(r.Author, 12), truncate(branch, 25), age})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(minInt(len(rows)+1, m.height-12)),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = headerStyle
+	s.Selected = selectedStyle
+	s.Cell = normalStyle
+	t.SetStyles(s)
+
+	return t
+}
+
+func (m Model) buildCommentsTable() table.Model {
+	columns := []table.Column{
+		{Title: "LOCATION", Width: 30},
+		{Title: "AUTHOR", Width: 12},
+		{Title: "COMMENT", Width: 50},
+	}
+
+	rows := make([]table.Row, 0, len(m.threads))
+	for _, t := range m.threads {
+		location := t.Path
+		if t.Line > 0 {
+			location = fmt.Sprintf("%s:%d", t.Path, t.Line)
+		}
+		body := strings.Join(strings.Fields(t.Body), " ")
+		if t.Replies > 0 {
+			body = fmt.Sprintf("(+%d) %s", t.Replies, body)
+		}
+
+		rows = append(rows, table.Row{truncateLeft(location, 30), truncate(t.Author, 12), truncate(body, 50)})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(minInt(len(rows)+1, m.height-12)),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = headerStyle
+	s.Selected = selectedStyle
+	s.Cell = normalStyle
+	t.SetStyles(s)
+
+	return t
+}
+
+func (m Model) buildHistoryTable() table.Model {
+	columns := []table.Column{
+		{Title: "TIME", Width: 16},
+		{Title: "EVENT", Width: 10},
+		{Title: "SHA", Width: 15},
+		{Title: "DETAIL", Width: 50},
+	}
+
+	rows := make([]table.Row, 0, len(m.events))
+	for _, e := range m.events {
+		rows = append(rows, table.Row{
+			e.CreatedAt.Local().Format("2006-01-02 15:04"),
+			string(e.Kind),
+			ops.EventSHA(e),
+			truncate(e.Detail, 50),
+		})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(minInt(len(rows)+1, m.height-12)),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = headerStyle
+	s.Selected = selectedStyle
+	s.Cell = normalStyle
+	t.SetStyles(s)
+
+	return t
+}
+
 // Run starts the TUI.
 func Run(o *ops.Ops, repoName string) error {
 	m := New(o, repoName)
@@ -748,6 +1406,14 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// truncateLeft truncates a string from the left, keeping its end (useful for paths).
+func truncateLeft(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return "..." + s[len(s)-maxLen+3:]
+}
+
 func formatAge(t time.Time) string {
 	return formatDuration(time.Since(t))
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/laurent/trak/internal/db"
+	"github.com/laurent/trak/internal/github"
 	"github.com/laurent/trak/internal/ops"
 	"github.com/laurent/trak/internal/track"
 )
@@ -46,10 +47,16 @@ func TestDefaultKeyMap(t *testing.T) {
 		{"Enter", km.Enter},
 		{"Refresh", km.Refresh},
 		{"Browse", km.Browse},
+		{"Reviews", km.Reviews},
 		{"New", km.New},
 		{"Delete", km.Delete},
 		{"Sync", km.Sync},
 		{"AI", km.AI},
+		{"RerunCI", km.RerunCI},
+		{"Merge", km.Merge},
+		{"Comments", km.Comments},
+		{"Resolve", km.Resolve},
+		{"Output", km.Output},
 		{"Back", km.Back},
 		{"Quit", km.Quit},
 		{"Help", km.Help},
@@ -79,12 +86,12 @@ func TestKeyMapFullHelp(t *testing.T) {
 	km := DefaultKeyMap()
 	help := km.FullHelp()
 
-	if len(help) != 4 {
-		t.Errorf("expected 4 full help rows, got %d", len(help))
+	if len(help) != 5 {
+		t.Errorf("expected 5 full help rows, got %d", len(help))
 	}
 
-	// Row sizes: {Up, Down, Enter}, {Refresh, Browse, New}, {Sync, AI, Delete, ForceDelete}, {Back, Quit, Help}
-	expectedSizes := []int{3, 3, 4, 3}
+	// Row sizes: {Up, Down, Enter}, {Refresh, Browse, Reviews, New}, {Sync, AI, RerunCI, Merge, Delete, ForceDelete}, {Comments, Resolve, History, Output}, {Back, Quit, Help}
+	expectedSizes := []int{3, 4, 6, 4, 3}
 	for i, row := range help {
 		if len(row) != expectedSizes[i] {
 			t.Errorf("expected %d bindings in row %d, got %d", expectedSizes[i], i, len(row))
@@ -240,6 +247,43 @@ func TestModelUpdateTracksLoaded(t *testing.T) {
 	}
 }
 
+func TestModelUpdateTracksLoadedRateLimited(t *testing.T) {
+	m := New(nil, "test")
+
+	tracks := []ops.TrackWithStatus{
+		{
+			Track: db.Track{Branch: "feature-1", Type: db.TrackTypeWorktree, CreatedAt: time.Now()},
+			Err:   fmt.Errorf("failed to get PR status: %w", github.ErrRateLimited),
+		},
+	}
+	rate := github.RateLimit{Limit: 5000, Remaining: 0, Reset: time.Now().Add(time.Hour)}
+
+	newModel, _ := m.Update(tracksLoadedMsg{tracks: tracks, rateLimit: rate})
+	model := newModel.(Model)
+
+	if !strings.Contains(model.statusWarning, "rate limit exceeded") {
+		t.Errorf("expected rate limit banner, got %q", model.statusWarning)
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "rate limit exceeded") {
+		t.Error("expected banner in view")
+	}
+	if !strings.Contains(view, "API quota: 0/5000") {
+		t.Error("expected quota in footer")
+	}
+	if !strings.Contains(model.table.View(), "!") {
+		t.Error("expected PR column to flag the error")
+	}
+}
+
+func TestRenderQuotaUnknown(t *testing.T) {
+	m := New(nil, "test")
+	if got := m.renderQuota(); got != "" {
+		t.Errorf("expected no quota when unknown, got %q", got)
+	}
+}
+
 func TestModelUpdateRemoteBranchesLoaded(t *testing.T) {
 	m := New(nil, "test")
 	m.view = ViewRemoteBrowser
@@ -320,6 +364,60 @@ func TestModelUpdateBrowse(t *testing.T) {
 	}
 }
 
+func TestModelUpdateReviewQueue(t *testing.T) {
+	m := New(nil, "test")
+	m.loading = false
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+
+	model := newModel.(Model)
+	if model.view != ViewReviewQueue {
+		t.Error("expected view to switch to ViewReviewQueue")
+	}
+	if !model.loading {
+		t.Error("expected loading to be true when opening the review queue")
+	}
+	if cmd == nil {
+		t.Error("expected command to load review requests")
+	}
+
+	newModel, _ = model.Update(reviewRequestsLoadedMsg{requests: []github.ReviewRequest{
+		{Number: 12, Title: "Add widgets", Author: "alice", Branch: "widgets"},
+		{Number: 15, Title: "Fix typo", Author: "bob", Branch: "patch-1", IsFork: true, Draft: true},
+	}})
+	model = newModel.(Model)
+	if model.loading {
+		t.Error("expected loading to be false after requests loaded")
+	}
+	if len(model.reviewRequests) != 2 {
+		t.Fatalf("expected 2 review requests, got %d", len(model.reviewRequests))
+	}
+
+	view := model.View()
+	if !strings.Contains(view, "fork:patch-1") {
+		t.Error("expected fork PRs to be marked in the review queue")
+	}
+	if !strings.Contains(view, "[draft] Fix typo") {
+		t.Error("expected draft PRs to be marked in the review queue")
+	}
+
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	model = newModel.(Model)
+	if model.view != ViewMain {
+		t.Error("expected esc to return to the main view")
+	}
+}
+
+func TestRenderReviewQueueViewEmpty(t *testing.T) {
+	m := New(nil, "test")
+
+	view := m.renderReviewQueueView()
+
+	if !strings.Contains(view, "No pull requests awaiting your review") {
+		t.Error("expected empty state message")
+	}
+}
+
 func TestModelUpdateBack(t *testing.T) {
 	m := New(nil, "test")
 	m.view = ViewRemoteBrowser
@@ -451,6 +549,25 @@ func TestBuildMainTable(t *testing.T) {
 	}
 }
 
+func TestBuildMainTableShowsIssue(t *testing.T) {
+	m := New(nil, "test")
+	m.height = 30
+
+	ref := "PROJ-123"
+	m.tracks = []ops.TrackWithStatus{
+		{Track: db.Track{Branch: "PROJ-123-fix", Type: db.TrackTypeWorktree, CreatedAt: time.Now(), IssueRef: &ref}},
+	}
+
+	tbl := m.buildMainTable()
+	rows := tbl.Rows()
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	if rows[0][6] != "PROJ-123" {
+		t.Errorf("expected issue column 'PROJ-123', got %q", rows[0][6])
+	}
+}
+
 func TestBuildRemoteTable(t *testing.T) {
 	m := New(nil, "test")
 	m.height = 30
@@ -516,3 +633,266 @@ func TestMessagesTypes(t *testing.T) {
 	_ = operationCompleteMsg{message: "test", isError: false}
 	_ = errMsg{err: fmt.Errorf("test")}
 }
+
+func TestModelUpdateCIRerun(t *testing.T) {
+	m := New(nil, "test")
+	m.tracks = []ops.TrackWithStatus{
+		{
+			Track:  db.Track{Branch: "feature-1", Type: db.TrackTypeWorktree, CreatedAt: time.Now()},
+			Status: track.TrackStatus{CI: &track.CIStatus{Failing: true}},
+		},
+	}
+
+	newModel, _ := m.Update(ciRerunMsg{branch: "feature-1", workflows: []string{"CI"}})
+
+	model := newModel.(Model)
+	if model.loading {
+		t.Error("expected loading to be false after re-run")
+	}
+	if ci := model.tracks[0].Status.CI; ci == nil || !ci.Pending || ci.Failing {
+		t.Errorf("expected CI to switch to pending, got %+v", ci)
+	}
+	if !strings.Contains(model.notification, "CI") {
+		t.Errorf("unexpected notification: %q", model.notification)
+	}
+}
+
+func TestModelUpdateCIRerunError(t *testing.T) {
+	m := New(nil, "test")
+
+	newModel, _ := m.Update(ciRerunMsg{branch: "feature-1", err: fmt.Errorf("no PR")})
+
+	model := newModel.(Model)
+	if model.err == nil {
+		t.Error("expected error to be set")
+	}
+}
+
+func TestModelUpdateComments(t *testing.T) {
+	m := New(nil, "test")
+	m.loading = false
+	m.tracks = []ops.TrackWithStatus{
+		{Track: db.Track{Branch: "feature-1", Type: db.TrackTypeWorktree, CreatedAt: time.Now()}},
+	}
+	m.table = m.buildMainTable()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+
+	model := newModel.(Model)
+	if model.view != ViewComments {
+		t.Fatal("expected view to switch to ViewComments")
+	}
+	if model.commentsBranch != "feature-1" {
+		t.Errorf("expected comments branch 'feature-1', got %q", model.commentsBranch)
+	}
+	if cmd == nil {
+		t.Error("expected command to load review threads")
+	}
+
+	threads := []github.ReviewThread{
+		{ID: "T1", Path: "main.go", Line: 3, Author: "alice", Body: "nit"},
+		{ID: "T2", Path: "util.go", Line: 9, Author: "bob", Body: "why?"},
+	}
+	newModel, _ = model.Update(threadsLoadedMsg{branch: "feature-1", threads: threads})
+	model = newModel.(Model)
+	if len(model.threads) != 2 {
+		t.Fatalf("expected 2 threads, got %d", len(model.threads))
+	}
+
+	newModel, _ = model.Update(threadResolvedMsg{id: "T1"})
+	model = newModel.(Model)
+	if len(model.threads) != 1 || model.threads[0].ID != "T2" {
+		t.Errorf("expected only T2 to remain, got %+v", model.threads)
+	}
+
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	model = newModel.(Model)
+	if model.view != ViewMain {
+		t.Error("expected view to switch back to ViewMain")
+	}
+}
+
+func TestRenderCommentsViewEmpty(t *testing.T) {
+	m := New(nil, "test")
+	m.commentsBranch = "feature-1"
+
+	view := m.renderCommentsView()
+
+	if !strings.Contains(view, "No unresolved review comments") {
+		t.Error("expected empty state message")
+	}
+}
+
+func TestTruncateLeft(t *testing.T) {
+	if got := truncateLeft("internal/pkg/file.go:12", 12); got != "...ile.go:12" {
+		t.Errorf("truncateLeft() = %q", got)
+	}
+	if got := truncateLeft("short", 12); got != "short" {
+		t.Errorf("truncateLeft() = %q, want %q", got, "short")
+	}
+}
+
+func TestMergeKeyEnabledOnlyWhenMergeable(t *testing.T) {
+	m := New(nil, "test")
+
+	if m.keys.Merge.Enabled() {
+		t.Error("expected merge to be disabled initially")
+	}
+
+	ready := track.TrackStatus{
+		PR:     &track.PRStatus{Number: 1, State: "open"},
+		Review: &track.ReviewStatus{Approved: true},
+		CI:     &track.CIStatus{Passing: true},
+	}
+	tracks := []ops.TrackWithStatus{
+		{Track: db.Track{Branch: "ready", Type: db.TrackTypeWorktree, CreatedAt: time.Now()}, Status: ready},
+		{Track: db.Track{Branch: "wip", Type: db.TrackTypeWorktree, CreatedAt: time.Now()}},
+	}
+
+	newModel, _ := m.Update(tracksLoadedMsg{tracks: tracks})
+	model := newModel.(Model)
+	if !model.keys.Merge.Enabled() {
+		t.Error("expected merge to be enabled for an approved, passing track")
+	}
+
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model = newModel.(Model)
+	if model.keys.Merge.Enabled() {
+		t.Error("expected merge to be disabled for a track without a PR")
+	}
+
+	// Pressing m on a non-mergeable track does nothing
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	model = newModel.(Model)
+	if model.loading {
+		t.Error("expected merge not to start for a non-mergeable track")
+	}
+}
+
+func TestIsPRRef(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"#1234", true},
+		{"https://github.com/owner/repo/pull/1234", true},
+		{"feature/login", false},
+		{"1234-fix", false},
+	}
+	for _, tt := range tests {
+		if got := isPRRef(tt.input); got != tt.want {
+			t.Errorf("isPRRef(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestModelUpdateHistory(t *testing.T) {
+	m := New(nil, "test")
+	m.loading = false
+	m.tracks = []ops.TrackWithStatus{
+		{Track: db.Track{Branch: "feature-1", Type: db.TrackTypeWorktree, CreatedAt: time.Now()}},
+	}
+	m.table = m.buildMainTable()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+
+	model := newModel.(Model)
+	if model.view != ViewHistory {
+		t.Fatal("expected view to switch to ViewHistory")
+	}
+	if model.historyBranch != "feature-1" {
+		t.Errorf("expected history branch 'feature-1', got %q", model.historyBranch)
+	}
+	if cmd == nil {
+		t.Error("expected command to load events")
+	}
+
+	events := []db.Event{
+		{Branch: "feature-1", Kind: db.EventSync, Detail: "rebased", BeforeSHA: "aaaaaaaaaa", AfterSHA: "bbbbbbbbbb", CreatedAt: time.Now()},
+		{Branch: "feature-1", Kind: db.EventCreate, Detail: "worktree at /tmp/wt", CreatedAt: time.Now()},
+	}
+	newModel, _ = model.Update(eventsLoadedMsg{branch: "other", events: events})
+	model = newModel.(Model)
+	if len(model.events) != 0 {
+		t.Error("expected events for another branch to be ignored")
+	}
+
+	newModel, _ = model.Update(eventsLoadedMsg{branch: "feature-1", events: events})
+	model = newModel.(Model)
+	if len(model.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(model.events))
+	}
+	if view := model.renderHistoryView(); !strings.Contains(view, "aaaaaaa→bbbbbbb") {
+		t.Errorf("expected SHA change in history view, got:\n%s", view)
+	}
+
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	model = newModel.(Model)
+	if model.view != ViewMain {
+		t.Error("expected view to switch back to ViewMain")
+	}
+}
+
+func TestModelUpdateDeleteWarnings(t *testing.T) {
+	m := New(nil, "test")
+	m.loading = false
+	m.tracks = []ops.TrackWithStatus{
+		{Track: db.Track{Branch: "feature-1", Type: db.TrackTypeWorktree, CreatedAt: time.Now()}},
+	}
+	m.table = m.buildMainTable()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	model := newModel.(Model)
+	if model.view != ViewDeleteConfirm || model.pendingDeleteBranch != "feature-1" {
+		t.Fatalf("expected delete confirmation for feature-1, got view %v branch %q", model.view, model.pendingDeleteBranch)
+	}
+	if cmd == nil {
+		t.Error("expected command to load window warnings")
+	}
+
+	warning := "nvim is running in pane %1 and may have unsaved buffers"
+	newModel, _ = model.Update(deleteWarningsMsg{branch: "other", warnings: []string{warning}})
+	model = newModel.(Model)
+	if len(model.pendingDeleteWarnings) != 0 {
+		t.Error("expected warnings for another branch to be ignored")
+	}
+
+	newModel, _ = model.Update(deleteWarningsMsg{branch: "feature-1", warnings: []string{warning}})
+	model = newModel.(Model)
+	if view := model.renderDeleteConfirmView(); !strings.Contains(view, warning) {
+		t.Errorf("expected warning in delete confirmation, got:\n%s", view)
+	}
+}
+
+func TestModelUpdateOutput(t *testing.T) {
+	m := New(nil, "test")
+	m.loading = false
+	m.tracks = []ops.TrackWithStatus{
+		{Track: db.Track{Branch: "feature-1", Type: db.TrackTypeWorktree, CreatedAt: time.Now()}},
+	}
+	m.table = m.buildMainTable()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	model := newModel.(Model)
+	if model.view != ViewOutput || model.outputBranch != "feature-1" {
+		t.Fatalf("expected output view for feature-1, got view %v branch %q", model.view, model.outputBranch)
+	}
+	if cmd == nil {
+		t.Error("expected command to load pane output")
+	}
+	if view := model.renderOutputView(); !strings.Contains(view, "No tmux window") {
+		t.Errorf("expected empty output view, got:\n%s", view)
+	}
+
+	newModel, _ = model.Update(paneOutputMsg{branch: "feature-1", lines: []string{"PASS", "ok  \tgithub.com/laurent/trak"}})
+	model = newModel.(Model)
+	if view := model.renderOutputView(); !strings.Contains(view, "PASS") {
+		t.Errorf("expected pane output in view, got:\n%s", view)
+	}
+
+	newModel, _ = model.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	model = newModel.(Model)
+	if model.view != ViewMain || model.output != nil {
+		t.Error("expected esc to return to the main view and clear the output")
+	}
+}
@@ -0,0 +1,179 @@
+// Package zellij provides functions to interact with zellij via its CLI.
+package zellij
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// CommandRunner is the interface for running zellij commands.
+// This allows for mocking in tests.
+type CommandRunner interface {
+	Run(name string, args ...string) (string, error)
+	Exec(name string, args ...string) error
+}
+
+// DefaultRunner executes real zellij commands.
+type DefaultRunner struct{}
+
+// Run executes a command and returns its output.
+func (r *DefaultRunner) Run(name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("%s %s failed: %w\nstderr: %s", name, strings.Join(args, " "), err, stderr.String())
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Exec executes a command attached to the terminal (for attach).
+func (r *DefaultRunner) Exec(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// runner is the current command runner (can be swapped for testing).
+var runner CommandRunner = &DefaultRunner{}
+
+// SetRunner sets the command runner (for testing).
+func SetRunner(r CommandRunner) {
+	runner = r
+}
+
+// ResetRunner resets the command runner to the default.
+func ResetRunner() {
+	runner = &DefaultRunner{}
+}
+
+// action runs a zellij action in a session.
+func action(session string, args ...string) (string, error) {
+	return runner.Run("zellij", append([]string{"--session", session, "action"}, args...)...)
+}
+
+// SanitizeSessionName returns a valid session name: zellij names a socket after the
+// session, so it can't contain "/".
+func SanitizeSessionName(name string) string {
+	return strings.ReplaceAll(name, "/", "-")
+}
+
+// IsInsideZellij returns true if currently running inside zellij.
+func IsInsideZellij() bool {
+	return os.Getenv("ZELLIJ") != ""
+}
+
+// CurrentSession returns the session trak runs in, or "" outside zellij.
+func CurrentSession() string {
+	return os.Getenv("ZELLIJ_SESSION_NAME")
+}
+
+// ListSessions returns the names of all zellij sessions.
+func ListSessions() ([]string, error) {
+	output, err := runner.Run("zellij", "list-sessions", "--short", "--no-formatting")
+	if err != nil {
+		// No sessions is not an error for our purposes
+		if strings.Contains(err.Error(), "No active zellij sessions") {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	if output == "" {
+		return []string{}, nil
+	}
+
+	return strings.Split(output, "\n"), nil
+}
+
+// SessionExists checks if a zellij session with the given name exists.
+func SessionExists(name string) (bool, error) {
+	sessions, err := ListSessions()
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(sessions, name), nil
+}
+
+// CreateSession creates a new session in the background.
+func CreateSession(name string) error {
+	_, err := runner.Run("zellij", "attach", "--create-background", name)
+	return err
+}
+
+// AttachSession attaches to a session.
+// This should be used when not inside zellij.
+func AttachSession(session string) error {
+	return runner.Exec("zellij", "attach", session)
+}
+
+// NewTab creates a tab in a session with optional start directory.
+func NewTab(session, name, startDir string) error {
+	args := []string{"new-tab", "--name", name}
+	if startDir != "" {
+		args = append(args, "--cwd", startDir)
+	}
+	_, err := action(session, args...)
+	return err
+}
+
+// TabNames returns the names of the tabs of a session, in order.
+func TabNames(session string) ([]string, error) {
+	output, err := action(session, "query-tab-names")
+	if err != nil {
+		return nil, err
+	}
+
+	if output == "" {
+		return []string{}, nil
+	}
+
+	return strings.Split(output, "\n"), nil
+}
+
+// GoToTab makes a tab the focused tab of its session.
+func GoToTab(session, name string) error {
+	_, err := action(session, "go-to-tab-name", name)
+	return err
+}
+
+// RunInTab focuses a tab and types a command into its focused pane, followed by Enter.
+func RunInTab(session, name, command string) error {
+	if err := GoToTab(session, name); err != nil {
+		return err
+	}
+	if _, err := action(session, "write-chars", command); err != nil {
+		return err
+	}
+	// 13 is the carriage return Enter sends
+	_, err := action(session, "write", "13")
+	return err
+}
+
+// CloseTab closes a tab and everything running in it.
+func CloseTab(session, name string) error {
+	// close-tab only acts on the focused tab, and going to a missing tab leaves the
+	// focus where it was, so make sure the tab exists first
+	names, err := TabNames(session)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(names, name) {
+		return fmt.Errorf("session %s has no tab %s", session, name)
+	}
+	if err := GoToTab(session, name); err != nil {
+		return err
+	}
+	_, err = action(session, "close-tab")
+	return err
+}
@@ -0,0 +1,138 @@
+package zellij
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// MockRunner is a mock implementation of CommandRunner for testing.
+type MockRunner struct {
+	// RunFunc is called for Run() calls
+	RunFunc func(name string, args ...string) (string, error)
+	// Calls records the arguments of all calls made
+	Calls [][]string
+}
+
+func (m *MockRunner) Run(name string, args ...string) (string, error) {
+	m.Calls = append(m.Calls, args)
+	if m.RunFunc != nil {
+		return m.RunFunc(name, args...)
+	}
+	return "", nil
+}
+
+func (m *MockRunner) Exec(name string, args ...string) error {
+	m.Calls = append(m.Calls, args)
+	return nil
+}
+
+func TestListSessions(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		err     error
+		want    []string
+		wantErr bool
+	}{
+		{"sessions", "trak\nother", nil, []string{"trak", "other"}, false},
+		{"empty", "", nil, []string{}, false},
+		{"no sessions", "", errors.New("exit status 1\nstderr: No active zellij sessions found."), []string{}, false},
+		{"error", "", errors.New("exit status 2"), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetRunner(&MockRunner{RunFunc: func(name string, args ...string) (string, error) {
+				return tt.output, tt.err
+			}})
+			defer ResetRunner()
+
+			got, err := ListSessions()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListSessions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTab(t *testing.T) {
+	mock := &MockRunner{}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if err := NewTab("trak", "feature", "/wt"); err != nil {
+		t.Fatalf("NewTab failed: %v", err)
+	}
+	if err := NewTab("trak", "ci", ""); err != nil {
+		t.Fatalf("NewTab failed: %v", err)
+	}
+
+	want := [][]string{
+		{"--session", "trak", "action", "new-tab", "--name", "feature", "--cwd", "/wt"},
+		{"--session", "trak", "action", "new-tab", "--name", "ci"},
+	}
+	if !reflect.DeepEqual(mock.Calls, want) {
+		t.Errorf("unexpected calls:\ngot  %v\nwant %v", mock.Calls, want)
+	}
+}
+
+func TestRunInTab(t *testing.T) {
+	mock := &MockRunner{}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if err := RunInTab("trak", "feature", "make test"); err != nil {
+		t.Fatalf("RunInTab failed: %v", err)
+	}
+
+	want := [][]string{
+		{"--session", "trak", "action", "go-to-tab-name", "feature"},
+		{"--session", "trak", "action", "write-chars", "make test"},
+		{"--session", "trak", "action", "write", "13"},
+	}
+	if !reflect.DeepEqual(mock.Calls, want) {
+		t.Errorf("unexpected calls:\ngot  %v\nwant %v", mock.Calls, want)
+	}
+}
+
+func TestCloseTab(t *testing.T) {
+	mock := &MockRunner{RunFunc: func(name string, args ...string) (string, error) {
+		if args[3] == "query-tab-names" {
+			return "main\nfeature", nil
+		}
+		return "", nil
+	}}
+	SetRunner(mock)
+	defer ResetRunner()
+
+	if err := CloseTab("trak", "feature"); err != nil {
+		t.Fatalf("CloseTab failed: %v", err)
+	}
+	want := [][]string{
+		{"--session", "trak", "action", "query-tab-names"},
+		{"--session", "trak", "action", "go-to-tab-name", "feature"},
+		{"--session", "trak", "action", "close-tab"},
+	}
+	if !reflect.DeepEqual(mock.Calls, want) {
+		t.Errorf("unexpected calls:\ngot  %v\nwant %v", mock.Calls, want)
+	}
+
+	// Closing a missing tab would close whichever tab has the focus
+	mock.Calls = nil
+	if err := CloseTab("trak", "gone"); err == nil {
+		t.Error("expected an error for a missing tab")
+	}
+	if len(mock.Calls) != 1 {
+		t.Errorf("expected only the tabs to be listed, got %v", mock.Calls)
+	}
+}
+
+func TestSanitizeSessionName(t *testing.T) {
+	if got := SanitizeSessionName("trak-feature/login"); got != "trak-feature-login" {
+		t.Errorf("SanitizeSessionName() = %q", got)
+	}
+}